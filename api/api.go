@@ -0,0 +1,76 @@
+// Package api holds what the server and client packages share about the HTTP API: the request and error bodies
+// and the code identifying each sentinel error, so the client can map responses back without importing the server.
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/pokt-foundation/portal-db/driver"
+	"github.com/pokt-foundation/portal-db/types"
+)
+
+var (
+	ErrInvalidBody      = errors.New("invalid request body")
+	ErrInvalidQuery     = errors.New("invalid query parameter")
+	ErrUnknownRoute     = errors.New("unknown route")
+	ErrMethodNotAllowed = errors.New("method not allowed")
+
+	// ErrorCodes holds the status and code of every sentinel error a request may fail with, other errors are internal
+	ErrorCodes = []ErrorCode{
+		{driver.ErrMissingID, http.StatusBadRequest, "MISSING_ID"},
+		{driver.ErrLBMustHaveUser, http.StatusBadRequest, "LB_MUST_HAVE_USER"},
+		{driver.ErrCannotSetToOwner, http.StatusBadRequest, "CANNOT_SET_TO_OWNER"},
+		{driver.ErrUserInputIsMissingField, http.StatusBadRequest, "USER_INPUT_IS_MISSING_FIELD"},
+		{types.ErrNoFieldsToUpdate, http.StatusBadRequest, "NO_FIELDS_TO_UPDATE"},
+		{types.ErrInvalidAppStatus, http.StatusBadRequest, "INVALID_APP_STATUS"},
+		{types.ErrInvalidPayPlanType, http.StatusBadRequest, "INVALID_PAY_PLAN_TYPE"},
+		{types.ErrNotEnterprisePlan, http.StatusBadRequest, "NOT_ENTERPRISE_PLAN"},
+		{types.ErrEnterprisePlanNeedsCustomLimit, http.StatusBadRequest, "ENTERPRISE_PLAN_NEEDS_CUSTOM_LIMIT"},
+		{types.ErrInvalidPageLimit, http.StatusBadRequest, "INVALID_PAGE_LIMIT"},
+		{types.ErrInvalidBufferSize, http.StatusBadRequest, "INVALID_BUFFER_SIZE"},
+		{types.ErrInvalidOverflowPolicy, http.StatusBadRequest, "INVALID_OVERFLOW_POLICY"},
+		{types.ErrInvalidDomain, http.StatusBadRequest, "INVALID_DOMAIN"},
+		{ErrInvalidBody, http.StatusBadRequest, "INVALID_BODY"},
+		{ErrInvalidQuery, http.StatusBadRequest, "INVALID_QUERY"},
+		{driver.ErrNotFound, http.StatusNotFound, "NOT_FOUND"},
+		{ErrUnknownRoute, http.StatusNotFound, "UNKNOWN_ROUTE"},
+		{ErrMethodNotAllowed, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED"},
+		{driver.ErrAlreadyExists, http.StatusConflict, "ALREADY_EXISTS"},
+		{driver.ErrBlockchainInUse, http.StatusConflict, "BLOCKCHAIN_IN_USE"},
+		{driver.ErrConflict, http.StatusConflict, "CONFLICT"},
+		{driver.ErrInvalidReference, http.StatusUnprocessableEntity, "INVALID_REFERENCE"},
+		{driver.ErrListenerClosed, http.StatusServiceUnavailable, "LISTENER_CLOSED"},
+	}
+)
+
+type (
+	/* ErrorResponse is the body of every failed request, Code is empty for internal errors */
+	ErrorResponse struct {
+		Error string `json:"error"`
+		Code  string `json:"code,omitempty"`
+	}
+
+	/* ErrorCode is the status and code a sentinel error is returned with */
+	ErrorCode struct {
+		Err    error
+		Status int
+		Code   string
+	}
+
+	/* ActivateChainRequest is the body of a request to ActivateChain */
+	ActivateChainRequest struct {
+		Active bool `json:"active"`
+	}
+)
+
+/* ErrorFromCode returns the sentinel error identified by the code of an ErrorResponse, or nil for unknown codes */
+func ErrorFromCode(code string) error {
+	for _, e := range ErrorCodes {
+		if e.Code == code {
+			return e.Err
+		}
+	}
+
+	return nil
+}
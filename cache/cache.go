@@ -0,0 +1,337 @@
+// Package cache provides an in-memory driver.Reader kept current by the notifications of another one
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pokt-foundation/portal-db/driver"
+	"github.com/pokt-foundation/portal-db/types"
+)
+
+var (
+	ErrMissingID = driver.ErrMissingID
+	ErrNotFound  = driver.ErrNotFound
+)
+
+type (
+	// Cache is a driver.Reader serving Applications, LoadBalancers, Blockchains, PayPlans and user roles from memory.
+	// It bootstraps from a source Reader and applies its notifications, reloading everything on ActionResync.
+	// PayPlans have no notifications and are only refreshed by reloads, user roles follow the user_access notifications.
+	// Pages, events and subscriptions are read from the source.
+	// Returned entities are shared with the cache and must not be modified
+	Cache struct {
+		source       driver.Reader
+		errorHandler func(error)
+		snapshotPath string
+
+		mu    sync.RWMutex
+		state *state
+
+		cancel context.CancelFunc
+		done   chan struct{}
+	}
+
+	Option func(*Cache)
+)
+
+/* WithErrorHandler sets the function called when restoring the cache or reloading it after a resync fails */
+func WithErrorHandler(handler func(error)) Option {
+	return func(c *Cache) {
+		c.errorHandler = handler
+	}
+}
+
+// New loads every Application, LoadBalancer and Blockchain from source and keeps them current until ctx is done or Close is called.
+// The subscription starts before the load so no change is missed, notifications already reflected by it are applied again harmlessly
+func New(ctx context.Context, source driver.Reader, options ...Option) (*Cache, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	c := &Cache{
+		source:       source,
+		errorHandler: func(error) {},
+		cancel:       cancel,
+		done:         make(chan struct{}),
+	}
+	for _, option := range options {
+		option(c)
+	}
+
+	notifications, err := source.Subscribe(ctx, nil)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	if err := c.load(ctx); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	go c.follow(ctx, notifications)
+
+	return c, nil
+}
+
+/* Close stops following the source's notifications, the cache keeps serving its last state */
+func (c *Cache) Close() {
+	c.cancel()
+	<-c.done
+}
+
+/* Done is closed once the cache stopped following the source's notifications */
+func (c *Cache) Done() <-chan struct{} {
+	return c.done
+}
+
+func (c *Cache) follow(ctx context.Context, notifications <-chan *types.Notification) {
+	defer close(c.done)
+
+	for {
+		var n *types.Notification
+		select {
+		case <-ctx.Done():
+			return
+		case notification, ok := <-notifications:
+			if !ok {
+				return
+			}
+			n = notification
+		}
+
+		if n.Action == types.ActionResync {
+			if err := c.reload(ctx); err != nil && ctx.Err() == nil {
+				c.errorHandler(fmt.Errorf("reloading cache: %w", err))
+			}
+			continue
+		}
+
+		c.mu.Lock()
+		c.state.apply(n)
+		resolved := c.state.resolvesRole(n)
+		c.mu.Unlock()
+
+		// the permissions of a role no cached user has are only known by reloading the user roles
+		if !resolved {
+			if err := c.reloadUserRoles(ctx); err != nil && ctx.Err() == nil {
+				c.errorHandler(fmt.Errorf("reloading user roles: %w", err))
+			}
+		}
+	}
+}
+
+/* load sets the initial state from the snapshot file if any, falling back to the source */
+func (c *Cache) load(ctx context.Context) error {
+	if c.snapshotPath != "" {
+		err := c.restore(ctx)
+		if err == nil {
+			return nil
+		}
+		c.errorHandler(fmt.Errorf("restoring cache from %s: %w", c.snapshotPath, err))
+	}
+
+	return c.reload(ctx)
+}
+
+/* reload replaces the cached state with the source's current one */
+func (c *Cache) reload(ctx context.Context) error {
+	data := &snapshotData{}
+
+	var err error
+	if data.Applications, err = c.source.ReadApplications(ctx); err != nil {
+		return err
+	}
+	if data.LoadBalancers, err = c.source.ReadLoadBalancers(ctx); err != nil {
+		return err
+	}
+	if data.Blockchains, err = c.source.ReadBlockchains(ctx); err != nil {
+		return err
+	}
+	if data.PayPlans, err = c.source.ReadPayPlans(ctx); err != nil {
+		return err
+	}
+	if data.UserRoles, err = c.source.ReadUserRoles(ctx); err != nil {
+		return err
+	}
+
+	s := newState(data)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.state != nil {
+		s.sequence = c.state.sequence
+	}
+	c.state = s
+
+	return nil
+}
+
+/* reloadUserRoles replaces the cached user roles with the source's current ones */
+func (c *Cache) reloadUserRoles(ctx context.Context) error {
+	userRoles, err := c.source.ReadUserRoles(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.state.setUserRoles(userRoles)
+
+	return nil
+}
+
+/* Snapshot returns a consistent view of the cache that later notifications do not change */
+func (c *Cache) Snapshot() *Snapshot {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return &Snapshot{state: c.state.clone()}
+}
+
+/* Sequence returns the sequence of the last notification applied */
+func (c *Cache) Sequence() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.state.sequence
+}
+
+/* Cached reads */
+
+func (c *Cache) ReadApplications(ctx context.Context) ([]*types.Application, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return applications(c.state), nil
+}
+
+func (c *Cache) ReadLoadBalancers(ctx context.Context) ([]*types.LoadBalancer, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return loadBalancers(c.state), nil
+}
+
+func (c *Cache) ReadBlockchains(ctx context.Context) ([]*types.Blockchain, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return blockchains(c.state), nil
+}
+
+func (c *Cache) ReadApplication(ctx context.Context, id string) (*types.Application, error) {
+	if id == "" {
+		return nil, ErrMissingID
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return application(c.state, id)
+}
+
+func (c *Cache) ReadLoadBalancer(ctx context.Context, id string) (*types.LoadBalancer, error) {
+	if id == "" {
+		return nil, ErrMissingID
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return loadBalancer(c.state, id)
+}
+
+func (c *Cache) ReadBlockchain(ctx context.Context, id string) (*types.Blockchain, error) {
+	if id == "" {
+		return nil, ErrMissingID
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return blockchain(c.state, id)
+}
+
+/* ReadApplicationByPublicKey returns the app whose gateway AAT has the given application public key */
+func (c *Cache) ReadApplicationByPublicKey(ctx context.Context, publicKey string) (*types.Application, error) {
+	if publicKey == "" {
+		return nil, ErrMissingID
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return applicationByPublicKey(c.state, publicKey)
+}
+
+/* ReadBlockchainByAlias returns the blockchain with the given alias or ID */
+func (c *Cache) ReadBlockchainByAlias(ctx context.Context, alias string) (*types.Blockchain, error) {
+	if alias == "" {
+		return nil, ErrMissingID
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return blockchainByAlias(c.state, alias)
+}
+
+func (c *Cache) ReadPayPlans(ctx context.Context) ([]*types.PayPlan, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.state.payPlans, nil
+}
+
+func (c *Cache) ReadUserRoles(ctx context.Context) (map[string]map[string][]types.PermissionsEnum, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.state.userRoles, nil
+}
+
+/* Reads served by the source */
+
+func (c *Cache) ReadApplicationsPage(ctx context.Context, options *types.QueryOptions) (*types.ApplicationsPage, error) {
+	return c.source.ReadApplicationsPage(ctx, options)
+}
+
+func (c *Cache) ReadLoadBalancersPage(ctx context.Context, options *types.QueryOptions) (*types.LoadBalancersPage, error) {
+	return c.source.ReadLoadBalancersPage(ctx, options)
+}
+
+func (c *Cache) ReadRedirects(ctx context.Context, filter *types.RedirectFilter) ([]*types.Redirect, error) {
+	return c.source.ReadRedirects(ctx, filter)
+}
+
+func (c *Cache) ReadApplicationsUpdatedSince(ctx context.Context, since time.Time) ([]*types.Application, error) {
+	return c.source.ReadApplicationsUpdatedSince(ctx, since)
+}
+
+func (c *Cache) ReadLoadBalancersUpdatedSince(ctx context.Context, since time.Time) ([]*types.LoadBalancer, error) {
+	return c.source.ReadLoadBalancersUpdatedSince(ctx, since)
+}
+
+func (c *Cache) ReadBlockchainsUpdatedSince(ctx context.Context, since time.Time) ([]*types.Blockchain, error) {
+	return c.source.ReadBlockchainsUpdatedSince(ctx, since)
+}
+
+func (c *Cache) ReadUserRolesUpdatedSince(ctx context.Context, since time.Time) (map[string]map[string][]types.PermissionsEnum, error) {
+	return c.source.ReadUserRolesUpdatedSince(ctx, since)
+}
+
+func (c *Cache) ReadEventsSince(ctx context.Context, sequence int64) ([]*types.Notification, error) {
+	return c.source.ReadEventsSince(ctx, sequence)
+}
+
+func (c *Cache) NotificationChannel() <-chan *types.Notification {
+	return c.source.NotificationChannel()
+}
+
+func (c *Cache) Subscribe(ctx context.Context, options *types.SubscriptionOptions) (<-chan *types.Notification, error) {
+	return c.source.Subscribe(ctx, options)
+}
@@ -0,0 +1,280 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pokt-foundation/portal-db/driver"
+	"github.com/pokt-foundation/portal-db/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var _ driver.Reader = &Cache{}
+
+func testSource(notifications chan *types.Notification) *driver.MockDriver {
+	source := &driver.MockDriver{}
+
+	source.On("Subscribe", mock.Anything, (*types.SubscriptionOptions)(nil)).Return((<-chan *types.Notification)(notifications), nil)
+	source.On("ReadApplications", mock.Anything).Return([]*types.Application{
+		{
+			ID:         "app_1",
+			Name:       "pokt_app_1",
+			GatewayAAT: types.GatewayAAT{ApplicationPublicKey: "public_key_1"},
+			Limit:      types.AppLimit{PayPlan: types.PayPlan{Type: types.FreetierV0, Limit: 250000}},
+		},
+	}, nil)
+	source.On("ReadLoadBalancers", mock.Anything).Return([]*types.LoadBalancer{
+		{
+			ID:             "lb_1",
+			Name:           "pokt_lb_1",
+			ApplicationIDs: []string{"app_1"},
+			StickyOptions:  types.StickyOptions{Duration: "40", StickyMax: 300},
+			Users:          []types.UserAccess{{UserID: "user_1", RoleName: types.RoleOwner}},
+		},
+	}, nil)
+	source.On("ReadBlockchains", mock.Anything).Return([]*types.Blockchain{
+		{
+			ID:                "0001",
+			Blockchain:        "pokt-mainnet",
+			BlockchainAliases: []string{"pokt-mainnet"},
+			SyncCheckOptions:  types.SyncCheckOptions{BlockchainID: "0001", Allowance: 1},
+		},
+	}, nil)
+	source.On("ReadPayPlans", mock.Anything).Return([]*types.PayPlan{
+		{Type: types.FreetierV0, Limit: 250000},
+		{Type: types.PayAsYouGoV0, Limit: 0},
+		{Type: types.Enterprise, Limit: 1000000},
+	}, nil)
+	source.On("ReadUserRoles", mock.Anything).Return(map[string]map[string][]types.PermissionsEnum{
+		"user_1": {"lb_1": {types.ReadEndpoint, types.WriteEndpoint}},
+	}, nil)
+
+	return source
+}
+
+/* waitForSequence waits until the cache applied the notification with the given sequence */
+func waitForSequence(t *testing.T, c *Cache, sequence int64) {
+	t.Helper()
+
+	require.Eventually(t, func() bool { return c.Sequence() >= sequence }, time.Second, time.Millisecond)
+}
+
+func TestCache(t *testing.T) {
+	c := require.New(t)
+	ctx := context.Background()
+
+	notifications := make(chan *types.Notification)
+	cache, err := New(ctx, testSource(notifications))
+	c.NoError(err)
+	defer cache.Close()
+
+	app, err := cache.ReadApplicationByPublicKey(ctx, "public_key_1")
+	c.NoError(err)
+	c.Equal("app_1", app.ID)
+
+	chain, err := cache.ReadBlockchainByAlias(ctx, "pokt-mainnet")
+	c.NoError(err)
+	c.Equal("0001", chain.ID)
+
+	snapshot := cache.Snapshot()
+
+	notifications <- &types.Notification{Sequence: 1, Table: types.TableApplications, Action: types.ActionUpdate,
+		Data: &types.Application{ID: "app_1", Name: "pokt_app_renamed"},
+	}
+	notifications <- &types.Notification{Sequence: 2, Table: types.TableGatewayAAT, Action: types.ActionUpdate,
+		Data: &types.GatewayAAT{ID: "app_1", ApplicationPublicKey: "public_key_2"},
+	}
+	notifications <- &types.Notification{Sequence: 3, Table: types.TableApplications, Action: types.ActionInsert,
+		Data: &types.Application{ID: "app_2", Name: "pokt_app_2"},
+	}
+	notifications <- &types.Notification{Sequence: 4, Table: types.TableLbApps, Action: types.ActionInsert,
+		Data: &types.LbApp{LbID: "lb_1", AppID: "app_2"},
+	}
+	notifications <- &types.Notification{Sequence: 5, Table: types.TableUserAccess, Action: types.ActionUpdate,
+		Data:     &types.UserAccess{ID: "lb_1", UserID: "user_1", RoleName: types.RoleAdmin},
+		Previous: &types.UserAccess{ID: "lb_1", UserID: "user_1", RoleName: types.RoleOwner},
+	}
+	notifications <- &types.Notification{Sequence: 6, Table: types.TableRedirects, Action: types.ActionInsert,
+		Data: &types.Redirect{BlockchainID: "0001", Alias: "pokt-mainnet", Domain: "pokt.network", LoadBalancerID: "lb_1"},
+	}
+	notifications <- &types.Notification{Sequence: 7, Table: types.TableBlockchains, Action: types.ActionUpdate,
+		Data: &types.Blockchain{ID: "0001", Blockchain: "pokt-mainnet", BlockchainAliases: []string{"mainnet"}},
+	}
+	waitForSequence(t, cache, 7)
+
+	app, err = cache.ReadApplication(ctx, "app_1")
+	c.NoError(err)
+	c.Equal("pokt_app_renamed", app.Name)
+	c.Equal(types.FreetierV0, app.Limit.PayPlan.Type)
+	c.Equal(types.GatewayAAT{ApplicationPublicKey: "public_key_2"}, app.GatewayAAT)
+
+	_, err = cache.ReadApplicationByPublicKey(ctx, "public_key_1")
+	c.ErrorIs(err, ErrNotFound)
+	app, err = cache.ReadApplicationByPublicKey(ctx, "public_key_2")
+	c.NoError(err)
+	c.Equal("app_1", app.ID)
+
+	lb, err := cache.ReadLoadBalancer(ctx, "lb_1")
+	c.NoError(err)
+	c.Equal([]string{"app_1", "app_2"}, lb.ApplicationIDs)
+	c.Equal([]types.UserAccess{{UserID: "user_1", RoleName: types.RoleAdmin}}, lb.Users)
+	c.Equal("40", lb.StickyOptions.Duration)
+
+	chain, err = cache.ReadBlockchainByAlias(ctx, "mainnet")
+	c.NoError(err)
+	c.Len(chain.Redirects, 1)
+	c.Equal(1, chain.SyncCheckOptions.Allowance)
+	_, err = cache.ReadBlockchainByAlias(ctx, "pokt-mainnet")
+	c.ErrorIs(err, ErrNotFound)
+
+	apps, err := cache.ReadApplications(ctx)
+	c.NoError(err)
+	c.Len(apps, 2)
+
+	// the snapshot taken before is not affected
+	c.Equal(int64(0), snapshot.Sequence())
+	app, err = snapshot.Application("app_1")
+	c.NoError(err)
+	c.Equal("pokt_app_1", app.Name)
+	c.Len(snapshot.Applications(), 1)
+
+	notifications <- &types.Notification{Sequence: 8, Table: types.TableAppLimits, Action: types.ActionUpdate,
+		Data: &types.AppLimit{ID: "app_1", PayPlan: types.PayPlan{Type: types.Enterprise}, CustomLimit: 5000},
+	}
+	waitForSequence(t, cache, 8)
+
+	app, err = cache.ReadApplication(ctx, "app_1")
+	c.NoError(err)
+	c.Equal(types.AppLimit{PayPlan: types.PayPlan{Type: types.Enterprise, Limit: 1000000}, CustomLimit: 5000}, app.Limit)
+
+	notifications <- &types.Notification{Sequence: 9, Table: types.TableApplications, Action: types.ActionDelete,
+		Data: &types.Application{ID: "app_1"},
+	}
+	waitForSequence(t, cache, 9)
+
+	_, err = cache.ReadApplication(ctx, "app_1")
+	c.ErrorIs(err, ErrNotFound)
+	_, err = cache.ReadApplicationByPublicKey(ctx, "public_key_2")
+	c.ErrorIs(err, ErrNotFound)
+	_, err = cache.ReadApplication(ctx, "")
+	c.ErrorIs(err, ErrMissingID)
+}
+
+func TestCacheUserRoles(t *testing.T) {
+	c := require.New(t)
+	ctx := context.Background()
+
+	notifications := make(chan *types.Notification)
+	source := testSource(notifications)
+
+	cache, err := New(ctx, source)
+	c.NoError(err)
+	defer cache.Close()
+
+	// the permissions of a role a cached user has are resolved without reading the source
+	notifications <- &types.Notification{Sequence: 1, Table: types.TableUserAccess, Action: types.ActionInsert,
+		Data: &types.UserAccess{ID: "lb_1", UserID: "user_2", RoleName: types.RoleOwner},
+	}
+	notifications <- &types.Notification{Sequence: 2, Table: types.TableUserAccess, Action: types.ActionDelete,
+		Data: &types.UserAccess{ID: "lb_1", UserID: "user_1", RoleName: types.RoleOwner},
+	}
+	waitForSequence(t, cache, 2)
+
+	userRoles, err := cache.ReadUserRoles(ctx)
+	c.NoError(err)
+	c.Equal(map[string]map[string][]types.PermissionsEnum{
+		"user_2": {"lb_1": {types.ReadEndpoint, types.WriteEndpoint}},
+	}, userRoles, "the removed user loses their roles without a resync")
+	source.AssertNumberOfCalls(t, "ReadUserRoles", 1)
+
+	// those of a role no cached user has are reloaded
+	reloaded := map[string]map[string][]types.PermissionsEnum{
+		"user_2": {"lb_1": {types.ReadEndpoint, types.WriteEndpoint}},
+		"user_3": {"lb_1": {types.ReadEndpoint}},
+	}
+	for _, call := range source.ExpectedCalls {
+		if call.Method == "ReadUserRoles" {
+			call.Return(reloaded, nil)
+		}
+	}
+
+	notifications <- &types.Notification{Sequence: 3, Table: types.TableUserAccess, Action: types.ActionInsert,
+		Data: &types.UserAccess{ID: "lb_1", UserID: "user_3", RoleName: types.RoleMember},
+	}
+	waitForSequence(t, cache, 3)
+
+	c.Eventually(func() bool {
+		userRoles, err := cache.ReadUserRoles(ctx)
+		return err == nil && len(userRoles["user_3"]["lb_1"]) == 1
+	}, time.Second, time.Millisecond)
+
+	notifications <- &types.Notification{Sequence: 4, Table: types.TableUserAccess, Action: types.ActionInsert,
+		Data: &types.UserAccess{ID: "lb_1", UserID: "user_4", RoleName: types.RoleMember},
+	}
+	waitForSequence(t, cache, 4)
+
+	userRoles, err = cache.ReadUserRoles(ctx)
+	c.NoError(err)
+	c.Equal([]types.PermissionsEnum{types.ReadEndpoint}, userRoles["user_4"]["lb_1"], "the reloaded role is known")
+	source.AssertNumberOfCalls(t, "ReadUserRoles", 2)
+}
+
+func TestCacheResync(t *testing.T) {
+	c := require.New(t)
+	ctx := context.Background()
+
+	notifications := make(chan *types.Notification)
+	source := testSource(notifications)
+
+	cache, err := New(ctx, source)
+	c.NoError(err)
+	defer cache.Close()
+
+	notifications <- &types.Notification{Sequence: 1, Table: types.TableApplications, Action: types.ActionDelete,
+		Data: &types.Application{ID: "app_1"},
+	}
+	waitForSequence(t, cache, 1)
+	c.Empty(cache.Snapshot().Applications())
+
+	notifications <- &types.Notification{Action: types.ActionResync}
+	c.Eventually(func() bool { return len(cache.Snapshot().Applications()) == 1 }, time.Second, time.Millisecond)
+	c.Equal(int64(1), cache.Sequence())
+
+	source.AssertNumberOfCalls(t, "ReadApplications", 2)
+}
+
+func TestCacheErrors(t *testing.T) {
+	c := require.New(t)
+	ctx := context.Background()
+	errRead := errors.New("read failed")
+
+	source := &driver.MockDriver{}
+	source.On("Subscribe", mock.Anything, (*types.SubscriptionOptions)(nil)).Return((<-chan *types.Notification)(make(chan *types.Notification)), nil)
+	source.On("ReadApplications", mock.Anything).Return(nil, errRead)
+
+	_, err := New(ctx, source)
+	c.ErrorIs(err, errRead)
+
+	notifications := make(chan *types.Notification)
+	source = testSource(notifications)
+
+	reported := make(chan error, 1)
+	cache, err := New(ctx, source, WithErrorHandler(func(err error) { reported <- err }))
+	c.NoError(err)
+
+	source.ExpectedCalls = nil
+	source.On("ReadApplications", mock.Anything).Return(nil, errRead)
+
+	notifications <- &types.Notification{Action: types.ActionResync}
+	c.ErrorIs(<-reported, errRead)
+
+	// the cache keeps serving its last state
+	_, err = cache.ReadApplication(ctx, "app_1")
+	c.NoError(err)
+
+	close(notifications)
+	<-cache.Done()
+}
@@ -0,0 +1,277 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pokt-foundation/portal-db/types"
+)
+
+/* SnapshotVersion is the version of the snapshot file format written by WriteTo */
+const SnapshotVersion = 1
+
+var (
+	ErrSnapshotVersion  = errors.New("unsupported snapshot version")
+	ErrSnapshotChecksum = errors.New("snapshot checksum mismatch")
+)
+
+type (
+	/* snapshotFile is the file format of a Snapshot, Checksum is the hex SHA-256 of Data */
+	snapshotFile struct {
+		Version  int             `json:"version"`
+		Checksum string          `json:"checksum"`
+		Data     json.RawMessage `json:"data"`
+	}
+
+	// snapshotData holds everything the cache serves from memory.
+	// Sequence is the last event applied and UpdatedAt the latest update time among the entities,
+	// they are the positions a restored cache catches up from
+	snapshotData struct {
+		Sequence      int64                                         `json:"sequence"`
+		UpdatedAt     time.Time                                     `json:"updatedAt"`
+		Applications  []*types.Application                          `json:"applications"`
+		LoadBalancers []*types.LoadBalancer                         `json:"loadBalancers"`
+		Blockchains   []*types.Blockchain                           `json:"blockchains"`
+		PayPlans      []*types.PayPlan                              `json:"payPlans"`
+		UserRoles     map[string]map[string][]types.PermissionsEnum `json:"userRoles"`
+	}
+)
+
+func (s *state) data() *snapshotData {
+	data := &snapshotData{
+		Sequence:      s.sequence,
+		Applications:  applications(s),
+		LoadBalancers: loadBalancers(s),
+		Blockchains:   blockchains(s),
+		PayPlans:      s.payPlans,
+		UserRoles:     s.userRoles,
+	}
+
+	for _, app := range data.Applications {
+		data.UpdatedAt = latest(data.UpdatedAt, app.UpdatedAt)
+	}
+	for _, lb := range data.LoadBalancers {
+		data.UpdatedAt = latest(data.UpdatedAt, lb.UpdatedAt)
+	}
+	for _, blockchain := range data.Blockchains {
+		data.UpdatedAt = latest(data.UpdatedAt, blockchain.UpdatedAt)
+	}
+
+	return data
+}
+
+func latest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+
+	return a
+}
+
+/* WriteTo writes the snapshot in the versioned and checksummed snapshot file format */
+func (s *Snapshot) WriteTo(w io.Writer) (int64, error) {
+	data, err := json.Marshal(s.state.data())
+	if err != nil {
+		return 0, err
+	}
+
+	checksum := sha256.Sum256(data)
+	file, err := json.Marshal(snapshotFile{
+		Version:  SnapshotVersion,
+		Checksum: hex.EncodeToString(checksum[:]),
+		Data:     data,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := w.Write(file)
+	return int64(n), err
+}
+
+/* ReadSnapshot reads a snapshot written by WriteTo, verifying its version and checksum */
+func ReadSnapshot(r io.Reader) (*Snapshot, error) {
+	var file snapshotFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("decoding snapshot: %w", err)
+	}
+
+	if file.Version != SnapshotVersion {
+		return nil, fmt.Errorf("%w: %d", ErrSnapshotVersion, file.Version)
+	}
+
+	checksum := sha256.Sum256(file.Data)
+	if hex.EncodeToString(checksum[:]) != file.Checksum {
+		return nil, ErrSnapshotChecksum
+	}
+
+	var data snapshotData
+	if err := json.Unmarshal(file.Data, &data); err != nil {
+		return nil, fmt.Errorf("decoding snapshot data: %w", err)
+	}
+
+	return &Snapshot{state: newState(&data)}, nil
+}
+
+// WriteSnapshotFile writes a snapshot of the cache to path.
+// The file is written next to it first and then renamed so readers never see a partial one,
+// it is only readable by its owner as it holds the apps' secrets
+func (c *Cache) WriteSnapshotFile(path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := c.Snapshot().WriteTo(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+/* ReadSnapshotFile reads a snapshot written by WriteSnapshotFile */
+func ReadSnapshotFile(path string) (*Snapshot, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ReadSnapshot(file)
+}
+
+// WithSnapshotFile starts the cache from the snapshot at path instead of reading every table from the source.
+// It then catches up with the events after the snapshot's sequence or, when it holds none,
+// with the entities updated after its latest update, which misses deletions until the next resync.
+// User roles are caught up by update time and pay plans read again.
+// A missing or invalid file falls back to reading every table and is reported to the error handler
+func WithSnapshotFile(path string) Option {
+	return func(c *Cache) {
+		c.snapshotPath = path
+	}
+}
+
+/* restore sets the cache's state from its snapshot file and catches up with the source */
+func (c *Cache) restore(ctx context.Context) error {
+	snapshot, err := ReadSnapshotFile(c.snapshotPath)
+	if err != nil {
+		return err
+	}
+
+	s := snapshot.state
+	if err := c.catchUp(ctx, s, s.data()); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.state = s
+
+	return nil
+}
+
+/* catchUp applies the changes made after the snapshot's position to s */
+func (c *Cache) catchUp(ctx context.Context, s *state, position *snapshotData) error {
+	if position.Sequence > 0 {
+		events, err := c.source.ReadEventsSince(ctx, position.Sequence)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			s.apply(event)
+		}
+	} else {
+		applications, err := c.source.ReadApplicationsUpdatedSince(ctx, position.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		loadBalancers, err := c.source.ReadLoadBalancersUpdatedSince(ctx, position.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		blockchains, err := c.source.ReadBlockchainsUpdatedSince(ctx, position.UpdatedAt)
+		if err != nil {
+			return err
+		}
+
+		for _, app := range applications {
+			s.setApplication(app)
+		}
+		for _, lb := range loadBalancers {
+			s.loadBalancers[lb.ID] = lb
+		}
+		for _, blockchain := range blockchains {
+			s.setBlockchain(blockchain)
+		}
+	}
+
+	userRoles, err := c.source.ReadUserRolesUpdatedSince(ctx, position.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	s.setUserRoles(mergeUserRoles(s.userRoles, userRoles))
+
+	s.payPlans, err = c.source.ReadPayPlans(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// mergeUserRoles returns a copy of userRoles where the roles of every load balancer present in updated are replaced by its ones.
+// Both are keyed by user ID and then load balancer ID, like ReadUserRoles. Load balancers left without users are in updated
+// under an empty user ID, which is not kept
+func mergeUserRoles(userRoles, updated map[string]map[string][]types.PermissionsEnum) map[string]map[string][]types.PermissionsEnum {
+	updatedLBs := make(map[string]bool)
+	for _, roles := range updated {
+		for lbID := range roles {
+			updatedLBs[lbID] = true
+		}
+	}
+
+	merged := make(map[string]map[string][]types.PermissionsEnum, len(userRoles)+len(updated))
+	add := func(userID, lbID string, permissions []types.PermissionsEnum) {
+		if merged[userID] == nil {
+			merged[userID] = make(map[string][]types.PermissionsEnum)
+		}
+		merged[userID][lbID] = permissions
+	}
+
+	for userID, roles := range userRoles {
+		for lbID, permissions := range roles {
+			if !updatedLBs[lbID] {
+				add(userID, lbID, permissions)
+			}
+		}
+	}
+	for userID, roles := range updated {
+		if userID == "" {
+			continue
+		}
+		for lbID, permissions := range roles {
+			add(userID, lbID, permissions)
+		}
+	}
+
+	return merged
+}
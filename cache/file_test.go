@@ -0,0 +1,161 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pokt-foundation/portal-db/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotFile(t *testing.T) {
+	c := require.New(t)
+	ctx := context.Background()
+
+	notifications := make(chan *types.Notification)
+	cache, err := New(ctx, testSource(notifications))
+	c.NoError(err)
+	defer cache.Close()
+
+	notifications <- &types.Notification{Sequence: 3, Table: types.TableApplications, Action: types.ActionInsert,
+		Data: &types.Application{ID: "app_2", Name: "pokt_app_2", UpdatedAt: time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	waitForSequence(t, cache, 3)
+
+	path := filepath.Join(t.TempDir(), "portal-db.snapshot")
+	c.NoError(cache.WriteSnapshotFile(path))
+
+	info, err := os.Stat(path)
+	c.NoError(err)
+	c.Equal(os.FileMode(0600), info.Mode().Perm())
+
+	snapshot, err := ReadSnapshotFile(path)
+	c.NoError(err)
+	c.Equal(int64(3), snapshot.Sequence())
+	c.Equal(cache.Snapshot().Applications(), snapshot.Applications())
+	c.Equal(cache.Snapshot().LoadBalancers(), snapshot.LoadBalancers())
+	c.Equal(cache.Snapshot().Blockchains(), snapshot.Blockchains())
+	c.Equal(cache.Snapshot().PayPlans(), snapshot.PayPlans())
+	c.Equal(cache.Snapshot().UserRoles(), snapshot.UserRoles())
+
+	app, err := snapshot.ApplicationByPublicKey("public_key_1")
+	c.NoError(err)
+	c.Equal("app_1", app.ID)
+}
+
+func TestReadSnapshotErrors(t *testing.T) {
+	c := require.New(t)
+
+	snapshot := &Snapshot{state: newState(&snapshotData{Sequence: 1})}
+
+	var buf bytes.Buffer
+	_, err := snapshot.WriteTo(&buf)
+	c.NoError(err)
+
+	var file snapshotFile
+	c.NoError(json.Unmarshal(buf.Bytes(), &file))
+
+	corrupted := file
+	corrupted.Data = json.RawMessage(`{"sequence":2}`)
+	raw, err := json.Marshal(corrupted)
+	c.NoError(err)
+	_, err = ReadSnapshot(bytes.NewReader(raw))
+	c.ErrorIs(err, ErrSnapshotChecksum)
+
+	future := file
+	future.Version = SnapshotVersion + 1
+	raw, err = json.Marshal(future)
+	c.NoError(err)
+	_, err = ReadSnapshot(bytes.NewReader(raw))
+	c.ErrorIs(err, ErrSnapshotVersion)
+
+	restored, err := ReadSnapshot(&buf)
+	c.NoError(err)
+	c.Equal(int64(1), restored.Sequence())
+}
+
+func TestWithSnapshotFile(t *testing.T) {
+	c := require.New(t)
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "portal-db.snapshot")
+
+	notifications := make(chan *types.Notification)
+	cache, err := New(ctx, testSource(notifications))
+	c.NoError(err)
+
+	notifications <- &types.Notification{Sequence: 3, Table: types.TableApplications, Action: types.ActionInsert,
+		Data: &types.Application{ID: "app_2", Name: "pokt_app_2"},
+	}
+	waitForSequence(t, cache, 3)
+	c.NoError(cache.WriteSnapshotFile(path))
+	cache.Close()
+
+	// a warm start only reads the events after the snapshot, the updated user roles and the pay plans
+	source := testSource(make(chan *types.Notification))
+	source.On("ReadEventsSince", mock.Anything, int64(3)).Return([]*types.Notification{
+		{Sequence: 4, Table: types.TableApplications, Action: types.ActionDelete, Data: &types.Application{ID: "app_1"}},
+	}, nil)
+	source.On("ReadUserRolesUpdatedSince", mock.Anything, time.Time{}).Return(map[string]map[string][]types.PermissionsEnum{
+		"user_2": {"lb_1": {types.ReadEndpoint}},
+	}, nil)
+
+	restored, err := New(ctx, source, WithSnapshotFile(path))
+	c.NoError(err)
+	defer restored.Close()
+
+	source.AssertNotCalled(t, "ReadApplications", mock.Anything)
+	source.AssertNotCalled(t, "ReadUserRoles", mock.Anything)
+	source.AssertCalled(t, "ReadPayPlans", mock.Anything)
+
+	c.Equal(int64(4), restored.Sequence())
+	apps, err := restored.ReadApplications(ctx)
+	c.NoError(err)
+	c.Len(apps, 1)
+	c.Equal("app_2", apps[0].ID)
+
+	userRoles, err := restored.ReadUserRoles(ctx)
+	c.NoError(err)
+	c.Equal(map[string]map[string][]types.PermissionsEnum{
+		"user_2": {"lb_1": {types.ReadEndpoint}},
+	}, userRoles)
+
+	// a missing snapshot falls back to reading every table
+	reported := make(chan error, 1)
+	cold, err := New(ctx, testSource(make(chan *types.Notification)),
+		WithSnapshotFile(filepath.Join(t.TempDir(), "missing")),
+		WithErrorHandler(func(err error) { reported <- err }),
+	)
+	c.NoError(err)
+	defer cold.Close()
+
+	c.ErrorIs(<-reported, os.ErrNotExist)
+	apps, err = cold.ReadApplications(ctx)
+	c.NoError(err)
+	c.Len(apps, 1)
+}
+
+func TestMergeUserRoles(t *testing.T) {
+	c := require.New(t)
+
+	merged := mergeUserRoles(
+		map[string]map[string][]types.PermissionsEnum{
+			"user_1": {"lb_1": {types.ReadEndpoint}, "lb_2": {types.ReadEndpoint}, "lb_3": {types.ReadEndpoint}},
+		},
+		map[string]map[string][]types.PermissionsEnum{
+			"user_2": {"lb_1": {types.ReadEndpoint, types.WriteEndpoint}},
+			// lb_3's last user was removed
+			"": {"lb_3": nil},
+		},
+	)
+
+	c.Equal(map[string]map[string][]types.PermissionsEnum{
+		"user_1": {"lb_2": {types.ReadEndpoint}},
+		"user_2": {"lb_1": {types.ReadEndpoint, types.WriteEndpoint}},
+	}, merged)
+}
@@ -0,0 +1,135 @@
+package cache
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pokt-foundation/portal-db/types"
+)
+
+/* Snapshot is a read-only view of the cache at a single point of its notification stream */
+type Snapshot struct {
+	state *state
+}
+
+/* Sequence returns the sequence of the last notification reflected by the snapshot */
+func (s *Snapshot) Sequence() int64 {
+	return s.state.sequence
+}
+
+func (s *Snapshot) Applications() []*types.Application {
+	return applications(s.state)
+}
+
+func (s *Snapshot) LoadBalancers() []*types.LoadBalancer {
+	return loadBalancers(s.state)
+}
+
+func (s *Snapshot) Blockchains() []*types.Blockchain {
+	return blockchains(s.state)
+}
+
+func (s *Snapshot) PayPlans() []*types.PayPlan {
+	return s.state.payPlans
+}
+
+func (s *Snapshot) UserRoles() map[string]map[string][]types.PermissionsEnum {
+	return s.state.userRoles
+}
+
+func (s *Snapshot) Application(id string) (*types.Application, error) {
+	return application(s.state, id)
+}
+
+func (s *Snapshot) LoadBalancer(id string) (*types.LoadBalancer, error) {
+	return loadBalancer(s.state, id)
+}
+
+func (s *Snapshot) Blockchain(id string) (*types.Blockchain, error) {
+	return blockchain(s.state, id)
+}
+
+func (s *Snapshot) ApplicationByPublicKey(publicKey string) (*types.Application, error) {
+	return applicationByPublicKey(s.state, publicKey)
+}
+
+func (s *Snapshot) BlockchainByAlias(alias string) (*types.Blockchain, error) {
+	return blockchainByAlias(s.state, alias)
+}
+
+/* Lookups shared by Cache and Snapshot, lists are sorted by ID */
+
+func applications(s *state) []*types.Application {
+	apps := make([]*types.Application, 0, len(s.applications))
+	for _, app := range s.applications {
+		apps = append(apps, app)
+	}
+	sort.Slice(apps, func(i, j int) bool { return apps[i].ID < apps[j].ID })
+
+	return apps
+}
+
+func loadBalancers(s *state) []*types.LoadBalancer {
+	lbs := make([]*types.LoadBalancer, 0, len(s.loadBalancers))
+	for _, lb := range s.loadBalancers {
+		lbs = append(lbs, lb)
+	}
+	sort.Slice(lbs, func(i, j int) bool { return lbs[i].ID < lbs[j].ID })
+
+	return lbs
+}
+
+func blockchains(s *state) []*types.Blockchain {
+	chains := make([]*types.Blockchain, 0, len(s.blockchains))
+	for _, blockchain := range s.blockchains {
+		chains = append(chains, blockchain)
+	}
+	sort.Slice(chains, func(i, j int) bool { return chains[i].ID < chains[j].ID })
+
+	return chains
+}
+
+func application(s *state, id string) (*types.Application, error) {
+	app, ok := s.applications[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: application %s", ErrNotFound, id)
+	}
+
+	return app, nil
+}
+
+func loadBalancer(s *state, id string) (*types.LoadBalancer, error) {
+	lb, ok := s.loadBalancers[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: load balancer %s", ErrNotFound, id)
+	}
+
+	return lb, nil
+}
+
+func blockchain(s *state, id string) (*types.Blockchain, error) {
+	chain, ok := s.blockchains[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: blockchain %s", ErrNotFound, id)
+	}
+
+	return chain, nil
+}
+
+func applicationByPublicKey(s *state, publicKey string) (*types.Application, error) {
+	id, ok := s.publicKeys[publicKey]
+	if !ok {
+		return nil, fmt.Errorf("%w: application with public key %s", ErrNotFound, publicKey)
+	}
+
+	return s.applications[id], nil
+}
+
+func blockchainByAlias(s *state, alias string) (*types.Blockchain, error) {
+	chain, ok := s.blockchainByAlias(alias)
+	if !ok {
+		return nil, fmt.Errorf("%w: blockchain %s", ErrNotFound, alias)
+	}
+
+	return chain, nil
+}
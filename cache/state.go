@@ -0,0 +1,447 @@
+package cache
+
+import (
+	"reflect"
+
+	"github.com/pokt-foundation/portal-db/types"
+)
+
+// state holds the cached entities and their indexes.
+// Entities, pay plans and user roles are never modified in place, changes replace them with updated copies
+// so a copy of the maps is a consistent snapshot.
+type state struct {
+	sequence      int64
+	applications  map[string]*types.Application
+	loadBalancers map[string]*types.LoadBalancer
+	blockchains   map[string]*types.Blockchain
+	payPlans      []*types.PayPlan
+	userRoles     map[string]map[string][]types.PermissionsEnum
+
+	// rolePermissions holds the permissions of the roles the loaded users have, to resolve the roles of user_access notifications
+	rolePermissions map[types.RoleName][]types.PermissionsEnum
+
+	// aliases maps blockchain aliases to blockchain IDs and publicKeys gateway public keys to application IDs
+	aliases    map[string]string
+	publicKeys map[string]string
+}
+
+func newState(data *snapshotData) *state {
+	s := &state{
+		sequence:      data.Sequence,
+		applications:  make(map[string]*types.Application, len(data.Applications)),
+		loadBalancers: make(map[string]*types.LoadBalancer, len(data.LoadBalancers)),
+		blockchains:   make(map[string]*types.Blockchain, len(data.Blockchains)),
+		payPlans:      data.PayPlans,
+		aliases:       make(map[string]string),
+		publicKeys:    make(map[string]string, len(data.Applications)),
+	}
+
+	for _, app := range data.Applications {
+		s.setApplication(app)
+	}
+	for _, lb := range data.LoadBalancers {
+		s.loadBalancers[lb.ID] = lb
+	}
+	for _, blockchain := range data.Blockchains {
+		s.setBlockchain(blockchain)
+	}
+	s.setUserRoles(data.UserRoles)
+
+	return s
+}
+
+/* setUserRoles replaces the user roles and learns the permissions of every role a cached load balancer user has */
+func (s *state) setUserRoles(userRoles map[string]map[string][]types.PermissionsEnum) {
+	s.userRoles = userRoles
+	s.rolePermissions = make(map[types.RoleName][]types.PermissionsEnum)
+
+	for _, lb := range s.loadBalancers {
+		for _, user := range lb.Users {
+			if permissions, ok := userRoles[user.UserID][lb.ID]; ok {
+				s.rolePermissions[user.RoleName] = permissions
+			}
+		}
+	}
+}
+
+func (s *state) clone() *state {
+	c := &state{
+		sequence:      s.sequence,
+		applications:  make(map[string]*types.Application, len(s.applications)),
+		loadBalancers: make(map[string]*types.LoadBalancer, len(s.loadBalancers)),
+		blockchains:   make(map[string]*types.Blockchain, len(s.blockchains)),
+		payPlans:      s.payPlans,
+		userRoles:     s.userRoles,
+		aliases:       make(map[string]string, len(s.aliases)),
+		publicKeys:    make(map[string]string, len(s.publicKeys)),
+
+		rolePermissions: s.rolePermissions,
+	}
+
+	for id, app := range s.applications {
+		c.applications[id] = app
+	}
+	for id, lb := range s.loadBalancers {
+		c.loadBalancers[id] = lb
+	}
+	for id, blockchain := range s.blockchains {
+		c.blockchains[id] = blockchain
+	}
+	for alias, id := range s.aliases {
+		c.aliases[alias] = id
+	}
+	for publicKey, id := range s.publicKeys {
+		c.publicKeys[publicKey] = id
+	}
+
+	return c
+}
+
+/* setApplication stores the app replacing its previous version and updating the public key index */
+func (s *state) setApplication(app *types.Application) {
+	s.removeApplication(app.ID)
+
+	s.applications[app.ID] = app
+	if publicKey := app.GatewayAAT.ApplicationPublicKey; publicKey != "" {
+		s.publicKeys[publicKey] = app.ID
+	}
+}
+
+func (s *state) removeApplication(id string) {
+	cached, ok := s.applications[id]
+	if !ok {
+		return
+	}
+
+	if s.publicKeys[cached.GatewayAAT.ApplicationPublicKey] == id {
+		delete(s.publicKeys, cached.GatewayAAT.ApplicationPublicKey)
+	}
+	delete(s.applications, id)
+}
+
+/* setBlockchain stores the blockchain replacing its previous version and updating the alias index */
+func (s *state) setBlockchain(blockchain *types.Blockchain) {
+	s.removeBlockchain(blockchain.ID)
+
+	s.blockchains[blockchain.ID] = blockchain
+	for _, alias := range blockchain.BlockchainAliases {
+		s.aliases[alias] = blockchain.ID
+	}
+}
+
+func (s *state) removeBlockchain(id string) {
+	cached, ok := s.blockchains[id]
+	if !ok {
+		return
+	}
+
+	for _, alias := range cached.BlockchainAliases {
+		if s.aliases[alias] == id {
+			delete(s.aliases, alias)
+		}
+	}
+	delete(s.blockchains, id)
+}
+
+/* blockchainByAlias returns the blockchain with the given alias, falling back to its ID */
+func (s *state) blockchainByAlias(alias string) (*types.Blockchain, bool) {
+	if id, ok := s.aliases[alias]; ok {
+		return s.blockchains[id], true
+	}
+
+	blockchain, ok := s.blockchains[alias]
+	return blockchain, ok
+}
+
+/* apply updates the state with the notification, notifications of entities that are not cached are ignored */
+func (s *state) apply(n *types.Notification) {
+	if n.Sequence > s.sequence {
+		s.sequence = n.Sequence
+	}
+
+	deleted := n.Action == types.ActionDelete
+
+	switch data := n.Data.(type) {
+	case *types.Application:
+		if deleted {
+			s.removeApplication(data.ID)
+			return
+		}
+		s.setApplication(mergeApplication(s.applications[data.ID], data))
+
+	case *types.AppLimit:
+		s.updateApplication(data.ID, func(app *types.Application) {
+			app.Limit = types.AppLimit{}
+			if !deleted {
+				app.Limit, app.Limit.ID = *data, ""
+				app.Limit.PayPlan = s.payPlan(data.PayPlan.Type)
+			}
+		})
+	case *types.GatewayAAT:
+		s.updateApplication(data.ID, func(app *types.Application) {
+			app.GatewayAAT = types.GatewayAAT{}
+			if !deleted {
+				app.GatewayAAT, app.GatewayAAT.ID = *data, ""
+			}
+		})
+	case *types.GatewaySettings:
+		s.updateApplication(data.ID, func(app *types.Application) {
+			app.GatewaySettings = types.GatewaySettings{}
+			if !deleted {
+				app.GatewaySettings, app.GatewaySettings.ID = *data, ""
+			}
+		})
+	case *types.NotificationSettings:
+		s.updateApplication(data.ID, func(app *types.Application) {
+			app.NotificationSettings = types.NotificationSettings{}
+			if !deleted {
+				app.NotificationSettings, app.NotificationSettings.ID = *data, ""
+			}
+		})
+
+	case *types.LoadBalancer:
+		if deleted {
+			delete(s.loadBalancers, data.ID)
+			return
+		}
+		s.loadBalancers[data.ID] = mergeLoadBalancer(s.loadBalancers[data.ID], data)
+
+	case *types.StickyOptions:
+		s.updateLoadBalancer(data.ID, func(lb *types.LoadBalancer) {
+			lb.StickyOptions = types.StickyOptions{}
+			if !deleted {
+				lb.StickyOptions, lb.StickyOptions.ID = *data, ""
+			}
+		})
+	case *types.UserAccess:
+		userID := data.UserID
+		if previous, ok := n.Previous.(*types.UserAccess); ok {
+			userID = previous.UserID
+		}
+		s.updateLoadBalancer(data.ID, func(lb *types.LoadBalancer) {
+			users := make([]types.UserAccess, 0, len(lb.Users)+1)
+			for _, user := range lb.Users {
+				if user.UserID != userID && user.UserID != data.UserID {
+					users = append(users, user)
+				}
+			}
+			if !deleted {
+				user := *data
+				user.ID = ""
+				users = append(users, user)
+			}
+			lb.Users = users
+		})
+
+		s.removeUserRole(userID, data.ID)
+		s.removeUserRole(data.UserID, data.ID)
+		if permissions, ok := s.rolePermissions[data.RoleName]; ok && !deleted {
+			s.setUserRole(data.UserID, data.ID, permissions)
+		}
+	case *types.LbApp:
+		s.updateLoadBalancer(data.LbID, func(lb *types.LoadBalancer) {
+			appIDs := make([]string, 0, len(lb.ApplicationIDs)+1)
+			for _, appID := range lb.ApplicationIDs {
+				if appID != data.AppID {
+					appIDs = append(appIDs, appID)
+				}
+			}
+			if !deleted {
+				appIDs = append(appIDs, data.AppID)
+			}
+			lb.ApplicationIDs = appIDs
+		})
+
+	case *types.Blockchain:
+		if deleted {
+			s.removeBlockchain(data.ID)
+			return
+		}
+		s.setBlockchain(mergeBlockchain(s.blockchains[data.ID], data))
+
+	case *types.SyncCheckOptions:
+		s.updateBlockchain(data.BlockchainID, func(blockchain *types.Blockchain) {
+			blockchain.SyncCheckOptions = types.SyncCheckOptions{}
+			if !deleted {
+				blockchain.SyncCheckOptions = *data
+			}
+		})
+	case *types.Redirect:
+		domain := data.Domain
+		if previous, ok := n.Previous.(*types.Redirect); ok {
+			domain = previous.Domain
+		}
+		s.updateBlockchain(data.BlockchainID, func(blockchain *types.Blockchain) {
+			redirects := make([]types.Redirect, 0, len(blockchain.Redirects)+1)
+			for _, redirect := range blockchain.Redirects {
+				if redirect.Domain != domain && redirect.Domain != data.Domain {
+					redirects = append(redirects, redirect)
+				}
+			}
+			if !deleted {
+				redirects = append(redirects, *data)
+			}
+			blockchain.Redirects = redirects
+		})
+	}
+}
+
+/* resolvesRole returns false if n grants a role whose permissions are unknown, the user has no roles on the load balancer until they are reloaded */
+func (s *state) resolvesRole(n *types.Notification) bool {
+	userAccess, ok := n.Data.(*types.UserAccess)
+	if !ok || n.Action == types.ActionDelete {
+		return true
+	}
+
+	_, ok = s.rolePermissions[userAccess.RoleName]
+	return ok
+}
+
+/* setUserRole replaces the user roles with a copy holding the user's permissions on the load balancer */
+func (s *state) setUserRole(userID, lbID string, permissions []types.PermissionsEnum) {
+	userRoles := s.copyUserRoles(userID)
+	userRoles[userID][lbID] = permissions
+	s.userRoles = userRoles
+}
+
+/* removeUserRole replaces the user roles with a copy without the user's permissions on the load balancer */
+func (s *state) removeUserRole(userID, lbID string) {
+	if _, ok := s.userRoles[userID][lbID]; !ok {
+		return
+	}
+
+	userRoles := s.copyUserRoles(userID)
+	delete(userRoles[userID], lbID)
+	if len(userRoles[userID]) == 0 {
+		delete(userRoles, userID)
+	}
+	s.userRoles = userRoles
+}
+
+/* copyUserRoles returns a copy of the user roles where the roles of the user can be modified */
+func (s *state) copyUserRoles(userID string) map[string]map[string][]types.PermissionsEnum {
+	userRoles := make(map[string]map[string][]types.PermissionsEnum, len(s.userRoles)+1)
+	for id, roles := range s.userRoles {
+		userRoles[id] = roles
+	}
+
+	roles := make(map[string][]types.PermissionsEnum, len(s.userRoles[userID])+1)
+	for lbID, permissions := range s.userRoles[userID] {
+		roles[lbID] = permissions
+	}
+	userRoles[userID] = roles
+
+	return userRoles
+}
+
+/* updateApplication replaces the cached app with a copy changed by update */
+func (s *state) updateApplication(id string, update func(app *types.Application)) {
+	cached, ok := s.applications[id]
+	if !ok {
+		return
+	}
+
+	app := *cached
+	update(&app)
+	s.setApplication(&app)
+}
+
+/* updateLoadBalancer replaces the cached load balancer with a copy changed by update */
+func (s *state) updateLoadBalancer(id string, update func(lb *types.LoadBalancer)) {
+	cached, ok := s.loadBalancers[id]
+	if !ok {
+		return
+	}
+
+	lb := *cached
+	update(&lb)
+	s.loadBalancers[id] = &lb
+}
+
+/* updateBlockchain replaces the cached blockchain with a copy changed by update */
+func (s *state) updateBlockchain(id string, update func(blockchain *types.Blockchain)) {
+	cached, ok := s.blockchains[id]
+	if !ok {
+		return
+	}
+
+	blockchain := *cached
+	update(&blockchain)
+	s.setBlockchain(&blockchain)
+}
+
+// mergeApplication returns the incoming app keeping the cached child rows it does not carry.
+// Notifications of the applications table only hold its own columns while aggregated ones hold the whole app
+func mergeApplication(cached, incoming *types.Application) *types.Application {
+	app := *incoming
+	if cached == nil {
+		return &app
+	}
+
+	if isZero(app.GatewayAAT) {
+		app.GatewayAAT = cached.GatewayAAT
+	}
+	if isZero(app.GatewaySettings) {
+		app.GatewaySettings = cached.GatewaySettings
+	}
+	if isZero(app.Limit) {
+		app.Limit = cached.Limit
+	}
+	if isZero(app.NotificationSettings) {
+		app.NotificationSettings = cached.NotificationSettings
+	}
+
+	return &app
+}
+
+/* mergeLoadBalancer returns the incoming load balancer keeping the cached child rows it does not carry */
+func mergeLoadBalancer(cached, incoming *types.LoadBalancer) *types.LoadBalancer {
+	lb := *incoming
+	if cached == nil {
+		return &lb
+	}
+
+	if isZero(lb.StickyOptions) {
+		lb.StickyOptions = cached.StickyOptions
+	}
+	if lb.ApplicationIDs == nil {
+		lb.ApplicationIDs = cached.ApplicationIDs
+	}
+	if lb.Users == nil {
+		lb.Users = cached.Users
+	}
+
+	return &lb
+}
+
+/* mergeBlockchain returns the incoming blockchain keeping the cached child rows it does not carry */
+func mergeBlockchain(cached, incoming *types.Blockchain) *types.Blockchain {
+	blockchain := *incoming
+	if cached == nil {
+		return &blockchain
+	}
+
+	if isZero(blockchain.SyncCheckOptions) {
+		blockchain.SyncCheckOptions = cached.SyncCheckOptions
+	}
+	if blockchain.Redirects == nil {
+		blockchain.Redirects = cached.Redirects
+	}
+
+	return &blockchain
+}
+
+func isZero(value any) bool {
+	return reflect.ValueOf(value).IsZero()
+}
+
+/* payPlan returns the cached pay plan of the given type, app_limits notifications only carry its type */
+func (s *state) payPlan(planType types.PayPlanType) types.PayPlan {
+	for _, plan := range s.payPlans {
+		if plan.Type == planType {
+			return *plan
+		}
+	}
+
+	return types.PayPlan{Type: planType}
+}
@@ -0,0 +1,134 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pokt-foundation/portal-db/types"
+)
+
+func (c *Client) ReadPayPlans(ctx context.Context) ([]*types.PayPlan, error) {
+	var payPlans []*types.PayPlan
+	if err := c.do(ctx, http.MethodGet, "/pay_plan", nil, nil, &payPlans); err != nil {
+		return nil, err
+	}
+
+	return payPlans, nil
+}
+
+func (c *Client) ReadApplications(ctx context.Context) ([]*types.Application, error) {
+	var applications []*types.Application
+	if err := c.do(ctx, http.MethodGet, "/application", nil, nil, &applications); err != nil {
+		return nil, err
+	}
+
+	return applications, nil
+}
+
+func (c *Client) ReadApplication(ctx context.Context, id string) (*types.Application, error) {
+	path, err := escapePath("application", id)
+	if err != nil {
+		return nil, err
+	}
+
+	var application types.Application
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &application); err != nil {
+		return nil, err
+	}
+
+	return &application, nil
+}
+
+func (c *Client) ReadApplicationsPage(ctx context.Context, options *types.QueryOptions) (*types.ApplicationsPage, error) {
+	var page types.ApplicationsPage
+	if err := c.do(ctx, http.MethodGet, "/application/page", queryValues(options), nil, &page); err != nil {
+		return nil, err
+	}
+
+	return &page, nil
+}
+
+func (c *Client) ReadApplicationsUpdatedSince(ctx context.Context, since time.Time) ([]*types.Application, error) {
+	var applications []*types.Application
+	if err := c.do(ctx, http.MethodGet, "/application/updated", sinceValues(since), nil, &applications); err != nil {
+		return nil, err
+	}
+
+	return applications, nil
+}
+
+func (c *Client) WriteApplication(ctx context.Context, app *types.Application) (*types.Application, error) {
+	var application types.Application
+	if err := c.do(ctx, http.MethodPost, "/application", nil, app, &application); err != nil {
+		return nil, err
+	}
+
+	return &application, nil
+}
+
+func (c *Client) UpdateApplication(ctx context.Context, id string, update *types.UpdateApplication) error {
+	path, err := escapePath("application", id)
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, http.MethodPut, path, nil, update, nil)
+}
+
+func (c *Client) UpdateAppFirstDateSurpassed(ctx context.Context, update *types.UpdateFirstDateSurpassed) error {
+	return c.do(ctx, http.MethodPut, "/application/first_date_surpassed", nil, update, nil)
+}
+
+func (c *Client) RemoveApplication(ctx context.Context, id string) error {
+	path, err := escapePath("application", id)
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+/* queryValues encodes the QueryOptions of a page request as the query parameters the server reads */
+func queryValues(options *types.QueryOptions) url.Values {
+	query := url.Values{}
+	if options == nil {
+		return query
+	}
+
+	if options.Limit != 0 {
+		query.Set("limit", strconv.Itoa(options.Limit))
+	}
+	if options.Cursor != "" {
+		query.Set("cursor", options.Cursor)
+	}
+	if options.UserID != "" {
+		query.Set("userID", options.UserID)
+	}
+	if options.Status != "" {
+		query.Set("status", string(options.Status))
+	}
+	if options.PayPlan != "" {
+		query.Set("payPlan", string(options.PayPlan))
+	}
+	if options.Dummy != nil {
+		query.Set("dummy", strconv.FormatBool(*options.Dummy))
+	}
+	if !options.CreatedAfter.IsZero() {
+		query.Set("createdAfter", options.CreatedAfter.Format(time.RFC3339Nano))
+	}
+
+	return query
+}
+
+/* sinceValues encodes the since query parameter of an updated since request, which the server reads as the zero time when missing */
+func sinceValues(since time.Time) url.Values {
+	query := url.Values{}
+	if !since.IsZero() {
+		query.Set("since", since.Format(time.RFC3339Nano))
+	}
+
+	return query
+}
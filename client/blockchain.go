@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pokt-foundation/portal-db/api"
+	"github.com/pokt-foundation/portal-db/types"
+)
+
+func (c *Client) ReadBlockchains(ctx context.Context) ([]*types.Blockchain, error) {
+	var blockchains []*types.Blockchain
+	if err := c.do(ctx, http.MethodGet, "/blockchain", nil, nil, &blockchains); err != nil {
+		return nil, err
+	}
+
+	return blockchains, nil
+}
+
+func (c *Client) ReadBlockchain(ctx context.Context, id string) (*types.Blockchain, error) {
+	path, err := escapePath("blockchain", id)
+	if err != nil {
+		return nil, err
+	}
+
+	var blockchain types.Blockchain
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &blockchain); err != nil {
+		return nil, err
+	}
+
+	return &blockchain, nil
+}
+
+func (c *Client) ReadBlockchainsUpdatedSince(ctx context.Context, since time.Time) ([]*types.Blockchain, error) {
+	var blockchains []*types.Blockchain
+	if err := c.do(ctx, http.MethodGet, "/blockchain/updated", sinceValues(since), nil, &blockchains); err != nil {
+		return nil, err
+	}
+
+	return blockchains, nil
+}
+
+func (c *Client) WriteBlockchain(ctx context.Context, blockchain *types.Blockchain) (*types.Blockchain, error) {
+	var chain types.Blockchain
+	if err := c.do(ctx, http.MethodPost, "/blockchain", nil, blockchain, &chain); err != nil {
+		return nil, err
+	}
+
+	return &chain, nil
+}
+
+func (c *Client) UpdateBlockchain(ctx context.Context, id string, update *types.UpdateBlockchain) error {
+	path, err := escapePath("blockchain", id)
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, http.MethodPut, path, nil, update, nil)
+}
+
+func (c *Client) RemoveBlockchain(ctx context.Context, id string) error {
+	path, err := escapePath("blockchain", id)
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+func (c *Client) ReadRedirects(ctx context.Context, filter *types.RedirectFilter) ([]*types.Redirect, error) {
+	query := url.Values{}
+	if filter != nil {
+		if filter.BlockchainID != "" {
+			query.Set("blockchainID", filter.BlockchainID)
+		}
+		if filter.LoadBalancerID != "" {
+			query.Set("loadBalancerID", filter.LoadBalancerID)
+		}
+	}
+
+	var redirects []*types.Redirect
+	if err := c.do(ctx, http.MethodGet, "/redirect", query, nil, &redirects); err != nil {
+		return nil, err
+	}
+
+	return redirects, nil
+}
+
+func (c *Client) WriteRedirect(ctx context.Context, redirect *types.Redirect) (*types.Redirect, error) {
+	var written types.Redirect
+	if err := c.do(ctx, http.MethodPost, "/redirect", nil, redirect, &written); err != nil {
+		return nil, err
+	}
+
+	return &written, nil
+}
+
+func (c *Client) UpdateRedirect(ctx context.Context, blockchainID, domain string, update *types.UpdateRedirect) error {
+	path, err := escapePath("redirect", blockchainID, domain)
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, http.MethodPut, path, nil, update, nil)
+}
+
+func (c *Client) RemoveRedirect(ctx context.Context, blockchainID, domain string) error {
+	path, err := escapePath("redirect", blockchainID, domain)
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+/* ActivateChain toggles the chain's active field, unlike PostgresDriver it fails with ErrMissingID without an ID */
+func (c *Client) ActivateChain(ctx context.Context, id string, active bool) error {
+	path, err := escapePath("blockchain", id, "activate")
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, http.MethodPut, path, nil, &api.ActivateChainRequest{Active: active}, nil)
+}
@@ -0,0 +1,244 @@
+// Package client provides a driver.Driver calling the HTTP API of the server package, so services can use the
+// portal database without its credentials. Failed requests return the same sentinel errors as PostgresDriver and
+// the Notifications are read from the server's Server-Sent Events, reconnecting whenever the stream is lost.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pokt-foundation/portal-db/api"
+	"github.com/pokt-foundation/portal-db/driver"
+	"github.com/pokt-foundation/portal-db/types"
+)
+
+const (
+	defaultRetryDelay = time.Second
+)
+
+var (
+	// Errors shared with every Driver so callers can check them the same way
+	ErrMissingID        = driver.ErrMissingID
+	ErrNotFound         = driver.ErrNotFound
+	ErrAlreadyExists    = driver.ErrAlreadyExists
+	ErrInvalidReference = driver.ErrInvalidReference
+	ErrConflict         = driver.ErrConflict
+	ErrListenerClosed   = driver.ErrListenerClosed
+	ErrBlockchainInUse  = driver.ErrBlockchainInUse
+)
+
+type (
+	// Client is a driver.Driver sending every call to the server at its base URL.
+	// Its notification streams reconnect until their context is done or Close is called
+	Client struct {
+		baseURL      string
+		httpClient   *http.Client
+		retryDelay   time.Duration
+		errorHandler func(error)
+
+		notification     chan *types.Notification
+		notificationOnce sync.Once
+
+		ctx       context.Context
+		cancel    context.CancelFunc
+		streams   sync.WaitGroup
+		closeOnce sync.Once
+	}
+
+	Option func(*Client)
+
+	// ResponseError is returned when the server fails a request.
+	// It wraps the sentinel error named by its Code, so errors.Is works as it does with the driver behind the server
+	ResponseError struct {
+		StatusCode int
+		Code       string
+		Message    string
+		err        error
+	}
+)
+
+/* WithHTTPClient sets the http.Client sending the requests, it must not time out the notification streams */
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+/* WithRetryDelay sets how long a lost notification stream waits between reconnection attempts, 1s by default */
+func WithRetryDelay(delay time.Duration) Option {
+	return func(c *Client) {
+		c.retryDelay = delay
+	}
+}
+
+/* WithErrorHandler calls handler with the errors of notification streams, which reconnect instead of returning them */
+func WithErrorHandler(handler func(error)) Option {
+	return func(c *Client) {
+		c.errorHandler = handler
+	}
+}
+
+/* NewClient returns a Client for the server at baseURL */
+func NewClient(baseURL string, options ...Option) *Client {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	c := &Client{
+		baseURL:      strings.TrimSuffix(baseURL, "/"),
+		httpClient:   http.DefaultClient,
+		retryDelay:   defaultRetryDelay,
+		notification: make(chan *types.Notification, 32),
+		ctx:          ctx,
+		cancel:       cancel,
+	}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	return c
+}
+
+// Close stops the notification streams, closing their channels, and waits for them to end or for ctx to be done.
+// Requests already sent are not interrupted
+func (c *Client) Close(ctx context.Context) error {
+	c.closeOnce.Do(c.cancel)
+
+	done := make(chan struct{})
+	go func() {
+		c.streams.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+/* RunInTx fails with driver.ErrTxUnsupported, as the server applies every request on its own */
+func (c *Client) RunInTx(ctx context.Context, fn func(tx driver.Writer) error) error {
+	return driver.ErrTxUnsupported
+}
+
+func (e *ResponseError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("unexpected status %d", e.StatusCode)
+	}
+
+	return e.Message
+}
+
+func (e *ResponseError) Unwrap() error {
+	return e.err
+}
+
+/* escapePath joins the escaped segments into a path, failing with ErrMissingID when one of them is empty */
+func escapePath(segments ...string) (string, error) {
+	var builder strings.Builder
+
+	for _, segment := range segments {
+		if segment == "" {
+			return "", ErrMissingID
+		}
+
+		builder.WriteString("/")
+		builder.WriteString(url.PathEscape(segment))
+	}
+
+	return builder.String(), nil
+}
+
+/* do sends body encoded as JSON and decodes the response into out, failed requests return a ResponseError */
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	resp, err := c.send(ctx, method, path, query, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+/* send sends the request and returns its response if it succeeded, the caller must close its body */
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, body any) (*http.Response, error) {
+	endpoint := c.baseURL + path
+	if len(query) != 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	return nil, responseError(resp)
+}
+
+func responseError(resp *http.Response) error {
+	respErr := &ResponseError{StatusCode: resp.StatusCode}
+
+	var body api.ErrorResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		respErr.Message = http.StatusText(resp.StatusCode)
+		return respErr
+	}
+
+	respErr.Code = body.Code
+	respErr.Message = body.Error
+	respErr.err = api.ErrorFromCode(body.Code)
+
+	return respErr
+}
+
+func (c *Client) reportError(err error) {
+	if c.errorHandler != nil {
+		c.errorHandler(err)
+	}
+}
+
+/* isTemporary returns true unless err is a ResponseError the server would return again */
+func isTemporary(err error) bool {
+	var respErr *ResponseError
+	if errors.As(err, &respErr) {
+		return respErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	return true
+}
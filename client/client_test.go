@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pokt-foundation/portal-db/api"
+	"github.com/pokt-foundation/portal-db/driver"
+	"github.com/pokt-foundation/portal-db/drivertest"
+	"github.com/pokt-foundation/portal-db/memdriver"
+	"github.com/pokt-foundation/portal-db/server"
+	"github.com/pokt-foundation/portal-db/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var _ driver.Driver = &Client{}
+
+/* newTestClient returns a Client for a server in front of a new MemDriver */
+func newTestClient(t *testing.T, options ...Option) (*Client, *memdriver.MemDriver, *httptest.Server) {
+	d := memdriver.NewMemDriver()
+	ts := httptest.NewServer(server.NewServer(d))
+	c := NewClient(ts.URL, append([]Option{WithHTTPClient(ts.Client()), WithRetryDelay(10 * time.Millisecond)}, options...)...)
+
+	t.Cleanup(func() {
+		_ = c.Close(context.Background())
+		_ = d.Close(context.Background())
+		ts.Close()
+	})
+
+	return c, d, ts
+}
+
+func TestConformance(t *testing.T) {
+	drivertest.Run(t, func(t *testing.T) driver.Driver {
+		c, _, _ := newTestClient(t)
+		return c
+	})
+}
+
+func TestClient_Errors(t *testing.T) {
+	c := require.New(t)
+	client, _, _ := newTestClient(t)
+	ctx := context.Background()
+
+	_, err := client.ReadApplication(ctx, "app_1")
+	c.ErrorIs(err, ErrNotFound)
+
+	var respErr *ResponseError
+	c.True(errors.As(err, &respErr))
+	c.Equal(http.StatusNotFound, respErr.StatusCode)
+	c.Equal("NOT_FOUND", respErr.Code)
+
+	_, err = client.ReadLoadBalancer(ctx, "")
+	c.ErrorIs(err, ErrMissingID)
+
+	err = client.UpdateUserAccessRole(ctx, "user_1", "lb_1", types.RoleOwner)
+	c.ErrorIs(err, api.ErrorFromCode("CANNOT_SET_TO_OWNER"))
+
+	_, err = client.ReadApplicationsPage(ctx, &types.QueryOptions{Status: "NOT_A_STATUS"})
+	c.ErrorIs(err, types.ErrInvalidAppStatus)
+
+	_, err = client.Subscribe(ctx, &types.SubscriptionOptions{BufferSize: -1})
+	c.ErrorIs(err, types.ErrInvalidBufferSize)
+
+	c.NoError(client.Close(ctx))
+	_, err = client.Subscribe(ctx, nil)
+	c.ErrorIs(err, ErrListenerClosed)
+}
+
+func TestClient_InternalError(t *testing.T) {
+	c := require.New(t)
+
+	d := &driver.MockDriver{}
+	d.On("ReadBlockchains", mock.Anything).Return(nil, errors.New("connection refused"))
+
+	ts := httptest.NewServer(server.NewServer(d))
+	defer ts.Close()
+
+	client := NewClient(ts.URL + "/")
+
+	_, err := client.ReadBlockchains(context.Background())
+	c.EqualError(err, "Internal Server Error")
+
+	var respErr *ResponseError
+	c.True(errors.As(err, &respErr))
+	c.Equal(http.StatusInternalServerError, respErr.StatusCode)
+	c.Nil(respErr.Unwrap())
+}
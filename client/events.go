@@ -0,0 +1,331 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pokt-foundation/portal-db/types"
+)
+
+// reorderHorizon is how far behind the latest sequence received a notification may still arrive. The server only delivers
+// out of sequence order the notifications it holds at once, a missing sequence further behind was filtered out or never committed
+const reorderHorizon = 1024
+
+/* stream follows a notification stream of the server across reconnections */
+type stream struct {
+	client  *Client
+	options *types.SubscriptionOptions
+	out     chan *types.Notification
+	// lastSequence is the sequence reconnections resume after, every sequence up to it was received or is past the
+	// reorderHorizon. It is nil until a notification with a sequence was received, unless the options' FromSequence is set
+	lastSequence *int64
+	// received holds the sequences received after lastSequence, the server replays them again when resuming
+	received map[int64]struct{}
+	// replayed holds the sequences received before the current connection that its replay sends again
+	replayed map[int64]struct{}
+}
+
+func (c *Client) ReadEventsSince(ctx context.Context, sequence int64) ([]*types.Notification, error) {
+	query := url.Values{"since": {strconv.FormatInt(sequence, 10)}}
+
+	var events []*types.Notification
+	if err := c.do(ctx, http.MethodGet, "/event", query, nil, &events); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// Subscribe returns an independent channel with the notifications that pass the options' filter, read from the
+// server's stream once it is connected. A lost stream is reconnected, resuming after the notifications received
+// so none is missed or repeated. When that is not known, an ActionResync notification is sent once reconnected.
+// The channel is closed when ctx is done, the client is closed, the server refuses to reconnect it
+// or the overflow policy disconnects it
+func (c *Client) Subscribe(ctx context.Context, options *types.SubscriptionOptions) (<-chan *types.Notification, error) {
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+	if options == nil {
+		options = &types.SubscriptionOptions{}
+	}
+	if c.ctx.Err() != nil {
+		return nil, ErrListenerClosed
+	}
+
+	ctx, cancel := c.streamContext(ctx)
+
+	s := c.newStream(options, make(chan *types.Notification))
+
+	body, err := s.connect(ctx)
+	if err != nil {
+		c.streams.Done()
+		cancel()
+		return nil, err
+	}
+
+	go func() {
+		defer c.streams.Done()
+		defer cancel()
+		s.serve(ctx, body)
+	}()
+
+	return s.out, nil
+}
+
+// NotificationChannel returns a channel with every notification written once the first call returns,
+// as long as the server could be reached then. It is reconnected until the client is closed, notifications
+// lost while it was disconnected are signalled by an ActionResync notification
+func (c *Client) NotificationChannel() <-chan *types.Notification {
+	c.notificationOnce.Do(func() {
+		s := c.newStream(&types.SubscriptionOptions{}, c.notification)
+
+		body, err := s.connect(c.ctx)
+		if err != nil {
+			c.reportError(err)
+		}
+
+		go func() {
+			defer c.streams.Done()
+
+			if body == nil {
+				if body, err = s.reconnect(c.ctx); err != nil {
+					close(s.out)
+					return
+				}
+			}
+			s.serve(c.ctx, body)
+		}()
+	})
+
+	return c.notification
+}
+
+/* newStream returns a stream Close waits for, the caller must call c.streams.Done once it ends */
+func (c *Client) newStream(options *types.SubscriptionOptions, out chan *types.Notification) *stream {
+	c.streams.Add(1)
+
+	s := &stream{client: c, options: options, out: out, received: make(map[int64]struct{})}
+	if options.FromSequence != nil {
+		sequence := *options.FromSequence
+		s.lastSequence = &sequence
+	}
+
+	return s
+}
+
+/* streamContext returns a context done when ctx is done or the client is closed */
+func (c *Client) streamContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	go func() {
+		select {
+		case <-c.ctx.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, cancel
+}
+
+/* serve reads the notifications of body and reconnects when it ends, until the stream can't continue */
+func (s *stream) serve(ctx context.Context, body io.ReadCloser) {
+	defer close(s.out)
+
+	for {
+		disconnected, err := s.read(ctx, body)
+		body.Close()
+		if disconnected || ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			s.client.reportError(err)
+		}
+
+		body, err = s.reconnect(ctx)
+		if err != nil {
+			return
+		}
+
+		// Without a sequence to resume from, the notifications sent while disconnected are lost
+		if s.lastSequence == nil && !s.send(ctx, &types.Notification{Action: types.ActionResync}) {
+			body.Close()
+			return
+		}
+	}
+}
+
+/* connect opens the stream, resuming after lastSequence when it is set */
+func (s *stream) connect(ctx context.Context) (io.ReadCloser, error) {
+	query := url.Values{}
+
+	if len(s.options.Tables) != 0 {
+		tables := make([]string, 0, len(s.options.Tables))
+		for _, table := range s.options.Tables {
+			tables = append(tables, string(table))
+		}
+		query.Set("tables", strings.Join(tables, ","))
+	}
+	if len(s.options.Actions) != 0 {
+		actions := make([]string, 0, len(s.options.Actions))
+		for _, action := range s.options.Actions {
+			actions = append(actions, string(action))
+		}
+		query.Set("actions", strings.Join(actions, ","))
+	}
+	if s.options.EntityID != "" {
+		query.Set("entityID", s.options.EntityID)
+	}
+	if s.options.BufferSize != 0 {
+		query.Set("bufferSize", strconv.Itoa(s.options.BufferSize))
+	}
+	if s.options.Overflow != "" {
+		query.Set("overflow", string(s.options.Overflow))
+	}
+	if s.lastSequence != nil {
+		query.Set("fromSequence", strconv.FormatInt(*s.lastSequence, 10))
+	}
+	s.resume()
+
+	resp, err := s.client.send(ctx, http.MethodGet, "/notification", query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+/* reconnect connects the stream, retrying until it succeeds, ctx is done or the server refuses it for good */
+func (s *stream) reconnect(ctx context.Context) (io.ReadCloser, error) {
+	for {
+		body, err := s.connect(ctx)
+		if err == nil {
+			return body, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		s.client.reportError(err)
+		if !isTemporary(err) {
+			return nil, err
+		}
+
+		select {
+		case <-time.After(s.client.retryDelay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// read sends the notifications of the Server-Sent Events in body until it ends.
+// disconnected is true when the stream must not be reconnected, because ctx is done or the overflow policy disconnected it
+func (s *stream) read(ctx context.Context, body io.Reader) (disconnected bool, err error) {
+	reader := bufio.NewReader(body)
+	var data []string
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return false, nil
+			}
+			return false, err
+		}
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+
+		switch {
+		case line == "":
+			if len(data) == 0 {
+				continue
+			}
+
+			var n types.Notification
+			if err := json.Unmarshal([]byte(strings.Join(data, "\n")), &n); err != nil {
+				return false, err
+			}
+			data = nil
+
+			if !s.send(ctx, &n) {
+				return true, nil
+			}
+			if n.Action == types.ActionResync && s.options.Policy() == types.OverflowDisconnect {
+				return true, nil
+			}
+
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+}
+
+/* resume expects the sequences received after lastSequence to be replayed by the connection being opened */
+func (s *stream) resume() {
+	s.replayed = make(map[int64]struct{}, len(s.received))
+	for sequence := range s.received {
+		s.replayed[sequence] = struct{}{}
+	}
+}
+
+// send passes n on unless it was replayed after already being received, returning false if ctx is done.
+// Notifications are not compared with the last sequence received, as the server delivers those of different
+// entities out of sequence order when it parses them with several workers or coalesces them
+func (s *stream) send(ctx context.Context, n *types.Notification) bool {
+	if _, ok := s.replayed[n.Sequence]; ok && n.Sequence != 0 {
+		delete(s.replayed, n.Sequence)
+		return true
+	}
+
+	select {
+	case s.out <- n:
+	case <-ctx.Done():
+		return false
+	}
+
+	if n.Sequence != 0 {
+		s.receive(n.Sequence)
+	}
+
+	return true
+}
+
+/* receive records sequence as received, moving lastSequence up to the highest sequence received without gaps */
+func (s *stream) receive(sequence int64) {
+	if s.lastSequence == nil {
+		last := sequence - 1
+		s.lastSequence = &last
+	}
+	if sequence <= *s.lastSequence {
+		return
+	}
+	s.received[sequence] = struct{}{}
+
+	last := *s.lastSequence
+	if horizon := sequence - reorderHorizon; horizon > last {
+		last = horizon
+		for received := range s.received {
+			if received <= last {
+				delete(s.received, received)
+			}
+		}
+	}
+
+	for {
+		if _, ok := s.received[last+1]; !ok {
+			break
+		}
+		delete(s.received, last+1)
+		last++
+	}
+
+	s.lastSequence = &last
+}
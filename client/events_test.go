@@ -0,0 +1,160 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pokt-foundation/portal-db/driver"
+	"github.com/pokt-foundation/portal-db/types"
+	"github.com/stretchr/testify/require"
+)
+
+/* receive waits for the next notification on ch */
+func receive(t *testing.T, ch <-chan *types.Notification) *types.Notification {
+	t.Helper()
+
+	select {
+	case n, ok := <-ch:
+		require.True(t, ok, "notification channel closed")
+		return n
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for notification")
+		return nil
+	}
+}
+
+/* writeApplication writes straight to the driver, as closing the test server's connections would fail writes through the client */
+func writeApplication(t *testing.T, d driver.Writer, name string) *types.Application {
+	t.Helper()
+
+	app, err := d.WriteApplication(context.Background(), &types.Application{
+		Name:  name,
+		Limit: types.AppLimit{PayPlan: types.PayPlan{Type: types.FreetierV0}},
+	})
+	require.NoError(t, err)
+
+	return app
+}
+
+func TestClient_SubscribeReconnects(t *testing.T) {
+	c := require.New(t)
+	client, d, ts := newTestClient(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sub, err := client.Subscribe(ctx, &types.SubscriptionOptions{
+		SubscriptionFilter: types.SubscriptionFilter{Tables: []types.Table{types.TableApplications}, Actions: []types.Action{types.ActionInsert}},
+	})
+	c.NoError(err)
+
+	first := writeApplication(t, d, "pokt_app_1")
+	n := receive(t, sub)
+	c.Equal(first.ID, n.Data.(*types.Application).ID)
+
+	// the stream resumes after the last notification received, so the one written while disconnected isn't lost
+	ts.CloseClientConnections()
+	second := writeApplication(t, d, "pokt_app_2")
+
+	n = receive(t, sub)
+	c.Equal(types.ActionInsert, n.Action)
+	c.Equal(second.ID, n.Data.(*types.Application).ID)
+
+	cancel()
+	for range sub {
+	}
+}
+
+func TestStream_SendOutOfOrder(t *testing.T) {
+	c := require.New(t)
+
+	fromSequence := int64(2)
+	s := (&Client{}).newStream(&types.SubscriptionOptions{FromSequence: &fromSequence}, make(chan *types.Notification, 16))
+
+	send := func(sequences ...int64) []int64 {
+		for _, sequence := range sequences {
+			c.True(s.send(context.Background(), &types.Notification{Sequence: sequence}))
+		}
+
+		var sent []int64
+		for len(s.out) > 0 {
+			sent = append(sent, (<-s.out).Sequence)
+		}
+
+		return sent
+	}
+
+	// notifications of different entities arrive out of sequence order, none is dropped
+	c.Equal([]int64{5, 3, 6}, send(5, 3, 6))
+	c.Equal(int64(3), *s.lastSequence, "resumes after the highest sequence received without gaps")
+
+	// resuming replays what was received after it, only the missed sequences are passed on
+	s.resume()
+	c.Equal([]int64{4, 7}, send(4, 5, 6, 7))
+	c.Empty(s.replayed)
+	c.Equal(int64(7), *s.lastSequence)
+	c.Empty(s.received)
+
+	// a gap further behind than the reorder horizon is not waited for
+	c.Equal([]int64{9 + reorderHorizon}, send(9+reorderHorizon))
+	c.Equal(int64(9), *s.lastSequence)
+}
+
+func TestClient_SubscribeResyncs(t *testing.T) {
+	c := require.New(t)
+	client, d, ts := newTestClient(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sub, err := client.Subscribe(ctx, &types.SubscriptionOptions{
+		SubscriptionFilter: types.SubscriptionFilter{Tables: []types.Table{types.TableApplications}, Actions: []types.Action{types.ActionInsert}},
+	})
+	c.NoError(err)
+
+	// without a notification to resume after, the subscriber is told it may have missed some
+	ts.CloseClientConnections()
+	c.Equal(types.ActionResync, receive(t, sub).Action)
+
+	app := writeApplication(t, d, "pokt_app_1")
+	n := receive(t, sub)
+	c.Equal(app.ID, n.Data.(*types.Application).ID)
+}
+
+func TestClient_SubscribeFromSequence(t *testing.T) {
+	c := require.New(t)
+	client, d, _ := newTestClient(t)
+
+	app := writeApplication(t, d, "pokt_app_1")
+
+	from := int64(0)
+	sub, err := client.Subscribe(context.Background(), &types.SubscriptionOptions{FromSequence: &from})
+	c.NoError(err)
+
+	n := receive(t, sub)
+	c.Equal(int64(1), n.Sequence)
+	c.Equal(app.ID, n.Data.(*types.Application).ID)
+
+	c.NoError(client.Close(context.Background()))
+	for range sub {
+	}
+}
+
+func TestClient_NotificationChannel(t *testing.T) {
+	c := require.New(t)
+	client, d, _ := newTestClient(t)
+
+	notifications := client.NotificationChannel()
+	app := writeApplication(t, d, "pokt_app_1")
+
+	n := receive(t, notifications)
+	c.Equal(int64(1), n.Sequence)
+	c.Equal(app.ID, n.Data.(*types.Application).ID)
+
+	c.NoError(d.Close(context.Background()))
+	c.NoError(client.Close(context.Background()))
+
+	for range notifications {
+	}
+}
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pokt-foundation/portal-db/types"
+)
+
+func (c *Client) ReadLoadBalancers(ctx context.Context) ([]*types.LoadBalancer, error) {
+	var loadBalancers []*types.LoadBalancer
+	if err := c.do(ctx, http.MethodGet, "/load_balancer", nil, nil, &loadBalancers); err != nil {
+		return nil, err
+	}
+
+	return loadBalancers, nil
+}
+
+func (c *Client) ReadLoadBalancer(ctx context.Context, id string) (*types.LoadBalancer, error) {
+	path, err := escapePath("load_balancer", id)
+	if err != nil {
+		return nil, err
+	}
+
+	var loadBalancer types.LoadBalancer
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, &loadBalancer); err != nil {
+		return nil, err
+	}
+
+	return &loadBalancer, nil
+}
+
+func (c *Client) ReadLoadBalancersPage(ctx context.Context, options *types.QueryOptions) (*types.LoadBalancersPage, error) {
+	var page types.LoadBalancersPage
+	if err := c.do(ctx, http.MethodGet, "/load_balancer/page", queryValues(options), nil, &page); err != nil {
+		return nil, err
+	}
+
+	return &page, nil
+}
+
+func (c *Client) ReadLoadBalancersUpdatedSince(ctx context.Context, since time.Time) ([]*types.LoadBalancer, error) {
+	var loadBalancers []*types.LoadBalancer
+	if err := c.do(ctx, http.MethodGet, "/load_balancer/updated", sinceValues(since), nil, &loadBalancers); err != nil {
+		return nil, err
+	}
+
+	return loadBalancers, nil
+}
+
+func (c *Client) ReadUserRoles(ctx context.Context) (map[string]map[string][]types.PermissionsEnum, error) {
+	var userRoles map[string]map[string][]types.PermissionsEnum
+	if err := c.do(ctx, http.MethodGet, "/user_roles", nil, nil, &userRoles); err != nil {
+		return nil, err
+	}
+
+	return userRoles, nil
+}
+
+func (c *Client) ReadUserRolesUpdatedSince(ctx context.Context, since time.Time) (map[string]map[string][]types.PermissionsEnum, error) {
+	var userRoles map[string]map[string][]types.PermissionsEnum
+	if err := c.do(ctx, http.MethodGet, "/user_roles/updated", sinceValues(since), nil, &userRoles); err != nil {
+		return nil, err
+	}
+
+	return userRoles, nil
+}
+
+func (c *Client) WriteLoadBalancer(ctx context.Context, loadBalancer *types.LoadBalancer) (*types.LoadBalancer, error) {
+	var lb types.LoadBalancer
+	if err := c.do(ctx, http.MethodPost, "/load_balancer", nil, loadBalancer, &lb); err != nil {
+		return nil, err
+	}
+
+	return &lb, nil
+}
+
+func (c *Client) WriteLoadBalancerUser(ctx context.Context, lbID string, userAccess types.UserAccess) error {
+	path, err := escapePath("load_balancer", lbID, "user")
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, http.MethodPost, path, nil, &userAccess, nil)
+}
+
+func (c *Client) UpdateLoadBalancer(ctx context.Context, id string, update *types.UpdateLoadBalancer) error {
+	path, err := escapePath("load_balancer", id)
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, http.MethodPut, path, nil, update, nil)
+}
+
+func (c *Client) UpdateUserAccessRole(ctx context.Context, userID, lbID string, roleName types.RoleName) error {
+	path, err := escapePath("load_balancer", lbID, "user", userID)
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, http.MethodPut, path, nil, &types.UpdateUserAccess{UserID: userID, RoleName: roleName}, nil)
+}
+
+func (c *Client) RemoveLoadBalancer(ctx context.Context, id string) error {
+	path, err := escapePath("load_balancer", id)
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+func (c *Client) RemoveUserAccess(ctx context.Context, userID, lbID string) error {
+	path, err := escapePath("load_balancer", lbID, "user", userID)
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
@@ -2,15 +2,26 @@ package driver
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/pokt-foundation/portal-db/types"
 )
 
+var (
+	// ErrTxUnsupported is returned by RunInTx when the driver can't apply several writes atomically
+	ErrTxUnsupported = errors.New("transactions unsupported")
+)
+
 type (
 	// The Driver interface represents all database operations required by the Pocket HTTP DB
 	Driver interface {
 		Reader
 		Writer
+
+		// RunInTx runs fn in a transaction, applying the writes made through tx only if it returns nil.
+		// fn may run again when the transaction has to be retried
+		RunInTx(ctx context.Context, fn func(tx Writer) error) error
 	}
 
 	Reader interface {
@@ -20,7 +31,23 @@ type (
 		ReadUserRoles(ctx context.Context) (map[string]map[string][]types.PermissionsEnum, error)
 		ReadBlockchains(ctx context.Context) ([]*types.Blockchain, error)
 
+		ReadApplication(ctx context.Context, id string) (*types.Application, error)
+		ReadLoadBalancer(ctx context.Context, id string) (*types.LoadBalancer, error)
+		ReadBlockchain(ctx context.Context, id string) (*types.Blockchain, error)
+		ReadRedirects(ctx context.Context, filter *types.RedirectFilter) ([]*types.Redirect, error)
+
+		ReadApplicationsPage(ctx context.Context, options *types.QueryOptions) (*types.ApplicationsPage, error)
+		ReadLoadBalancersPage(ctx context.Context, options *types.QueryOptions) (*types.LoadBalancersPage, error)
+
+		ReadApplicationsUpdatedSince(ctx context.Context, since time.Time) ([]*types.Application, error)
+		ReadLoadBalancersUpdatedSince(ctx context.Context, since time.Time) ([]*types.LoadBalancer, error)
+		ReadBlockchainsUpdatedSince(ctx context.Context, since time.Time) ([]*types.Blockchain, error)
+		ReadUserRolesUpdatedSince(ctx context.Context, since time.Time) (map[string]map[string][]types.PermissionsEnum, error)
+
+		ReadEventsSince(ctx context.Context, sequence int64) ([]*types.Notification, error)
+
 		NotificationChannel() <-chan *types.Notification
+		Subscribe(ctx context.Context, options *types.SubscriptionOptions) (<-chan *types.Notification, error)
 	}
 
 	Writer interface {
@@ -37,7 +64,11 @@ type (
 		RemoveApplication(ctx context.Context, id string) error
 
 		WriteBlockchain(ctx context.Context, blockchain *types.Blockchain) (*types.Blockchain, error)
+		UpdateBlockchain(ctx context.Context, id string, update *types.UpdateBlockchain) error
+		RemoveBlockchain(ctx context.Context, id string) error
 		WriteRedirect(ctx context.Context, redirect *types.Redirect) (*types.Redirect, error)
+		UpdateRedirect(ctx context.Context, blockchainID, domain string, update *types.UpdateRedirect) error
+		RemoveRedirect(ctx context.Context, blockchainID, domain string) error
 		ActivateChain(ctx context.Context, id string, active bool) error
 	}
 )
@@ -0,0 +1,43 @@
+package driver
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// Errors every Driver returns so callers can handle failures the same way whatever the database
+	ErrNotFound         = errors.New("not found")
+	ErrAlreadyExists    = errors.New("already exists")
+	ErrInvalidReference = errors.New("invalid reference")
+	ErrConflict         = errors.New("conflict")
+
+	// Validation and state errors of the Driver methods
+	ErrMissingID               = errors.New("missing id")
+	ErrUserInputIsMissingField = errors.New("error: user access input is missing a required field")
+	ErrLBMustHaveUser          = errors.New("error: a new load balancer must have at least one user")
+	ErrCannotSetToOwner        = errors.New("error: load balancers may only have one owner and the owner role is already set")
+	ErrBlockchainInUse         = errors.New("error: blockchain is referenced by redirects or application whitelists")
+	ErrListenerClosed          = errors.New("listener closed")
+)
+
+// Error is a database error mapped to one of the driver errors, keeping the code and the name of the constraint it violated.
+// errors.Is matches it with the driver error and errors.As with the database error
+type Error struct {
+	Err        error
+	Code       string
+	Constraint string
+	Cause      error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Err, e.Cause)
+}
+
+func (e *Error) Is(target error) bool {
+	return target == e.Err
+}
+
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
@@ -4,6 +4,7 @@ package driver
 
 import (
 	context "context"
+	time "time"
 
 	types "github.com/pokt-foundation/portal-db/types"
 	mock "github.com/stretchr/testify/mock"
@@ -44,6 +45,29 @@ func (_m *MockDriver) NotificationChannel() <-chan *types.Notification {
 	return r0
 }
 
+// ReadApplication provides a mock function with given fields: ctx, id
+func (_m *MockDriver) ReadApplication(ctx context.Context, id string) (*types.Application, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *types.Application
+	if rf, ok := ret.Get(0).(func(context.Context, string) *types.Application); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Application)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ReadApplications provides a mock function with given fields: ctx
 func (_m *MockDriver) ReadApplications(ctx context.Context) ([]*types.Application, error) {
 	ret := _m.Called(ctx)
@@ -67,6 +91,75 @@ func (_m *MockDriver) ReadApplications(ctx context.Context) ([]*types.Applicatio
 	return r0, r1
 }
 
+// ReadApplicationsPage provides a mock function with given fields: ctx, options
+func (_m *MockDriver) ReadApplicationsPage(ctx context.Context, options *types.QueryOptions) (*types.ApplicationsPage, error) {
+	ret := _m.Called(ctx, options)
+
+	var r0 *types.ApplicationsPage
+	if rf, ok := ret.Get(0).(func(context.Context, *types.QueryOptions) *types.ApplicationsPage); ok {
+		r0 = rf(ctx, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ApplicationsPage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *types.QueryOptions) error); ok {
+		r1 = rf(ctx, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReadApplicationsUpdatedSince provides a mock function with given fields: ctx, since
+func (_m *MockDriver) ReadApplicationsUpdatedSince(ctx context.Context, since time.Time) ([]*types.Application, error) {
+	ret := _m.Called(ctx, since)
+
+	var r0 []*types.Application
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []*types.Application); ok {
+		r0 = rf(ctx, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Application)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReadBlockchain provides a mock function with given fields: ctx, id
+func (_m *MockDriver) ReadBlockchain(ctx context.Context, id string) (*types.Blockchain, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *types.Blockchain
+	if rf, ok := ret.Get(0).(func(context.Context, string) *types.Blockchain); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Blockchain)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ReadBlockchains provides a mock function with given fields: ctx
 func (_m *MockDriver) ReadBlockchains(ctx context.Context) ([]*types.Blockchain, error) {
 	ret := _m.Called(ctx)
@@ -90,6 +183,75 @@ func (_m *MockDriver) ReadBlockchains(ctx context.Context) ([]*types.Blockchain,
 	return r0, r1
 }
 
+// ReadBlockchainsUpdatedSince provides a mock function with given fields: ctx, since
+func (_m *MockDriver) ReadBlockchainsUpdatedSince(ctx context.Context, since time.Time) ([]*types.Blockchain, error) {
+	ret := _m.Called(ctx, since)
+
+	var r0 []*types.Blockchain
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []*types.Blockchain); ok {
+		r0 = rf(ctx, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Blockchain)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReadEventsSince provides a mock function with given fields: ctx, sequence
+func (_m *MockDriver) ReadEventsSince(ctx context.Context, sequence int64) ([]*types.Notification, error) {
+	ret := _m.Called(ctx, sequence)
+
+	var r0 []*types.Notification
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []*types.Notification); ok {
+		r0 = rf(ctx, sequence)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Notification)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, sequence)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReadLoadBalancer provides a mock function with given fields: ctx, id
+func (_m *MockDriver) ReadLoadBalancer(ctx context.Context, id string) (*types.LoadBalancer, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *types.LoadBalancer
+	if rf, ok := ret.Get(0).(func(context.Context, string) *types.LoadBalancer); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.LoadBalancer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ReadLoadBalancers provides a mock function with given fields: ctx
 func (_m *MockDriver) ReadLoadBalancers(ctx context.Context) ([]*types.LoadBalancer, error) {
 	ret := _m.Called(ctx)
@@ -113,6 +275,52 @@ func (_m *MockDriver) ReadLoadBalancers(ctx context.Context) ([]*types.LoadBalan
 	return r0, r1
 }
 
+// ReadLoadBalancersPage provides a mock function with given fields: ctx, options
+func (_m *MockDriver) ReadLoadBalancersPage(ctx context.Context, options *types.QueryOptions) (*types.LoadBalancersPage, error) {
+	ret := _m.Called(ctx, options)
+
+	var r0 *types.LoadBalancersPage
+	if rf, ok := ret.Get(0).(func(context.Context, *types.QueryOptions) *types.LoadBalancersPage); ok {
+		r0 = rf(ctx, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.LoadBalancersPage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *types.QueryOptions) error); ok {
+		r1 = rf(ctx, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReadLoadBalancersUpdatedSince provides a mock function with given fields: ctx, since
+func (_m *MockDriver) ReadLoadBalancersUpdatedSince(ctx context.Context, since time.Time) ([]*types.LoadBalancer, error) {
+	ret := _m.Called(ctx, since)
+
+	var r0 []*types.LoadBalancer
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []*types.LoadBalancer); ok {
+		r0 = rf(ctx, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.LoadBalancer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ReadPayPlans provides a mock function with given fields: ctx
 func (_m *MockDriver) ReadPayPlans(ctx context.Context) ([]*types.PayPlan, error) {
 	ret := _m.Called(ctx)
@@ -136,6 +344,29 @@ func (_m *MockDriver) ReadPayPlans(ctx context.Context) ([]*types.PayPlan, error
 	return r0, r1
 }
 
+// ReadRedirects provides a mock function with given fields: ctx, filter
+func (_m *MockDriver) ReadRedirects(ctx context.Context, filter *types.RedirectFilter) ([]*types.Redirect, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 []*types.Redirect
+	if rf, ok := ret.Get(0).(func(context.Context, *types.RedirectFilter) []*types.Redirect); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Redirect)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *types.RedirectFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ReadUserRoles provides a mock function with given fields: ctx
 func (_m *MockDriver) ReadUserRoles(ctx context.Context) (map[string]map[string][]types.PermissionsEnum, error) {
 	ret := _m.Called(ctx)
@@ -159,6 +390,29 @@ func (_m *MockDriver) ReadUserRoles(ctx context.Context) (map[string]map[string]
 	return r0, r1
 }
 
+// ReadUserRolesUpdatedSince provides a mock function with given fields: ctx, since
+func (_m *MockDriver) ReadUserRolesUpdatedSince(ctx context.Context, since time.Time) (map[string]map[string][]types.PermissionsEnum, error) {
+	ret := _m.Called(ctx, since)
+
+	var r0 map[string]map[string][]types.PermissionsEnum
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) map[string]map[string][]types.PermissionsEnum); ok {
+		r0 = rf(ctx, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]map[string][]types.PermissionsEnum)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RemoveApplication provides a mock function with given fields: ctx, id
 func (_m *MockDriver) RemoveApplication(ctx context.Context, id string) error {
 	ret := _m.Called(ctx, id)
@@ -173,6 +427,20 @@ func (_m *MockDriver) RemoveApplication(ctx context.Context, id string) error {
 	return r0
 }
 
+// RemoveBlockchain provides a mock function with given fields: ctx, id
+func (_m *MockDriver) RemoveBlockchain(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemoveLoadBalancer provides a mock function with given fields: ctx, id
 func (_m *MockDriver) RemoveLoadBalancer(ctx context.Context, id string) error {
 	ret := _m.Called(ctx, id)
@@ -187,6 +455,20 @@ func (_m *MockDriver) RemoveLoadBalancer(ctx context.Context, id string) error {
 	return r0
 }
 
+// RemoveRedirect provides a mock function with given fields: ctx, blockchainID, domain
+func (_m *MockDriver) RemoveRedirect(ctx context.Context, blockchainID string, domain string) error {
+	ret := _m.Called(ctx, blockchainID, domain)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, blockchainID, domain)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemoveUserAccess provides a mock function with given fields: ctx, userID, lbID
 func (_m *MockDriver) RemoveUserAccess(ctx context.Context, userID string, lbID string) error {
 	ret := _m.Called(ctx, userID, lbID)
@@ -201,6 +483,43 @@ func (_m *MockDriver) RemoveUserAccess(ctx context.Context, userID string, lbID
 	return r0
 }
 
+// RunInTx provides a mock function with given fields: ctx, fn
+func (_m *MockDriver) RunInTx(ctx context.Context, fn func(tx Writer) error) error {
+	ret := _m.Called(ctx, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(tx Writer) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Subscribe provides a mock function with given fields: ctx, options
+func (_m *MockDriver) Subscribe(ctx context.Context, options *types.SubscriptionOptions) (<-chan *types.Notification, error) {
+	ret := _m.Called(ctx, options)
+
+	var r0 <-chan *types.Notification
+	if rf, ok := ret.Get(0).(func(context.Context, *types.SubscriptionOptions) <-chan *types.Notification); ok {
+		r0 = rf(ctx, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan *types.Notification)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *types.SubscriptionOptions) error); ok {
+		r1 = rf(ctx, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateAppFirstDateSurpassed provides a mock function with given fields: ctx, update
 func (_m *MockDriver) UpdateAppFirstDateSurpassed(ctx context.Context, update *types.UpdateFirstDateSurpassed) error {
 	ret := _m.Called(ctx, update)
@@ -229,6 +548,20 @@ func (_m *MockDriver) UpdateApplication(ctx context.Context, id string, update *
 	return r0
 }
 
+// UpdateBlockchain provides a mock function with given fields: ctx, id, update
+func (_m *MockDriver) UpdateBlockchain(ctx context.Context, id string, update *types.UpdateBlockchain) error {
+	ret := _m.Called(ctx, id, update)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *types.UpdateBlockchain) error); ok {
+		r0 = rf(ctx, id, update)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateLoadBalancer provides a mock function with given fields: ctx, id, options
 func (_m *MockDriver) UpdateLoadBalancer(ctx context.Context, id string, options *types.UpdateLoadBalancer) error {
 	ret := _m.Called(ctx, id, options)
@@ -243,6 +576,20 @@ func (_m *MockDriver) UpdateLoadBalancer(ctx context.Context, id string, options
 	return r0
 }
 
+// UpdateRedirect provides a mock function with given fields: ctx, blockchainID, domain, update
+func (_m *MockDriver) UpdateRedirect(ctx context.Context, blockchainID string, domain string, update *types.UpdateRedirect) error {
+	ret := _m.Called(ctx, blockchainID, domain, update)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *types.UpdateRedirect) error); ok {
+		r0 = rf(ctx, blockchainID, domain, update)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateUserAccessRole provides a mock function with given fields: ctx, userID, lbID, roleName
 func (_m *MockDriver) UpdateUserAccessRole(ctx context.Context, userID string, lbID string, roleName types.RoleName) error {
 	ret := _m.Called(ctx, userID, lbID, roleName)
@@ -0,0 +1,282 @@
+package drivertest
+
+import (
+	"time"
+
+	"github.com/pokt-foundation/portal-db/driver"
+	"github.com/pokt-foundation/portal-db/types"
+)
+
+func (ts *Suite) writeApplication(app *types.Application) *types.Application {
+	written, err := ts.driver.WriteApplication(ts.ctx, app)
+	ts.Require().NoError(err)
+
+	return written
+}
+
+func (ts *Suite) TestWriteApplication() {
+	userID := ts.unique("user_")
+
+	app := ts.writeApplication(&types.Application{
+		UserID:       userID,
+		Name:         "pokt_app_conformance",
+		ContactEmail: "app@test.com",
+		URL:          "https://test.app.io",
+		Status:       types.InService,
+		Dummy:        true,
+		GatewayAAT: types.GatewayAAT{
+			Address:              "test_address",
+			ApplicationPublicKey: ts.unique("public_key_"),
+			ApplicationSignature: "test_signature",
+			ClientPublicKey:      "test_client_public_key",
+			PrivateKey:           "test_private_key",
+			Version:              "0.0.1",
+		},
+		GatewaySettings: types.GatewaySettings{
+			SecretKey:         "test_secret_key",
+			SecretKeyRequired: true,
+			WhitelistOrigins:  []string{"https://pokt.network"},
+		},
+		Limit:                types.AppLimit{PayPlan: types.PayPlan{Type: types.FreetierV0}},
+		NotificationSettings: types.NotificationSettings{SignedUp: true, Half: true},
+	})
+	ts.Len(app.ID, 24)
+	ts.False(app.CreatedAt.IsZero())
+	ts.False(app.UpdatedAt.IsZero())
+
+	read, err := ts.driver.ReadApplication(ts.ctx, app.ID)
+	ts.Require().NoError(err)
+	ts.Equal(app.ID, read.ID)
+	ts.Equal(userID, read.UserID)
+	ts.Equal("pokt_app_conformance", read.Name)
+	ts.Equal("app@test.com", read.ContactEmail)
+	ts.Equal(types.InService, read.Status)
+	ts.True(read.Dummy)
+	ts.Equal(app.GatewayAAT.ApplicationPublicKey, read.GatewayAAT.ApplicationPublicKey)
+	ts.Equal("0.0.1", read.GatewayAAT.Version)
+	ts.Equal("test_secret_key", read.GatewaySettings.SecretKey)
+	ts.True(read.GatewaySettings.SecretKeyRequired)
+	ts.Equal([]string{"https://pokt.network"}, read.GatewaySettings.WhitelistOrigins)
+	ts.Equal(types.AppLimit{PayPlan: types.PayPlan{Type: types.FreetierV0, Limit: 250000}}, read.Limit)
+	ts.Equal(types.NotificationSettings{SignedUp: true, Half: true}, read.NotificationSettings)
+	ts.WithinDuration(app.CreatedAt, read.CreatedAt, time.Second)
+
+	apps, err := ts.driver.ReadApplications(ts.ctx)
+	ts.Require().NoError(err)
+	ts.Contains(applicationIDs(apps), app.ID)
+}
+
+func (ts *Suite) TestWriteApplicationValidation() {
+	tests := []struct {
+		name string
+		app  *types.Application
+		err  error
+	}{
+		{
+			name: "Should fail if the status is invalid",
+			app:  &types.Application{Status: "NOT_A_STATUS", Limit: types.AppLimit{PayPlan: types.PayPlan{Type: types.FreetierV0}}},
+			err:  types.ErrInvalidAppStatus,
+		},
+		{
+			name: "Should fail if the pay plan is invalid",
+			app:  &types.Application{Limit: types.AppLimit{PayPlan: types.PayPlan{Type: "NOT_A_PLAN"}}},
+			err:  types.ErrInvalidPayPlanType,
+		},
+		{
+			name: "Should fail if a custom limit is set on a plan that is not enterprise",
+			app:  &types.Application{Limit: types.AppLimit{PayPlan: types.PayPlan{Type: types.FreetierV0}, CustomLimit: 1000}},
+			err:  types.ErrNotEnterprisePlan,
+		},
+	}
+
+	for _, test := range tests {
+		ts.Run(test.name, func() {
+			_, err := ts.driver.WriteApplication(ts.ctx, test.app)
+			ts.ErrorIs(err, test.err)
+		})
+	}
+}
+
+func (ts *Suite) TestReadApplicationErrors() {
+	_, err := ts.driver.ReadApplication(ts.ctx, "")
+	ts.ErrorIs(err, driver.ErrMissingID)
+
+	_, err = ts.driver.ReadApplication(ts.ctx, ts.unique("missing_"))
+	ts.ErrorIs(err, driver.ErrNotFound)
+}
+
+func (ts *Suite) TestUpdateApplication() {
+	app := ts.writeApplication(&types.Application{
+		Name:            "pokt_app_conformance",
+		Status:          types.AwaitingStaking,
+		GatewaySettings: types.GatewaySettings{SecretKey: "test_secret_key", WhitelistOrigins: []string{"https://pokt.network"}},
+		Limit:           types.AppLimit{PayPlan: types.PayPlan{Type: types.FreetierV0}},
+	})
+
+	before, err := ts.driver.ReadApplication(ts.ctx, app.ID)
+	ts.Require().NoError(err)
+
+	// updated_at has a microsecond precision, waiting makes sure the update changes it
+	time.Sleep(10 * time.Millisecond)
+
+	secretKeyRequired, full := true, true
+	err = ts.driver.UpdateApplication(ts.ctx, app.ID, &types.UpdateApplication{
+		Name:                 "pokt_app_renamed",
+		GatewaySettings:      &types.UpdateGatewaySettings{SecretKeyRequired: &secretKeyRequired},
+		NotificationSettings: &types.UpdateNotificationSettings{Full: &full},
+		Limit:                &types.AppLimit{PayPlan: types.PayPlan{Type: types.Enterprise}, CustomLimit: 2000000},
+	})
+	ts.Require().NoError(err)
+
+	read, err := ts.driver.ReadApplication(ts.ctx, app.ID)
+	ts.Require().NoError(err)
+	ts.Equal("pokt_app_renamed", read.Name)
+	ts.Equal(types.AwaitingStaking, read.Status, "fields left empty keep their value")
+	ts.Equal("test_secret_key", read.GatewaySettings.SecretKey)
+	ts.True(read.GatewaySettings.SecretKeyRequired)
+	ts.Equal([]string{"https://pokt.network"}, read.GatewaySettings.WhitelistOrigins)
+	ts.True(read.NotificationSettings.Full)
+	ts.Equal(types.AppLimit{PayPlan: types.PayPlan{Type: types.Enterprise}, CustomLimit: 2000000}, read.Limit)
+	ts.True(read.UpdatedAt.After(before.UpdatedAt))
+
+	updated, err := ts.driver.ReadApplicationsUpdatedSince(ts.ctx, before.UpdatedAt)
+	ts.Require().NoError(err)
+	ts.Contains(applicationIDs(updated), app.ID)
+
+	surpassed := time.Date(2022, 11, 11, 11, 11, 11, 0, time.UTC)
+	err = ts.driver.UpdateAppFirstDateSurpassed(ts.ctx, &types.UpdateFirstDateSurpassed{
+		ApplicationIDs:     []string{app.ID},
+		FirstDateSurpassed: surpassed,
+	})
+	ts.Require().NoError(err)
+
+	err = ts.driver.RemoveApplication(ts.ctx, app.ID)
+	ts.Require().NoError(err)
+
+	read, err = ts.driver.ReadApplication(ts.ctx, app.ID)
+	ts.Require().NoError(err)
+	ts.True(surpassed.Equal(read.FirstDateSurpassed))
+	ts.Equal(types.AwaitingGracePeriod, read.Status)
+	ts.Equal("pokt_app_renamed", read.Name)
+}
+
+func (ts *Suite) TestUpdateApplicationConflict() {
+	app := ts.writeApplication(&types.Application{
+		Name:  "pokt_app_conformance",
+		Limit: types.AppLimit{PayPlan: types.PayPlan{Type: types.FreetierV0}},
+	})
+
+	// the written application carries the stored updated_at
+	expected := app.UpdatedAt
+
+	// updated_at has a microsecond precision, waiting makes sure the update changes it
+	time.Sleep(10 * time.Millisecond)
+
+	err := ts.driver.UpdateApplication(ts.ctx, app.ID, &types.UpdateApplication{Name: "pokt_app_first", ExpectedUpdatedAt: &expected})
+	ts.Require().NoError(err)
+
+	// the second update expects the application as it was before the first one
+	err = ts.driver.UpdateApplication(ts.ctx, app.ID, &types.UpdateApplication{Name: "pokt_app_second", ExpectedUpdatedAt: &expected})
+	ts.ErrorIs(err, driver.ErrConflict)
+
+	read, err := ts.driver.ReadApplication(ts.ctx, app.ID)
+	ts.Require().NoError(err)
+	ts.Equal("pokt_app_first", read.Name)
+
+	err = ts.driver.UpdateApplication(ts.ctx, app.ID, &types.UpdateApplication{Name: "pokt_app_second", ExpectedUpdatedAt: &read.UpdatedAt})
+	ts.Require().NoError(err)
+
+	err = ts.driver.UpdateApplication(ts.ctx, ts.unique("missing_"), &types.UpdateApplication{Name: "pokt_app_second", ExpectedUpdatedAt: &expected})
+	ts.ErrorIs(err, driver.ErrNotFound)
+}
+
+func (ts *Suite) TestUpdateApplicationValidation() {
+	tests := []struct {
+		name   string
+		id     string
+		update *types.UpdateApplication
+		err    error
+	}{
+		{
+			name:   "Should fail if the ID is missing",
+			update: &types.UpdateApplication{Name: "pokt_app_renamed"},
+			err:    driver.ErrMissingID,
+		},
+		{
+			name: "Should fail if there is no update",
+			id:   "test_app",
+			err:  types.ErrNoFieldsToUpdate,
+		},
+		{
+			name:   "Should fail if the status is invalid",
+			id:     "test_app",
+			update: &types.UpdateApplication{Status: "NOT_A_STATUS"},
+			err:    types.ErrInvalidAppStatus,
+		},
+		{
+			name:   "Should fail if an enterprise plan has no custom limit",
+			id:     "test_app",
+			update: &types.UpdateApplication{Limit: &types.AppLimit{PayPlan: types.PayPlan{Type: types.Enterprise}}},
+			err:    types.ErrEnterprisePlanNeedsCustomLimit,
+		},
+	}
+
+	for _, test := range tests {
+		ts.Run(test.name, func() {
+			ts.ErrorIs(ts.driver.UpdateApplication(ts.ctx, test.id, test.update), test.err)
+		})
+	}
+
+	ts.ErrorIs(ts.driver.RemoveApplication(ts.ctx, ""), driver.ErrMissingID)
+}
+
+func (ts *Suite) TestReadApplicationsPage() {
+	userID := ts.unique("user_")
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		app := ts.writeApplication(&types.Application{UserID: userID, Limit: types.AppLimit{PayPlan: types.PayPlan{Type: types.FreetierV0}}})
+		ids = append(ids, app.ID)
+	}
+	testPlan := ts.writeApplication(&types.Application{UserID: userID, Limit: types.AppLimit{PayPlan: types.PayPlan{Type: types.TestPlanV0}}})
+	ids = append(ids, testPlan.ID)
+
+	var paged []string
+	options := &types.QueryOptions{Limit: 3, UserID: userID}
+	for {
+		page, err := ts.driver.ReadApplicationsPage(ts.ctx, options)
+		ts.Require().NoError(err)
+		ts.LessOrEqual(len(page.Applications), 3)
+
+		paged = append(paged, applicationIDs(page.Applications)...)
+		if page.NextCursor == "" {
+			break
+		}
+		options.Cursor = page.NextCursor
+	}
+	ts.ElementsMatch(ids, paged)
+	ts.IsIncreasing(paged)
+
+	page, err := ts.driver.ReadApplicationsPage(ts.ctx, &types.QueryOptions{UserID: userID, PayPlan: types.TestPlanV0})
+	ts.Require().NoError(err)
+	ts.Equal([]string{testPlan.ID}, applicationIDs(page.Applications))
+
+	_, err = ts.driver.ReadApplicationsPage(ts.ctx, &types.QueryOptions{Limit: types.MaxPageLimit + 1})
+	ts.ErrorIs(err, types.ErrInvalidPageLimit)
+}
+
+func (ts *Suite) TestReadPayPlans() {
+	payPlans, err := ts.driver.ReadPayPlans(ts.ctx)
+	ts.Require().NoError(err)
+	ts.Contains(payPlans, &types.PayPlan{Type: types.FreetierV0, Limit: 250000})
+	ts.Contains(payPlans, &types.PayPlan{Type: types.TestPlanV0, Limit: 100})
+}
+
+func applicationIDs(apps []*types.Application) []string {
+	var ids []string
+	for _, app := range apps {
+		ids = append(ids, app.ID)
+	}
+
+	return ids
+}
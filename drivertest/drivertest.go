@@ -0,0 +1,71 @@
+// Package drivertest provides a conformance suite for implementations of driver.Driver.
+// It checks the read and write semantics, validation errors, partial updates and Notifications of PostgresDriver,
+// so alternative implementations can prove they behave the same way.
+package drivertest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/pokt-foundation/portal-db/driver"
+	"github.com/pokt-foundation/portal-db/types"
+	"github.com/stretchr/testify/suite"
+)
+
+/* notificationTimeout is how long the suite waits for a Notification the driver must emit */
+const notificationTimeout = 10 * time.Second
+
+type (
+	// NewDriver returns the driver under test, it is called before every test.
+	// The driver must hold the pay plans and user roles of testdata/seed_test_db.sql and may hold other rows,
+	// as the suite only checks the rows it writes. Closing the driver is left to t.Cleanup
+	NewDriver func(t *testing.T) driver.Driver
+
+	/* Suite is the conformance suite, use Run to run it */
+	Suite struct {
+		suite.Suite
+		newDriver NewDriver
+		driver    driver.Driver
+		ctx       context.Context
+	}
+)
+
+/* Run runs the conformance suite against the drivers returned by newDriver */
+func Run(t *testing.T, newDriver NewDriver) {
+	suite.Run(t, &Suite{newDriver: newDriver})
+}
+
+// SetupTest runs before each test
+func (ts *Suite) SetupTest() {
+	ts.ctx = context.Background()
+	ts.driver = ts.newDriver(ts.T())
+}
+
+/* unique returns prefix followed by a random suffix, so rows written by the suite never collide with existing ones */
+func (ts *Suite) unique(prefix string) string {
+	bytes := make([]byte, 8)
+	_, err := rand.Read(bytes)
+	ts.Require().NoError(err)
+
+	return prefix + hex.EncodeToString(bytes)
+}
+
+/* receive waits for the first notification on ch that matches */
+func (ts *Suite) receive(ch <-chan *types.Notification, matches func(n *types.Notification) bool) *types.Notification {
+	timeout := time.After(notificationTimeout)
+
+	for {
+		select {
+		case n, ok := <-ch:
+			ts.Require().True(ok, "notification channel closed")
+			if matches(n) {
+				return n
+			}
+		case <-timeout:
+			ts.Require().FailNow("timed out waiting for notification")
+		}
+	}
+}
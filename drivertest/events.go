@@ -0,0 +1,105 @@
+package drivertest
+
+import (
+	"context"
+	"time"
+
+	"github.com/pokt-foundation/portal-db/types"
+)
+
+func (ts *Suite) TestNotifications() {
+	ctx, cancel := context.WithCancel(ts.ctx)
+	defer cancel()
+
+	sub, err := ts.driver.Subscribe(ctx, &types.SubscriptionOptions{
+		SubscriptionFilter: types.SubscriptionFilter{Tables: []types.Table{types.TableApplications}},
+	})
+	ts.Require().NoError(err)
+
+	app := ts.writeApplication(&types.Application{Name: "pokt_app_conformance", Limit: types.AppLimit{PayPlan: types.PayPlan{Type: types.FreetierV0}}})
+
+	isApp := func(n *types.Notification) bool {
+		ts.Equal(types.TableApplications, n.Table, "the subscription only receives the tables of its filter")
+		data, ok := n.Data.(*types.Application)
+		return ok && data.ID == app.ID
+	}
+
+	inserted := ts.receive(sub, isApp)
+	ts.Equal(types.ActionInsert, inserted.Action)
+	ts.Equal("pokt_app_conformance", inserted.Data.(*types.Application).Name)
+	ts.Positive(inserted.Sequence)
+
+	err = ts.driver.UpdateApplication(ts.ctx, app.ID, &types.UpdateApplication{Name: "pokt_app_renamed"})
+	ts.Require().NoError(err)
+
+	updated := ts.receive(sub, func(n *types.Notification) bool {
+		return isApp(n) && n.Action == types.ActionUpdate && n.Data.(*types.Application).Name == "pokt_app_renamed"
+	})
+	ts.Greater(updated.Sequence, inserted.Sequence)
+	ts.Equal("pokt_app_conformance", updated.Previous.(*types.Application).Name)
+	ts.Contains(updated.ChangedFields, "name")
+	ts.NotContains(updated.ChangedFields, "status")
+
+	events, err := ts.driver.ReadEventsSince(ts.ctx, inserted.Sequence-1)
+	ts.Require().NoError(err)
+	ts.Require().NotEmpty(events)
+	ts.Equal(inserted.Sequence, events[0].Sequence)
+	ts.Equal(types.ActionInsert, events[0].Action)
+	ts.Equal(app.ID, events[0].Data.(*types.Application).ID)
+
+	cancel()
+	ts.closed(sub)
+}
+
+func (ts *Suite) TestSubscribeFromSequence() {
+	app := ts.writeApplication(&types.Application{Limit: types.AppLimit{PayPlan: types.PayPlan{Type: types.FreetierV0}}})
+
+	events, err := ts.driver.ReadEventsSince(ts.ctx, 0)
+	ts.Require().NoError(err)
+
+	var inserted *types.Notification
+	for _, event := range events {
+		if data, ok := event.Data.(*types.Application); ok && data.ID == app.ID && event.Action == types.ActionInsert {
+			inserted = event
+		}
+	}
+	ts.Require().NotNil(inserted)
+
+	ctx, cancel := context.WithCancel(ts.ctx)
+	defer cancel()
+
+	from := inserted.Sequence - 1
+	replay, err := ts.driver.Subscribe(ctx, &types.SubscriptionOptions{
+		SubscriptionFilter: types.SubscriptionFilter{EntityID: app.ID},
+		FromSequence:       &from,
+	})
+	ts.Require().NoError(err)
+
+	// the events of the app and its child tables are replayed in order
+	n := ts.receive(replay, func(*types.Notification) bool { return true })
+	ts.Equal(inserted.Sequence, n.Sequence)
+	ts.Equal(types.ActionInsert, n.Action)
+
+	limit := ts.receive(replay, func(n *types.Notification) bool { return n.Table == types.TableAppLimits })
+	ts.Greater(limit.Sequence, n.Sequence)
+	ts.Equal(app.ID, limit.Data.(*types.AppLimit).ID)
+
+	_, err = ts.driver.Subscribe(ctx, &types.SubscriptionOptions{Overflow: "NOT_A_POLICY"})
+	ts.ErrorIs(err, types.ErrInvalidOverflowPolicy)
+}
+
+/* closed waits for ch to be closed, discarding the notifications still in it */
+func (ts *Suite) closed(ch <-chan *types.Notification) {
+	timeout := time.After(notificationTimeout)
+
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			ts.Require().FailNow("timed out waiting for the subscription to close")
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package drivertest
+
+import (
+	"time"
+
+	"github.com/pokt-foundation/portal-db/driver"
+	"github.com/pokt-foundation/portal-db/types"
+)
+
+func (ts *Suite) writeLoadBalancer(lb *types.LoadBalancer) *types.LoadBalancer {
+	written, err := ts.driver.WriteLoadBalancer(ts.ctx, lb)
+	ts.Require().NoError(err)
+
+	return written
+}
+
+func (ts *Suite) TestWriteLoadBalancer() {
+	userID := ts.unique("user_")
+	app := ts.writeApplication(&types.Application{UserID: userID, Limit: types.AppLimit{PayPlan: types.PayPlan{Type: types.FreetierV0}}})
+
+	lb := ts.writeLoadBalancer(&types.LoadBalancer{
+		Name:              "pokt_lb_conformance",
+		UserID:            userID,
+		ApplicationIDs:    []string{app.ID},
+		RequestTimeout:    5000,
+		Gigastake:         true,
+		GigastakeRedirect: true,
+		StickyOptions:     types.StickyOptions{Duration: "40", StickyOrigins: []string{"chrome-extension://"}, StickyMax: 300, Stickiness: true},
+		Users:             []types.UserAccess{{UserID: userID, Email: "owner@test.com", RoleName: types.RoleAdmin}},
+	})
+	ts.Len(lb.ID, 24)
+	ts.False(lb.CreatedAt.IsZero())
+
+	read, err := ts.driver.ReadLoadBalancer(ts.ctx, lb.ID)
+	ts.Require().NoError(err)
+	ts.Equal(lb.ID, read.ID)
+	ts.Equal("pokt_lb_conformance", read.Name)
+	ts.Equal(userID, read.UserID)
+	ts.Equal([]string{app.ID}, read.ApplicationIDs)
+	ts.Equal(5000, read.RequestTimeout)
+	ts.True(read.Gigastake)
+	ts.True(read.GigastakeRedirect)
+	ts.Equal(types.StickyOptions{Duration: "40", StickyOrigins: []string{"chrome-extension://"}, StickyMax: 300, Stickiness: true}, read.StickyOptions)
+	ts.WithinDuration(lb.CreatedAt, read.CreatedAt, time.Second)
+
+	// the first user becomes the owner and has accepted
+	ts.Equal([]types.UserAccess{{UserID: userID, Email: "owner@test.com", RoleName: types.RoleOwner, Accepted: true}}, read.Users)
+
+	lbs, err := ts.driver.ReadLoadBalancers(ts.ctx)
+	ts.Require().NoError(err)
+	ts.Contains(loadBalancerIDs(lbs), lb.ID)
+
+	_, err = ts.driver.WriteLoadBalancer(ts.ctx, &types.LoadBalancer{Name: "pokt_lb_conformance"})
+	ts.ErrorIs(err, driver.ErrLBMustHaveUser)
+
+	_, err = ts.driver.ReadLoadBalancer(ts.ctx, "")
+	ts.ErrorIs(err, driver.ErrMissingID)
+	_, err = ts.driver.ReadLoadBalancer(ts.ctx, ts.unique("missing_"))
+	ts.ErrorIs(err, driver.ErrNotFound)
+}
+
+func (ts *Suite) TestLoadBalancerUsers() {
+	ownerID, memberID := ts.unique("user_"), ts.unique("user_")
+	lb := ts.writeLoadBalancer(&types.LoadBalancer{
+		UserID: ownerID,
+		Users:  []types.UserAccess{{UserID: ownerID, Email: "owner@test.com"}},
+	})
+
+	ts.ErrorIs(ts.driver.WriteLoadBalancerUser(ts.ctx, "", types.UserAccess{UserID: memberID}), driver.ErrMissingID)
+	ts.ErrorIs(ts.driver.WriteLoadBalancerUser(ts.ctx, lb.ID, types.UserAccess{
+		UserID: memberID, Email: "member@test.com", RoleName: types.RoleOwner,
+	}), driver.ErrCannotSetToOwner)
+	ts.ErrorIs(ts.driver.WriteLoadBalancerUser(ts.ctx, lb.ID, types.UserAccess{
+		UserID: memberID, RoleName: types.RoleMember,
+	}), driver.ErrUserInputIsMissingField)
+
+	err := ts.driver.WriteLoadBalancerUser(ts.ctx, lb.ID, types.UserAccess{UserID: memberID, Email: "member@test.com", RoleName: types.RoleMember})
+	ts.Require().NoError(err)
+	ts.ErrorIs(ts.driver.WriteLoadBalancerUser(ts.ctx, lb.ID, types.UserAccess{
+		UserID: memberID, Email: "member@test.com", RoleName: types.RoleMember,
+	}), driver.ErrAlreadyExists, "a user has one access per load balancer")
+	ts.ErrorIs(ts.driver.WriteLoadBalancerUser(ts.ctx, lb.ID, types.UserAccess{
+		UserID: ts.unique("user_"), Email: "member@test.com", RoleName: "NOT_A_ROLE",
+	}), driver.ErrInvalidReference, "user accesses must reference a role")
+	ts.ErrorIs(ts.driver.WriteLoadBalancerUser(ts.ctx, ts.unique("missing_"), types.UserAccess{
+		UserID: memberID, Email: "member@test.com", RoleName: types.RoleMember,
+	}), driver.ErrInvalidReference, "user accesses must reference a load balancer")
+
+	read, err := ts.driver.ReadLoadBalancer(ts.ctx, lb.ID)
+	ts.Require().NoError(err)
+	ts.ElementsMatch([]types.UserAccess{
+		{UserID: ownerID, Email: "owner@test.com", RoleName: types.RoleOwner, Accepted: true},
+		{UserID: memberID, Email: "member@test.com", RoleName: types.RoleMember, Accepted: false},
+	}, read.Users)
+
+	userRoles, err := ts.driver.ReadUserRoles(ts.ctx)
+	ts.Require().NoError(err)
+	ts.Equal(map[string][]types.PermissionsEnum{lb.ID: {types.ReadEndpoint, types.WriteEndpoint}}, userRoles[ownerID])
+	ts.Equal(map[string][]types.PermissionsEnum{lb.ID: {types.ReadEndpoint}}, userRoles[memberID])
+
+	ts.ErrorIs(ts.driver.UpdateUserAccessRole(ts.ctx, "", lb.ID, types.RoleAdmin), driver.ErrMissingID)
+	ts.ErrorIs(ts.driver.UpdateUserAccessRole(ts.ctx, memberID, lb.ID, types.RoleOwner), driver.ErrCannotSetToOwner)
+	ts.ErrorIs(ts.driver.UpdateUserAccessRole(ts.ctx, memberID, lb.ID, "NOT_A_ROLE"), driver.ErrInvalidReference)
+	ts.Require().NoError(ts.driver.UpdateUserAccessRole(ts.ctx, memberID, lb.ID, types.RoleAdmin))
+
+	userRoles, err = ts.driver.ReadUserRoles(ts.ctx)
+	ts.Require().NoError(err)
+	ts.Equal(map[string][]types.PermissionsEnum{lb.ID: {types.ReadEndpoint, types.WriteEndpoint}}, userRoles[memberID])
+
+	ts.ErrorIs(ts.driver.RemoveUserAccess(ts.ctx, memberID, ""), driver.ErrMissingID)
+	ts.Require().NoError(ts.driver.RemoveUserAccess(ts.ctx, memberID, lb.ID))
+
+	read, err = ts.driver.ReadLoadBalancer(ts.ctx, lb.ID)
+	ts.Require().NoError(err)
+	ts.Len(read.Users, 1)
+	ts.Equal(ownerID, read.Users[0].UserID)
+}
+
+func (ts *Suite) TestRemoveLastLoadBalancerUser() {
+	userID := ts.unique("user_")
+	lb := ts.writeLoadBalancer(&types.LoadBalancer{
+		UserID: userID,
+		Users:  []types.UserAccess{{UserID: userID, Email: "owner@test.com"}},
+	})
+
+	before, err := ts.driver.ReadLoadBalancer(ts.ctx, lb.ID)
+	ts.Require().NoError(err)
+
+	// updated_at has a microsecond precision, waiting makes sure the removal changes it
+	time.Sleep(10 * time.Millisecond)
+
+	ts.Require().NoError(ts.driver.RemoveUserAccess(ts.ctx, userID, lb.ID))
+
+	// the load balancer is still returned so consumers drop the roles they hold for it
+	userRoles, err := ts.driver.ReadUserRolesUpdatedSince(ts.ctx, before.UpdatedAt)
+	ts.Require().NoError(err)
+	ts.NotContains(userRoles, userID)
+	ts.Contains(userRoles[""], lb.ID)
+	ts.Empty(userRoles[""][lb.ID])
+}
+
+func (ts *Suite) TestUpdateLoadBalancer() {
+	userID := ts.unique("user_")
+	lb := ts.writeLoadBalancer(&types.LoadBalancer{
+		Name:          "pokt_lb_conformance",
+		UserID:        userID,
+		StickyOptions: types.StickyOptions{Duration: "40", StickyOrigins: []string{"chrome-extension://"}, StickyMax: 300},
+		Users:         []types.UserAccess{{UserID: userID, Email: "owner@test.com"}},
+	})
+
+	before, err := ts.driver.ReadLoadBalancer(ts.ctx, lb.ID)
+	ts.Require().NoError(err)
+
+	// updated_at has a microsecond precision, waiting makes sure the update changes it
+	time.Sleep(10 * time.Millisecond)
+
+	stickiness := true
+	err = ts.driver.UpdateLoadBalancer(ts.ctx, lb.ID, &types.UpdateLoadBalancer{
+		Name:          "pokt_lb_renamed",
+		StickyOptions: &types.UpdateStickyOptions{Duration: "60", Stickiness: &stickiness},
+	})
+	ts.Require().NoError(err)
+	ts.ErrorIs(ts.driver.UpdateLoadBalancer(ts.ctx, "", &types.UpdateLoadBalancer{Name: "pokt_lb_renamed"}), driver.ErrMissingID)
+
+	read, err := ts.driver.ReadLoadBalancer(ts.ctx, lb.ID)
+	ts.Require().NoError(err)
+	ts.Equal("pokt_lb_renamed", read.Name)
+	ts.Equal(types.StickyOptions{Duration: "60", StickyOrigins: []string{"chrome-extension://"}, StickyMax: 300, Stickiness: true}, read.StickyOptions)
+	ts.True(read.UpdatedAt.After(before.UpdatedAt))
+
+	updated, err := ts.driver.ReadLoadBalancersUpdatedSince(ts.ctx, before.UpdatedAt)
+	ts.Require().NoError(err)
+	ts.Contains(loadBalancerIDs(updated), lb.ID)
+
+	userRoles, err := ts.driver.ReadUserRolesUpdatedSince(ts.ctx, before.UpdatedAt)
+	ts.Require().NoError(err)
+	ts.Contains(userRoles[userID], lb.ID)
+
+	ts.ErrorIs(ts.driver.RemoveLoadBalancer(ts.ctx, ""), driver.ErrMissingID)
+	ts.Require().NoError(ts.driver.RemoveLoadBalancer(ts.ctx, lb.ID))
+
+	read, err = ts.driver.ReadLoadBalancer(ts.ctx, lb.ID)
+	ts.Require().NoError(err)
+	ts.Empty(read.UserID)
+	ts.Equal("pokt_lb_renamed", read.Name)
+}
+
+func (ts *Suite) TestUpdateLoadBalancerConflict() {
+	userID := ts.unique("user_")
+	lb := ts.writeLoadBalancer(&types.LoadBalancer{
+		Name:   "pokt_lb_conformance",
+		UserID: userID,
+		Users:  []types.UserAccess{{UserID: userID, Email: "owner@test.com"}},
+	})
+
+	// the written load balancer carries the stored updated_at, including the bumps of its users and applications
+	expected := lb.UpdatedAt
+
+	// updated_at has a microsecond precision, waiting makes sure the update changes it
+	time.Sleep(10 * time.Millisecond)
+
+	err := ts.driver.UpdateLoadBalancer(ts.ctx, lb.ID, &types.UpdateLoadBalancer{Name: "pokt_lb_first", ExpectedUpdatedAt: &expected})
+	ts.Require().NoError(err)
+
+	// the second update expects the load balancer as it was before the first one
+	err = ts.driver.UpdateLoadBalancer(ts.ctx, lb.ID, &types.UpdateLoadBalancer{Name: "pokt_lb_second", ExpectedUpdatedAt: &expected})
+	ts.ErrorIs(err, driver.ErrConflict)
+
+	read, err := ts.driver.ReadLoadBalancer(ts.ctx, lb.ID)
+	ts.Require().NoError(err)
+	ts.Equal("pokt_lb_first", read.Name)
+
+	err = ts.driver.UpdateLoadBalancer(ts.ctx, lb.ID, &types.UpdateLoadBalancer{Name: "pokt_lb_second", ExpectedUpdatedAt: &read.UpdatedAt})
+	ts.Require().NoError(err)
+
+	err = ts.driver.UpdateLoadBalancer(ts.ctx, ts.unique("missing_"), &types.UpdateLoadBalancer{Name: "pokt_lb_second", ExpectedUpdatedAt: &expected})
+	ts.ErrorIs(err, driver.ErrNotFound)
+}
+
+func (ts *Suite) TestReadLoadBalancersPage() {
+	userID := ts.unique("user_")
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		lb := ts.writeLoadBalancer(&types.LoadBalancer{UserID: userID, Users: []types.UserAccess{{UserID: userID, Email: "owner@test.com"}}})
+		ids = append(ids, lb.ID)
+	}
+
+	var paged []string
+	options := &types.QueryOptions{Limit: 2, UserID: userID}
+	for {
+		page, err := ts.driver.ReadLoadBalancersPage(ts.ctx, options)
+		ts.Require().NoError(err)
+		ts.LessOrEqual(len(page.LoadBalancers), 2)
+
+		paged = append(paged, loadBalancerIDs(page.LoadBalancers)...)
+		if page.NextCursor == "" {
+			break
+		}
+		options.Cursor = page.NextCursor
+	}
+	ts.ElementsMatch(ids, paged)
+	ts.IsIncreasing(paged)
+}
+
+func loadBalancerIDs(lbs []*types.LoadBalancer) []string {
+	var ids []string
+	for _, lb := range lbs {
+		ids = append(ids, lb.ID)
+	}
+
+	return ids
+}
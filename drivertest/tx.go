@@ -0,0 +1,73 @@
+package drivertest
+
+import (
+	"errors"
+
+	"github.com/pokt-foundation/portal-db/driver"
+	"github.com/pokt-foundation/portal-db/types"
+)
+
+func (ts *Suite) TestRunInTx() {
+	userID := ts.unique("user_")
+	var app *types.Application
+	var lb *types.LoadBalancer
+
+	err := ts.driver.RunInTx(ts.ctx, func(tx driver.Writer) error {
+		var err error
+
+		app, err = tx.WriteApplication(ts.ctx, &types.Application{UserID: userID, Name: "pokt_app_tx", Limit: types.AppLimit{PayPlan: types.PayPlan{Type: types.FreetierV0}}})
+		if err != nil {
+			return err
+		}
+
+		lb, err = tx.WriteLoadBalancer(ts.ctx, &types.LoadBalancer{
+			Name:           "pokt_lb_tx",
+			UserID:         userID,
+			ApplicationIDs: []string{app.ID},
+			Users:          []types.UserAccess{{UserID: userID, Email: "owner@test.com"}},
+		})
+		return err
+	})
+	if errors.Is(err, driver.ErrTxUnsupported) {
+		ts.T().Skip("the driver does not support transactions")
+	}
+	ts.Require().NoError(err)
+
+	read, err := ts.driver.ReadLoadBalancer(ts.ctx, lb.ID)
+	ts.Require().NoError(err)
+	ts.Equal([]string{app.ID}, read.ApplicationIDs)
+
+	// none of the writes are applied when the function fails
+	failed := errors.New("failed")
+	var rolledBack *types.Application
+
+	err = ts.driver.RunInTx(ts.ctx, func(tx driver.Writer) error {
+		var err error
+
+		rolledBack, err = tx.WriteApplication(ts.ctx, &types.Application{UserID: userID, Limit: types.AppLimit{PayPlan: types.PayPlan{Type: types.FreetierV0}}})
+		if err != nil {
+			return err
+		}
+
+		err = tx.UpdateApplication(ts.ctx, app.ID, &types.UpdateApplication{Name: "pokt_app_rolled_back"})
+		if err != nil {
+			return err
+		}
+
+		return failed
+	})
+	ts.ErrorIs(err, failed)
+
+	_, err = ts.driver.ReadApplication(ts.ctx, rolledBack.ID)
+	ts.ErrorIs(err, driver.ErrNotFound)
+
+	readApp, err := ts.driver.ReadApplication(ts.ctx, app.ID)
+	ts.Require().NoError(err)
+	ts.Equal("pokt_app_tx", readApp.Name)
+
+	err = ts.driver.RunInTx(ts.ctx, func(tx driver.Writer) error {
+		_, err := tx.WriteLoadBalancer(ts.ctx, &types.LoadBalancer{Name: "pokt_lb_tx"})
+		return err
+	})
+	ts.ErrorIs(err, driver.ErrLBMustHaveUser)
+}
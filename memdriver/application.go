@@ -0,0 +1,430 @@
+package memdriver
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pokt-foundation/portal-db/types"
+)
+
+/* ReadApplications returns all Applications ordered by ID */
+func (d *MemDriver) ReadApplications(ctx context.Context) ([]*types.Application, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	var applications []*types.Application
+	for _, id := range d.applicationIDs() {
+		applications = append(applications, d.application(id))
+	}
+
+	return applications, nil
+}
+
+/* ReadApplication returns a single Application by its ID */
+func (d *MemDriver) ReadApplication(ctx context.Context, id string) (*types.Application, error) {
+	if id == "" {
+		return nil, ErrMissingID
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if _, ok := d.applications[id]; !ok {
+		return nil, fmt.Errorf("%w: application %s", ErrNotFound, id)
+	}
+
+	return d.application(id), nil
+}
+
+/* ReadApplicationsPage returns one page of the Applications matching the query options, ordered by ID */
+func (d *MemDriver) ReadApplicationsPage(ctx context.Context, options *types.QueryOptions) (*types.ApplicationsPage, error) {
+	if options == nil {
+		options = &types.QueryOptions{}
+	}
+
+	err := options.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	limit := options.PageLimit()
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	page := &types.ApplicationsPage{Applications: []*types.Application{}}
+	for _, id := range d.applicationIDs() {
+		app := d.application(id)
+		if !matchesApplication(app, options) {
+			continue
+		}
+
+		if len(page.Applications) == limit {
+			page.NextCursor = page.Applications[limit-1].ID
+			break
+		}
+
+		page.Applications = append(page.Applications, app)
+	}
+
+	return page, nil
+}
+
+func matchesApplication(app *types.Application, options *types.QueryOptions) bool {
+	return app.ID > options.Cursor &&
+		(options.UserID == "" || app.UserID == options.UserID) &&
+		(options.Status == "" || app.Status == options.Status) &&
+		(options.PayPlan == "" || app.Limit.PayPlan.Type == options.PayPlan) &&
+		(options.Dummy == nil || app.Dummy == *options.Dummy) &&
+		(options.CreatedAfter.IsZero() || app.CreatedAt.After(options.CreatedAfter))
+}
+
+// ReadApplicationsUpdatedSince returns all Applications whose row or child table rows changed after the given time.
+// Like Postgres comparing with NULL, the zero time matches nothing
+func (d *MemDriver) ReadApplicationsUpdatedSince(ctx context.Context, since time.Time) ([]*types.Application, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	var applications []*types.Application
+	for _, id := range d.applicationIDs() {
+		if !since.IsZero() && d.applications[id].UpdatedAt.After(since) {
+			applications = append(applications, d.application(id))
+		}
+	}
+
+	return applications, nil
+}
+
+func (d *MemDriver) applicationIDs() []string {
+	ids := make([]string, 0, len(d.applications))
+	for id := range d.applications {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+/* application joins the app's row with its child table rows like SelectApplications */
+func (d *MemDriver) application(id string) *types.Application {
+	app := *d.applications[id]
+
+	if limit, ok := d.appLimits[id]; ok {
+		app.Limit = types.AppLimit{PayPlan: limit.PayPlan, CustomLimit: limit.CustomLimit}
+		if payPlan, ok := d.payPlans[limit.PayPlan.Type]; ok {
+			app.Limit.PayPlan.Limit = payPlan.Limit
+		}
+	}
+	if aat, ok := d.gatewayAATs[id]; ok {
+		app.GatewayAAT = *aat
+		app.GatewayAAT.ID = ""
+	}
+	if settings, ok := d.gatewaySettings[id]; ok {
+		app.GatewaySettings = *settings
+		app.GatewaySettings.ID = ""
+	}
+	if settings, ok := d.notificationSettings[id]; ok {
+		app.NotificationSettings = *settings
+		app.NotificationSettings.ID = ""
+	}
+
+	return &app
+}
+
+/* ReadPayPlans returns all pay plans ordered by type */
+func (d *MemDriver) ReadPayPlans(ctx context.Context) ([]*types.PayPlan, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	var payPlans []*types.PayPlan
+	for _, payPlan := range d.payPlans {
+		if err := payPlan.Validate(); err != nil {
+			return nil, err
+		}
+
+		payPlans = append(payPlans, &types.PayPlan{Type: payPlan.Type, Limit: payPlan.Limit})
+	}
+	sort.Slice(payPlans, func(i, j int) bool { return payPlans[i].Type < payPlans[j].Type })
+
+	return payPlans, nil
+}
+
+/* WriteApplication saves input Application */
+func (d *MemDriver) WriteApplication(ctx context.Context, app *types.Application) (*types.Application, error) {
+	appIsInvalid := app.Validate()
+	if appIsInvalid != nil {
+		return nil, appIsInvalid
+	}
+
+	id, err := generateRandomID()
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.write(func(tx *tx) error {
+		if _, ok := d.payPlans[app.Limit.PayPlan.Type]; !ok {
+			return fmt.Errorf("%w: pay plan %q", ErrInvalidReference, app.Limit.PayPlan.Type)
+		}
+
+		app.ID = id
+		app.CreatedAt = tx.now
+		app.UpdatedAt = tx.now
+
+		row := &types.Application{
+			ID:           app.ID,
+			UserID:       app.UserID,
+			Name:         app.Name,
+			ContactEmail: app.ContactEmail,
+			Description:  app.Description,
+			Owner:        app.Owner,
+			URL:          app.URL,
+			Status:       app.Status,
+			Dummy:        app.Dummy,
+			CreatedAt:    app.CreatedAt,
+			UpdatedAt:    app.UpdatedAt,
+		}
+		d.applications[id] = row
+		tx.insert(row)
+
+		limit := &types.AppLimit{ID: id, PayPlan: types.PayPlan{Type: app.Limit.PayPlan.Type}, CustomLimit: app.Limit.CustomLimit}
+		d.appLimits[id] = limit
+		tx.insert(limit)
+		tx.touchApplication(id)
+
+		if app.GatewayAAT.Version != "" || app.GatewayAAT.PrivateKey != "" {
+			aat := app.GatewayAAT
+			aat.ID = id
+			d.gatewayAATs[id] = &aat
+			tx.insert(&aat)
+			tx.touchApplication(id)
+		}
+
+		settings := app.GatewaySettings
+		if settings.SecretKey != "" || len(settings.WhitelistContracts) != 0 || len(settings.WhitelistMethods) != 0 ||
+			len(settings.WhitelistOrigins) != 0 || len(settings.WhitelistUserAgents) != 0 || len(settings.WhitelistBlockchains) != 0 {
+			settings.ID = id
+			settings.WhitelistContracts = nilIfEmpty(settings.WhitelistContracts)
+			settings.WhitelistMethods = nilIfEmpty(settings.WhitelistMethods)
+			d.gatewaySettings[id] = &settings
+			tx.insert(&settings)
+			tx.touchApplication(id)
+		}
+
+		notificationSettings := app.NotificationSettings
+		notificationSettings.ID = id
+		d.notificationSettings[id] = &notificationSettings
+		tx.insert(&notificationSettings)
+		tx.touchApplication(id)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return app, nil
+}
+
+// UpdateApplication updates Application and related table rows, fields left empty keep their value.
+// When ExpectedUpdatedAt is set the update fails with ErrConflict if the application was updated at another time
+func (d *MemDriver) UpdateApplication(ctx context.Context, id string, update *types.UpdateApplication) error {
+	if id == "" {
+		return ErrMissingID
+	}
+
+	invalidUpdate := update.Validate()
+	if invalidUpdate != nil {
+		return invalidUpdate
+	}
+
+	return d.write(func(tx *tx) error {
+		if update.Limit != nil {
+			if _, ok := d.payPlans[update.Limit.PayPlan.Type]; !ok {
+				return fmt.Errorf("%w: pay plan %q", ErrInvalidReference, update.Limit.PayPlan.Type)
+			}
+		}
+
+		if expected := update.ExpectedUpdatedAt; expected != nil {
+			app, ok := d.applications[id]
+			if !ok {
+				return fmt.Errorf("%w: application %s", ErrNotFound, id)
+			}
+			if !app.UpdatedAt.Equal(*expected) {
+				return fmt.Errorf("%w: application %s was updated at %s", ErrConflict, id, app.UpdatedAt.Format(time.RFC3339Nano))
+			}
+		}
+
+		// Like the upsert in Postgres, an app that does not exist is created with the updated fields
+		if previous, ok := d.applications[id]; ok {
+			app := *previous
+			app.Name = stringValue(update.Name, app.Name)
+			app.Status = types.AppStatus(stringValue(string(update.Status), string(app.Status)))
+			if !update.FirstDateSurpassed.IsZero() {
+				app.FirstDateSurpassed = timestamp(update.FirstDateSurpassed)
+			}
+			app.UpdatedAt = tx.now
+			d.applications[id] = &app
+			tx.update(previous, &app)
+		} else {
+			app := &types.Application{
+				ID:                 id,
+				Name:               update.Name,
+				Status:             update.Status,
+				FirstDateSurpassed: timestamp(update.FirstDateSurpassed),
+				UpdatedAt:          tx.now,
+			}
+			d.applications[id] = app
+			tx.insert(app)
+		}
+
+		if update.Limit != nil {
+			limit := &types.AppLimit{ID: id, PayPlan: types.PayPlan{Type: update.Limit.PayPlan.Type}}
+			if update.Limit.PayPlan.Type == types.Enterprise {
+				limit.CustomLimit = update.Limit.CustomLimit
+			}
+
+			tx.upsertAppLimit(limit)
+			tx.touchApplication(id)
+		}
+
+		if settings := update.GatewaySettings; settings != nil && (settings.SecretKey != "" || settings.SecretKeyRequired != nil ||
+			len(settings.WhitelistContracts) != 0 || len(settings.WhitelistMethods) != 0 ||
+			len(settings.WhitelistOrigins) != 0 || len(settings.WhitelistUserAgents) != 0 || len(settings.WhitelistBlockchains) != 0) {
+			tx.upsertGatewaySettings(id, settings)
+			tx.touchApplication(id)
+		}
+
+		if settings := update.NotificationSettings; settings != nil && (settings.SignedUp != nil || settings.Quarter != nil ||
+			settings.Half != nil || settings.ThreeQuarters != nil || settings.Full != nil) {
+			tx.upsertNotificationSettings(id, settings)
+			tx.touchApplication(id)
+		}
+
+		return nil
+	})
+}
+
+func (tx *tx) upsertAppLimit(limit *types.AppLimit) {
+	previous, ok := tx.d.appLimits[limit.ID]
+	tx.d.appLimits[limit.ID] = limit
+	if ok {
+		tx.update(previous, limit)
+		return
+	}
+
+	tx.insert(limit)
+}
+
+func (tx *tx) upsertGatewaySettings(id string, update *types.UpdateGatewaySettings) {
+	settings := types.GatewaySettings{ID: id}
+
+	previous, ok := tx.d.gatewaySettings[id]
+	if ok {
+		settings = *previous
+	}
+
+	settings.SecretKey = stringValue(update.SecretKey, settings.SecretKey)
+	settings.SecretKeyRequired = boolValue(update.SecretKeyRequired, settings.SecretKeyRequired)
+	if len(update.WhitelistContracts) != 0 {
+		settings.WhitelistContracts = update.WhitelistContracts
+	}
+	if len(update.WhitelistMethods) != 0 {
+		settings.WhitelistMethods = update.WhitelistMethods
+	}
+	if update.WhitelistOrigins != nil {
+		settings.WhitelistOrigins = update.WhitelistOrigins
+	}
+	if update.WhitelistUserAgents != nil {
+		settings.WhitelistUserAgents = update.WhitelistUserAgents
+	}
+	if update.WhitelistBlockchains != nil {
+		settings.WhitelistBlockchains = update.WhitelistBlockchains
+	}
+
+	tx.d.gatewaySettings[id] = &settings
+	if ok {
+		tx.update(previous, &settings)
+		return
+	}
+
+	tx.insert(&settings)
+}
+
+func (tx *tx) upsertNotificationSettings(id string, update *types.UpdateNotificationSettings) {
+	settings := types.NotificationSettings{ID: id}
+
+	previous, ok := tx.d.notificationSettings[id]
+	if ok {
+		settings = *previous
+	}
+
+	settings.SignedUp = boolValue(update.SignedUp, settings.SignedUp)
+	settings.Quarter = boolValue(update.Quarter, settings.Quarter)
+	settings.Half = boolValue(update.Half, settings.Half)
+	settings.ThreeQuarters = boolValue(update.ThreeQuarters, settings.ThreeQuarters)
+	settings.Full = boolValue(update.Full, settings.Full)
+
+	tx.d.notificationSettings[id] = &settings
+	if ok {
+		tx.update(previous, &settings)
+		return
+	}
+
+	tx.insert(&settings)
+}
+
+/* UpdateAppFirstDateSurpassed updates Application's firstDateSurpassed field */
+func (d *MemDriver) UpdateAppFirstDateSurpassed(ctx context.Context, update *types.UpdateFirstDateSurpassed) error {
+	return d.write(func(tx *tx) error {
+		updated := make(map[string]bool, len(update.ApplicationIDs))
+
+		for _, id := range update.ApplicationIDs {
+			previous, ok := d.applications[id]
+			if !ok || updated[id] {
+				continue
+			}
+			updated[id] = true
+
+			app := *previous
+			app.FirstDateSurpassed = timestamp(update.FirstDateSurpassed)
+			app.UpdatedAt = tx.now
+			d.applications[id] = &app
+			tx.update(previous, &app)
+		}
+
+		return nil
+	})
+}
+
+/* RemoveApplication updates Application's status field to AwaitingGracePeriod */
+func (d *MemDriver) RemoveApplication(ctx context.Context, id string) error {
+	if id == "" {
+		return ErrMissingID
+	}
+
+	return d.write(func(tx *tx) error {
+		previous, ok := d.applications[id]
+		if !ok {
+			return nil
+		}
+
+		app := *previous
+		app.Status = types.AwaitingGracePeriod
+		app.UpdatedAt = tx.now
+		d.applications[id] = &app
+		tx.update(previous, &app)
+
+		return nil
+	})
+}
+
+/* nilIfEmpty mirrors whitelists being saved as NULL when they are empty */
+func nilIfEmpty[T any](values []T) []T {
+	if len(values) == 0 {
+		return nil
+	}
+
+	return values
+}
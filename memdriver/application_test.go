@@ -0,0 +1,124 @@
+package memdriver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pokt-foundation/portal-db/driver"
+	"github.com/pokt-foundation/portal-db/types"
+	"github.com/stretchr/testify/require"
+)
+
+var _ driver.Driver = &MemDriver{}
+
+func TestMemDriver_Applications(t *testing.T) {
+	c := require.New(t)
+	ctx := context.Background()
+	d := NewMemDriver()
+
+	_, err := d.WriteApplication(ctx, &types.Application{Status: "NOT_A_STATUS"})
+	c.ErrorIs(err, types.ErrInvalidAppStatus)
+	_, err = d.WriteApplication(ctx, &types.Application{Limit: types.AppLimit{PayPlan: types.PayPlan{Type: types.FreetierV0}, CustomLimit: 10}})
+	c.ErrorIs(err, types.ErrNotEnterprisePlan)
+	_, err = d.WriteApplication(ctx, &types.Application{})
+	c.ErrorIs(err, ErrInvalidReference)
+
+	app, err := d.WriteApplication(ctx, &types.Application{
+		UserID:          "user_1",
+		Name:            "pokt_app_1",
+		Status:          types.InService,
+		GatewayAAT:      types.GatewayAAT{ApplicationPublicKey: "public_key_1", Version: "0.0.1"},
+		GatewaySettings: types.GatewaySettings{SecretKey: "secret_key_1", WhitelistOrigins: []string{"pokt.network"}},
+		Limit:           types.AppLimit{PayPlan: types.PayPlan{Type: types.FreetierV0}},
+	})
+	c.NoError(err)
+	c.Len(app.ID, idLength)
+	c.False(app.CreatedAt.IsZero())
+
+	read, err := d.ReadApplication(ctx, app.ID)
+	c.NoError(err)
+	c.Equal(types.AppLimit{PayPlan: types.PayPlan{Type: types.FreetierV0, Limit: 250000}}, read.Limit)
+	c.Equal(types.GatewayAAT{ApplicationPublicKey: "public_key_1", Version: "0.0.1"}, read.GatewayAAT)
+	c.Equal("secret_key_1", read.GatewaySettings.SecretKey)
+	c.Equal([]string{"pokt.network"}, read.GatewaySettings.WhitelistOrigins)
+
+	_, err = d.ReadApplication(ctx, "")
+	c.ErrorIs(err, ErrMissingID)
+	_, err = d.ReadApplication(ctx, "missing")
+	c.ErrorIs(err, ErrNotFound)
+
+	before := read.UpdatedAt
+	time.Sleep(time.Millisecond)
+
+	secretKeyRequired := true
+	err = d.UpdateApplication(ctx, app.ID, &types.UpdateApplication{
+		Name:            "pokt_app_renamed",
+		GatewaySettings: &types.UpdateGatewaySettings{SecretKeyRequired: &secretKeyRequired},
+		Limit:           &types.AppLimit{PayPlan: types.PayPlan{Type: types.Enterprise}, CustomLimit: 2000000},
+	})
+	c.NoError(err)
+	c.ErrorIs(d.UpdateApplication(ctx, app.ID, nil), types.ErrNoFieldsToUpdate)
+	c.ErrorIs(d.UpdateApplication(ctx, "", &types.UpdateApplication{}), ErrMissingID)
+
+	read, err = d.ReadApplication(ctx, app.ID)
+	c.NoError(err)
+	c.Equal("pokt_app_renamed", read.Name)
+	c.Equal(types.InService, read.Status)
+	c.Equal(types.AppLimit{PayPlan: types.PayPlan{Type: types.Enterprise}, CustomLimit: 2000000}, read.Limit)
+	c.Equal("secret_key_1", read.GatewaySettings.SecretKey)
+	c.True(read.GatewaySettings.SecretKeyRequired)
+	c.True(read.UpdatedAt.After(before))
+
+	updated, err := d.ReadApplicationsUpdatedSince(ctx, before)
+	c.NoError(err)
+	c.Len(updated, 1)
+
+	surpassed := time.Date(2022, 11, 11, 11, 11, 11, 0, time.UTC)
+	c.NoError(d.UpdateAppFirstDateSurpassed(ctx, &types.UpdateFirstDateSurpassed{
+		ApplicationIDs:     []string{app.ID},
+		FirstDateSurpassed: surpassed,
+	}))
+	c.NoError(d.RemoveApplication(ctx, app.ID))
+
+	read, err = d.ReadApplication(ctx, app.ID)
+	c.NoError(err)
+	c.Equal(surpassed, read.FirstDateSurpassed)
+	c.Equal(types.AwaitingGracePeriod, read.Status)
+
+	payPlans, err := d.ReadPayPlans(ctx)
+	c.NoError(err)
+	c.Len(payPlans, len(defaultPayPlans))
+	c.Equal(types.Enterprise, payPlans[0].Type)
+}
+
+func TestMemDriver_ReadApplicationsPage(t *testing.T) {
+	c := require.New(t)
+	ctx := context.Background()
+	d := NewMemDriver()
+
+	for i := 0; i < 5; i++ {
+		_, err := d.WriteApplication(ctx, &types.Application{UserID: "user_1", Limit: types.AppLimit{PayPlan: types.PayPlan{Type: types.FreetierV0}}})
+		c.NoError(err)
+	}
+	_, err := d.WriteApplication(ctx, &types.Application{UserID: "user_2", Limit: types.AppLimit{PayPlan: types.PayPlan{Type: types.TestPlanV0}}})
+	c.NoError(err)
+
+	page, err := d.ReadApplicationsPage(ctx, &types.QueryOptions{Limit: 3, UserID: "user_1"})
+	c.NoError(err)
+	c.Len(page.Applications, 3)
+	c.Equal(page.Applications[2].ID, page.NextCursor)
+
+	page, err = d.ReadApplicationsPage(ctx, &types.QueryOptions{Limit: 3, UserID: "user_1", Cursor: page.NextCursor})
+	c.NoError(err)
+	c.Len(page.Applications, 2)
+	c.Empty(page.NextCursor)
+
+	page, err = d.ReadApplicationsPage(ctx, &types.QueryOptions{PayPlan: types.TestPlanV0})
+	c.NoError(err)
+	c.Len(page.Applications, 1)
+	c.Equal("user_2", page.Applications[0].UserID)
+
+	_, err = d.ReadApplicationsPage(ctx, &types.QueryOptions{Limit: -1})
+	c.ErrorIs(err, types.ErrInvalidPageLimit)
+}
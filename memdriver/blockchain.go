@@ -0,0 +1,438 @@
+package memdriver
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pokt-foundation/portal-db/types"
+)
+
+/* ReadBlockchains returns all blockchains ordered by ID */
+func (d *MemDriver) ReadBlockchains(ctx context.Context) ([]*types.Blockchain, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	var blockchains []*types.Blockchain
+	for _, id := range d.blockchainIDs() {
+		blockchains = append(blockchains, d.blockchain(id))
+	}
+
+	return blockchains, nil
+}
+
+/* ReadBlockchain returns a single Blockchain by its ID */
+func (d *MemDriver) ReadBlockchain(ctx context.Context, id string) (*types.Blockchain, error) {
+	if id == "" {
+		return nil, ErrMissingID
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if _, ok := d.blockchains[id]; !ok {
+		return nil, fmt.Errorf("%w: blockchain %s", ErrNotFound, id)
+	}
+
+	return d.blockchain(id), nil
+}
+
+// ReadBlockchainsUpdatedSince returns all blockchains whose row, redirects or sync check options changed after the given time.
+// Like Postgres comparing with NULL, the zero time matches nothing
+func (d *MemDriver) ReadBlockchainsUpdatedSince(ctx context.Context, since time.Time) ([]*types.Blockchain, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	var blockchains []*types.Blockchain
+	for _, id := range d.blockchainIDs() {
+		if !since.IsZero() && d.blockchains[id].UpdatedAt.After(since) {
+			blockchains = append(blockchains, d.blockchain(id))
+		}
+	}
+
+	return blockchains, nil
+}
+
+func (d *MemDriver) blockchainIDs() []string {
+	ids := make([]string, 0, len(d.blockchains))
+	for id := range d.blockchains {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+/* blockchain joins the blockchain's row with its child table rows like SelectBlockchains */
+func (d *MemDriver) blockchain(id string) *types.Blockchain {
+	blockchain := *d.blockchains[id]
+
+	if options, ok := d.syncCheckOptions[id]; ok {
+		blockchain.SyncCheck = options.syncCheck
+		blockchain.SyncCheckOptions = options.SyncCheckOptions
+		blockchain.SyncCheckOptions.BlockchainID = ""
+	}
+
+	blockchain.Redirects = []types.Redirect{}
+	for _, redirect := range d.redirects {
+		if redirect.BlockchainID == id {
+			blockchain.Redirects = append(blockchain.Redirects, types.Redirect{
+				Alias:          redirect.Alias,
+				LoadBalancerID: redirect.LoadBalancerID,
+				Domain:         redirect.Domain,
+			})
+		}
+	}
+
+	return &blockchain
+}
+
+/* WriteBlockchain saves input Blockchain struct */
+func (d *MemDriver) WriteBlockchain(ctx context.Context, blockchain *types.Blockchain) (*types.Blockchain, error) {
+	err := d.write(func(tx *tx) error {
+		if _, ok := d.blockchains[blockchain.ID]; ok {
+			return fmt.Errorf("%w: blockchain %s", ErrAlreadyExists, blockchain.ID)
+		}
+
+		blockchain.CreatedAt = tx.now
+		blockchain.UpdatedAt = tx.now
+
+		row := &types.Blockchain{
+			ID:                blockchain.ID,
+			Altruist:          blockchain.Altruist,
+			Blockchain:        blockchain.Blockchain,
+			ChainID:           blockchain.ChainID,
+			ChainIDCheck:      blockchain.ChainIDCheck,
+			Description:       blockchain.Description,
+			EnforceResult:     blockchain.EnforceResult,
+			Network:           blockchain.Network,
+			Path:              blockchain.Path,
+			Ticker:            blockchain.Ticker,
+			BlockchainAliases: blockchain.BlockchainAliases,
+			LogLimitBlocks:    blockchain.LogLimitBlocks,
+			RequestTimeout:    blockchain.RequestTimeout,
+			Active:            blockchain.Active,
+			CreatedAt:         blockchain.CreatedAt,
+			UpdatedAt:         blockchain.UpdatedAt,
+		}
+		d.blockchains[blockchain.ID] = row
+		tx.insert(row)
+
+		options := blockchain.SyncCheckOptions
+		if blockchain.SyncCheck != "" || options.Body != "" || options.Path != "" || options.ResultKey != "" || options.Allowance != 0 {
+			options.BlockchainID = blockchain.ID
+			d.syncCheckOptions[blockchain.ID] = &syncCheckOptionsRow{SyncCheckOptions: options, syncCheck: blockchain.SyncCheck}
+			tx.insert(&options)
+			tx.touchBlockchain(blockchain.ID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return blockchain, nil
+}
+
+/* UpdateBlockchain updates Blockchain and its sync check options, fields left empty keep their value */
+func (d *MemDriver) UpdateBlockchain(ctx context.Context, id string, update *types.UpdateBlockchain) error {
+	if id == "" {
+		return ErrMissingID
+	}
+
+	invalidUpdate := update.Validate()
+	if invalidUpdate != nil {
+		return invalidUpdate
+	}
+
+	return d.write(func(tx *tx) error {
+		previous, ok := d.blockchains[id]
+		if !ok {
+			return fmt.Errorf("%w: blockchain %s", ErrNotFound, id)
+		}
+
+		blockchain := *previous
+		blockchain.Altruist = stringValue(update.Altruist, blockchain.Altruist)
+		blockchain.Blockchain = stringValue(update.Blockchain, blockchain.Blockchain)
+		blockchain.ChainID = stringValue(update.ChainID, blockchain.ChainID)
+		blockchain.ChainIDCheck = stringValue(update.ChainIDCheck, blockchain.ChainIDCheck)
+		blockchain.Description = stringValue(update.Description, blockchain.Description)
+		blockchain.EnforceResult = stringValue(update.EnforceResult, blockchain.EnforceResult)
+		blockchain.Network = stringValue(update.Network, blockchain.Network)
+		blockchain.Path = stringValue(update.Path, blockchain.Path)
+		blockchain.Ticker = stringValue(update.Ticker, blockchain.Ticker)
+		if update.BlockchainAliases != nil {
+			blockchain.BlockchainAliases = update.BlockchainAliases
+		}
+		if update.LogLimitBlocks != 0 {
+			blockchain.LogLimitBlocks = update.LogLimitBlocks
+		}
+		if update.RequestTimeout != 0 {
+			blockchain.RequestTimeout = update.RequestTimeout
+		}
+		blockchain.UpdatedAt = tx.now
+		d.blockchains[id] = &blockchain
+		tx.update(previous, &blockchain)
+
+		options := update.SyncCheckOptions
+		if update.SyncCheck != "" || options != nil && (options.Body != "" || options.Path != "" || options.ResultKey != "" || options.Allowance != 0) {
+			tx.upsertSyncCheckOptions(id, update)
+			tx.touchBlockchain(id)
+		}
+
+		return nil
+	})
+}
+
+func (tx *tx) upsertSyncCheckOptions(id string, update *types.UpdateBlockchain) {
+	row := syncCheckOptionsRow{SyncCheckOptions: types.SyncCheckOptions{BlockchainID: id}}
+
+	previous, ok := tx.d.syncCheckOptions[id]
+	if ok {
+		row = *previous
+	}
+
+	row.syncCheck = stringValue(update.SyncCheck, row.syncCheck)
+	if options := update.SyncCheckOptions; options != nil {
+		row.Body = stringValue(options.Body, row.Body)
+		row.Path = stringValue(options.Path, row.Path)
+		row.ResultKey = stringValue(options.ResultKey, row.ResultKey)
+		if options.Allowance != 0 {
+			row.Allowance = options.Allowance
+		}
+	}
+
+	tx.d.syncCheckOptions[id] = &row
+	if ok {
+		tx.update(&previous.SyncCheckOptions, &row.SyncCheckOptions)
+		return
+	}
+
+	tx.insert(&row.SyncCheckOptions)
+}
+
+// RemoveBlockchain deletes a Blockchain and its sync check options.
+// It fails with ErrBlockchainInUse while redirects or application whitelists reference the blockchain
+func (d *MemDriver) RemoveBlockchain(ctx context.Context, id string) error {
+	if id == "" {
+		return ErrMissingID
+	}
+
+	return d.write(func(tx *tx) error {
+		blockchain, ok := d.blockchains[id]
+		if !ok {
+			return fmt.Errorf("%w: blockchain %s", ErrNotFound, id)
+		}
+
+		var redirects int
+		for _, redirect := range d.redirects {
+			if redirect.BlockchainID == id {
+				redirects++
+			}
+		}
+		if redirects > 0 {
+			return fmt.Errorf("%w: blockchain %s has %d redirects", ErrBlockchainInUse, id, redirects)
+		}
+
+		for appID, settings := range d.gatewaySettings {
+			if whitelists(settings, id) {
+				return fmt.Errorf("%w: application %s whitelists blockchain %s", ErrBlockchainInUse, appID, id)
+			}
+		}
+
+		if options, ok := d.syncCheckOptions[id]; ok {
+			delete(d.syncCheckOptions, id)
+			tx.delete(&options.SyncCheckOptions)
+			tx.touchBlockchain(id)
+			blockchain = d.blockchains[id]
+		}
+
+		delete(d.blockchains, id)
+		tx.delete(blockchain)
+
+		return nil
+	})
+}
+
+/* whitelists returns true if the gateway settings whitelist the blockchain, its contracts or its methods */
+func whitelists(settings *types.GatewaySettings, id string) bool {
+	for _, blockchainID := range settings.WhitelistBlockchains {
+		if blockchainID == id {
+			return true
+		}
+	}
+	for _, contract := range settings.WhitelistContracts {
+		if contract.BlockchainID == id {
+			return true
+		}
+	}
+	for _, method := range settings.WhitelistMethods {
+		if method.BlockchainID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WriteRedirect saves input Redirect struct.
+// It must be called separately from WriteBlockchain due to how new chains are added
+func (d *MemDriver) WriteRedirect(ctx context.Context, redirect *types.Redirect) (*types.Redirect, error) {
+	invalidRedirect := redirect.Validate()
+	if invalidRedirect != nil {
+		return nil, invalidRedirect
+	}
+
+	err := d.write(func(tx *tx) error {
+		if _, ok := d.loadBalancers[redirect.LoadBalancerID]; !ok {
+			return fmt.Errorf("%w: load balancer %s", ErrInvalidReference, redirect.LoadBalancerID)
+		}
+		if _, ok := d.blockchains[redirect.BlockchainID]; !ok {
+			return fmt.Errorf("%w: blockchain %q", ErrInvalidReference, redirect.BlockchainID)
+		}
+		for _, existing := range d.redirects {
+			if existing.BlockchainID == redirect.BlockchainID && existing.Domain == redirect.Domain {
+				return fmt.Errorf("%w: redirect of blockchain %s to %s", ErrAlreadyExists, redirect.BlockchainID, redirect.Domain)
+			}
+		}
+
+		redirect.CreatedAt = tx.now
+		redirect.UpdatedAt = tx.now
+
+		row := *redirect
+		d.redirects = append(d.redirects, &row)
+		tx.insert(&row)
+		tx.touchBlockchain(redirect.BlockchainID)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return redirect, nil
+}
+
+/* ReadRedirects returns the redirects of the blockchain and load balancer set in the filter ordered by blockchain and domain, all of them if it is nil */
+func (d *MemDriver) ReadRedirects(ctx context.Context, filter *types.RedirectFilter) ([]*types.Redirect, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if filter == nil {
+		filter = &types.RedirectFilter{}
+	}
+
+	var redirects []*types.Redirect
+	for _, redirect := range d.redirects {
+		if filter.BlockchainID != "" && redirect.BlockchainID != filter.BlockchainID {
+			continue
+		}
+		if filter.LoadBalancerID != "" && redirect.LoadBalancerID != filter.LoadBalancerID {
+			continue
+		}
+
+		row := *redirect
+		redirects = append(redirects, &row)
+	}
+
+	sort.Slice(redirects, func(i, j int) bool {
+		if redirects[i].BlockchainID != redirects[j].BlockchainID {
+			return redirects[i].BlockchainID < redirects[j].BlockchainID
+		}
+		return redirects[i].Domain < redirects[j].Domain
+	})
+
+	return redirects, nil
+}
+
+/* UpdateRedirect updates the Redirect of the blockchain to the domain, fields left empty keep their value */
+func (d *MemDriver) UpdateRedirect(ctx context.Context, blockchainID, domain string, update *types.UpdateRedirect) error {
+	if blockchainID == "" || domain == "" {
+		return ErrMissingID
+	}
+
+	invalidUpdate := update.Validate()
+	if invalidUpdate != nil {
+		return invalidUpdate
+	}
+
+	return d.write(func(tx *tx) error {
+		if _, ok := d.loadBalancers[update.LoadBalancerID]; update.LoadBalancerID != "" && !ok {
+			return fmt.Errorf("%w: load balancer %s", ErrInvalidReference, update.LoadBalancerID)
+		}
+
+		i := d.redirectIndex(blockchainID, domain)
+		if i < 0 {
+			return fmt.Errorf("%w: redirect %s of blockchain %s", ErrNotFound, domain, blockchainID)
+		}
+
+		previous := d.redirects[i]
+		redirect := *previous
+		redirect.Alias = stringValue(update.Alias, redirect.Alias)
+		redirect.Domain = stringValue(update.Domain, redirect.Domain)
+		redirect.LoadBalancerID = stringValue(update.LoadBalancerID, redirect.LoadBalancerID)
+		redirect.UpdatedAt = tx.now
+
+		if j := d.redirectIndex(blockchainID, redirect.Domain); j >= 0 && j != i {
+			return fmt.Errorf("%w: redirect of blockchain %s to %s", ErrAlreadyExists, blockchainID, redirect.Domain)
+		}
+
+		d.redirects[i] = &redirect
+		tx.update(previous, &redirect)
+		tx.touchBlockchain(blockchainID)
+
+		return nil
+	})
+}
+
+/* RemoveRedirect deletes the Redirect of the blockchain to the domain */
+func (d *MemDriver) RemoveRedirect(ctx context.Context, blockchainID, domain string) error {
+	if blockchainID == "" || domain == "" {
+		return ErrMissingID
+	}
+
+	return d.write(func(tx *tx) error {
+		i := d.redirectIndex(blockchainID, domain)
+		if i < 0 {
+			return fmt.Errorf("%w: redirect %s of blockchain %s", ErrNotFound, domain, blockchainID)
+		}
+
+		redirect := d.redirects[i]
+		d.redirects = append(d.redirects[:i:i], d.redirects[i+1:]...)
+		tx.delete(redirect)
+		tx.touchBlockchain(blockchainID)
+
+		return nil
+	})
+}
+
+/* redirectIndex returns the index of the blockchain's redirect to the domain, -1 if there is none */
+func (d *MemDriver) redirectIndex(blockchainID, domain string) int {
+	for i, redirect := range d.redirects {
+		if redirect.BlockchainID == blockchainID && redirect.Domain == domain {
+			return i
+		}
+	}
+
+	return -1
+}
+
+/* Activate chain toggles chain.active field on or off */
+func (d *MemDriver) ActivateChain(ctx context.Context, id string, active bool) error {
+	return d.write(func(tx *tx) error {
+		previous, ok := d.blockchains[id]
+		if !ok {
+			return nil
+		}
+
+		blockchain := *previous
+		blockchain.Active = active
+		blockchain.UpdatedAt = tx.now
+		d.blockchains[id] = &blockchain
+		tx.update(previous, &blockchain)
+
+		return nil
+	})
+}
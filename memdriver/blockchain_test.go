@@ -0,0 +1,79 @@
+package memdriver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pokt-foundation/portal-db/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemDriver_Blockchains(t *testing.T) {
+	c := require.New(t)
+	ctx := context.Background()
+	d := NewMemDriver()
+
+	blockchain, err := d.WriteBlockchain(ctx, &types.Blockchain{
+		ID:                "0001",
+		Blockchain:        "pokt-mainnet",
+		BlockchainAliases: []string{"pokt-mainnet"},
+		SyncCheck:         "synccheck",
+		SyncCheckOptions:  types.SyncCheckOptions{Body: "{}", Allowance: 1},
+	})
+	c.NoError(err)
+	c.False(blockchain.CreatedAt.IsZero())
+
+	_, err = d.WriteBlockchain(ctx, &types.Blockchain{ID: "0001"})
+	c.ErrorIs(err, ErrAlreadyExists)
+
+	lb, err := d.WriteLoadBalancer(ctx, &types.LoadBalancer{UserID: "user_1", Users: []types.UserAccess{{UserID: "user_1"}}})
+	c.NoError(err)
+
+	redirect := &types.Redirect{BlockchainID: "0001", Alias: "pokt-mainnet", Domain: "pokt.network", LoadBalancerID: lb.ID}
+	_, err = d.WriteRedirect(ctx, redirect)
+	c.NoError(err)
+	_, err = d.WriteRedirect(ctx, redirect)
+	c.ErrorIs(err, ErrAlreadyExists)
+	_, err = d.WriteRedirect(ctx, &types.Redirect{BlockchainID: "missing", Domain: "pokt.network", LoadBalancerID: lb.ID})
+	c.ErrorIs(err, ErrInvalidReference)
+	_, err = d.WriteRedirect(ctx, &types.Redirect{BlockchainID: "0001", Domain: "pokt.network", LoadBalancerID: "lb_1"})
+	c.ErrorIs(err, ErrInvalidReference)
+	_, err = d.WriteRedirect(ctx, &types.Redirect{BlockchainID: "0001", Domain: "pokt..network", LoadBalancerID: lb.ID})
+	c.ErrorIs(err, types.ErrInvalidDomain)
+
+	c.NoError(d.ActivateChain(ctx, "0001", true))
+
+	read, err := d.ReadBlockchain(ctx, "0001")
+	c.NoError(err)
+	c.True(read.Active)
+	c.Equal("synccheck", read.SyncCheck)
+	c.Equal(types.SyncCheckOptions{Body: "{}", Allowance: 1}, read.SyncCheckOptions)
+	c.Equal([]types.Redirect{{Alias: "pokt-mainnet", Domain: "pokt.network", LoadBalancerID: lb.ID}}, read.Redirects)
+
+	_, err = d.ReadBlockchain(ctx, "")
+	c.ErrorIs(err, ErrMissingID)
+	_, err = d.ReadBlockchain(ctx, "missing")
+	c.ErrorIs(err, ErrNotFound)
+}
+
+func TestMemDriver_RemoveBlockchainEvents(t *testing.T) {
+	c := require.New(t)
+	ctx := context.Background()
+	d := NewMemDriver()
+
+	_, err := d.WriteBlockchain(ctx, &types.Blockchain{ID: "0001", SyncCheck: "synccheck"})
+	c.NoError(err)
+
+	events, err := d.ReadEventsSince(ctx, 0)
+	c.NoError(err)
+	written := events[len(events)-1].Sequence
+
+	c.NoError(d.RemoveBlockchain(ctx, "0001"))
+
+	// like the triggers, touching the blockchain when its sync check options are deleted is not notified
+	events, err = d.ReadEventsSince(ctx, written)
+	c.NoError(err)
+	c.Len(events, 2)
+	c.Equal([]types.Table{types.TableSyncCheckOptions, types.TableBlockchains}, []types.Table{events[0].Table, events[1].Table})
+	c.Equal([]types.Action{types.ActionDelete, types.ActionDelete}, []types.Action{events[0].Action, events[1].Action})
+}
@@ -0,0 +1,18 @@
+package memdriver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pokt-foundation/portal-db/driver"
+	"github.com/pokt-foundation/portal-db/drivertest"
+)
+
+func TestConformance(t *testing.T) {
+	drivertest.Run(t, func(t *testing.T) driver.Driver {
+		d := NewMemDriver()
+		t.Cleanup(func() { _ = d.Close(context.Background()) })
+
+		return d
+	})
+}
@@ -0,0 +1,186 @@
+package memdriver
+
+import (
+	"context"
+
+	"github.com/pokt-foundation/portal-db/types"
+)
+
+/* ReadEventsSince returns the events saved after the given sequence in the order they were committed */
+func (d *MemDriver) ReadEventsSince(ctx context.Context, sequence int64) ([]*types.Notification, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	var notifications []*types.Notification
+	for _, event := range d.events[d.cursor(sequence):] {
+		notifications = append(notifications, copyNotification(event))
+	}
+
+	return notifications, nil
+}
+
+// Subscribe returns an independent channel with the notifications that pass the options' filter.
+// When FromSequence is set, the events saved after it are replayed first. Each subscription follows the
+// event log at its own pace, so writes never wait for it, and its overflow policy applies once it lags
+// behind by more live events than its buffer holds.
+// The channel is closed when ctx is done, the driver is closed or the overflow policy disconnects it
+func (d *MemDriver) Subscribe(ctx context.Context, options *types.SubscriptionOptions) (<-chan *types.Notification, error) {
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+	if options == nil {
+		options = &types.SubscriptionOptions{}
+	}
+
+	select {
+	case <-d.closed:
+		return nil, ErrListenerClosed
+	default:
+	}
+
+	d.mu.RLock()
+	live := len(d.events)
+	cursor := live
+	if options.FromSequence != nil {
+		cursor = d.cursor(*options.FromSequence)
+	}
+	d.mu.RUnlock()
+
+	out := make(chan *types.Notification)
+
+	go d.serveSubscription(ctx, options, cursor, live, out)
+
+	return out, nil
+}
+
+/* cursor returns the index in the event log of the first event after sequence */
+func (d *MemDriver) cursor(sequence int64) int {
+	if sequence < 0 {
+		return 0
+	}
+	if sequence > int64(len(d.events)) {
+		return len(d.events)
+	}
+
+	return int(sequence)
+}
+
+/* serveSubscription sends the events from cursor on, the ones before live are replayed and don't count towards overflowing */
+func (d *MemDriver) serveSubscription(ctx context.Context, options *types.SubscriptionOptions, cursor, live int,
+	out chan<- *types.Notification) {
+	defer close(out)
+
+	send := func(n *types.Notification) bool {
+		select {
+		case out <- n:
+			return true
+		case <-ctx.Done():
+		case <-d.closed:
+		}
+
+		return false
+	}
+
+	for {
+		pending, ok := d.pending(ctx.Done(), cursor)
+		if !ok {
+			return
+		}
+
+		end := cursor + len(pending)
+		if lag := end - max(cursor, live); lag > options.Buffer() {
+			switch options.Policy() {
+			case types.OverflowDropOldest:
+				pending = pending[len(pending)-options.Buffer():]
+				cursor = end - options.Buffer()
+			case types.OverflowDisconnect:
+				send(&types.Notification{Action: types.ActionResync})
+				return
+			}
+		}
+
+		for _, n := range pending {
+			cursor++
+
+			if options.Matches(n) && !send(copyNotification(n)) {
+				return
+			}
+		}
+	}
+}
+
+// NotificationChannel returns the channel every Notification is sent to, starting with the first event written.
+// Unlike PostgresDriver writes don't wait for it to be read, it is closed when the driver is closed
+func (d *MemDriver) NotificationChannel() <-chan *types.Notification {
+	d.notificationOnce.Do(func() {
+		go d.serveNotificationChannel()
+	})
+
+	return d.notification
+}
+
+func (d *MemDriver) serveNotificationChannel() {
+	defer close(d.notification)
+
+	cursor := 0
+	for {
+		pending, ok := d.pending(nil, cursor)
+		if !ok {
+			return
+		}
+
+		for _, n := range pending {
+			cursor++
+
+			select {
+			case d.notification <- copyNotification(n):
+			case <-d.closed:
+				return
+			}
+		}
+	}
+}
+
+/* pending waits until there are events after cursor and returns them, it returns false once done or the driver is closed */
+func (d *MemDriver) pending(done <-chan struct{}, cursor int) ([]*types.Notification, bool) {
+	for {
+		d.mu.RLock()
+		events, appended := d.events[cursor:], d.appended
+		d.mu.RUnlock()
+
+		select {
+		case <-done:
+			return nil, false
+		case <-d.closed:
+			return nil, false
+		default:
+		}
+
+		if len(events) > 0 {
+			return events, true
+		}
+
+		select {
+		case <-appended:
+		case <-done:
+			return nil, false
+		case <-d.closed:
+			return nil, false
+		}
+	}
+}
+
+/* copyNotification returns a copy of the stored event so receivers can't change the log, the rows it points to are never modified */
+func copyNotification(n *types.Notification) *types.Notification {
+	copied := *n
+
+	return &copied
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,137 @@
+package memdriver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pokt-foundation/portal-db/driver"
+	"github.com/pokt-foundation/portal-db/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemDriver_Events(t *testing.T) {
+	c := require.New(t)
+	ctx := context.Background()
+	d := NewMemDriver()
+
+	notifications := d.NotificationChannel()
+
+	sub, err := d.Subscribe(ctx, &types.SubscriptionOptions{
+		SubscriptionFilter: types.SubscriptionFilter{Tables: []types.Table{types.TableApplications}},
+	})
+	c.NoError(err)
+
+	app, err := d.WriteApplication(ctx, &types.Application{Name: "pokt_app_1", Limit: types.AppLimit{PayPlan: types.PayPlan{Type: types.FreetierV0}}})
+	c.NoError(err)
+
+	// the parent's updated_at bumped by the child table rows is not notified
+	expected := []struct {
+		table  types.Table
+		action types.Action
+	}{
+		{types.TableApplications, types.ActionInsert},
+		{types.TableAppLimits, types.ActionInsert},
+		{types.TableNotificationSettings, types.ActionInsert},
+	}
+	for i, e := range expected {
+		n := <-notifications
+		c.Equal(int64(i+1), n.Sequence)
+		c.Equal(e.table, n.Table)
+		c.Equal(e.action, n.Action)
+	}
+
+	n := <-sub
+	c.Equal(types.ActionInsert, n.Action)
+	c.Equal(&types.Application{ID: app.ID, Name: "pokt_app_1", CreatedAt: app.CreatedAt, UpdatedAt: app.UpdatedAt}, n.Data)
+
+	c.NoError(d.UpdateApplication(ctx, app.ID, &types.UpdateApplication{Name: "pokt_app_renamed"}))
+
+	n = <-sub
+	c.Equal(int64(4), n.Sequence)
+	c.Equal([]string{"name", "updated_at"}, n.ChangedFields)
+	c.Equal("pokt_app_1", n.Previous.(*types.Application).Name)
+
+	events, err := d.ReadEventsSince(ctx, 2)
+	c.NoError(err)
+	c.Len(events, 2)
+
+	from := int64(3)
+	replay, err := d.Subscribe(ctx, &types.SubscriptionOptions{FromSequence: &from})
+	c.NoError(err)
+	c.Equal(int64(4), (<-replay).Sequence)
+
+	_, err = d.Subscribe(ctx, &types.SubscriptionOptions{BufferSize: -1})
+	c.ErrorIs(err, types.ErrInvalidBufferSize)
+
+	c.NoError(d.Close(ctx))
+	_, ok := <-sub
+	c.False(ok)
+	_, ok = <-notifications
+	c.False(ok)
+	_, err = d.Subscribe(ctx, nil)
+	c.ErrorIs(err, ErrListenerClosed)
+}
+
+func TestMemDriver_SubscribeOverflow(t *testing.T) {
+	c := require.New(t)
+	ctx := context.Background()
+	d := NewMemDriver()
+
+	disconnect, err := d.Subscribe(ctx, &types.SubscriptionOptions{BufferSize: 1, Overflow: types.OverflowDisconnect})
+	c.NoError(err)
+	dropOldest, err := d.Subscribe(ctx, &types.SubscriptionOptions{BufferSize: 1, Overflow: types.OverflowDropOldest})
+	c.NoError(err)
+
+	// writes don't wait for the subscribers, which fall behind by the three events of the write
+	_, err = d.WriteApplication(ctx, &types.Application{Limit: types.AppLimit{PayPlan: types.PayPlan{Type: types.FreetierV0}}})
+	c.NoError(err)
+
+	c.Equal(types.ActionResync, (<-disconnect).Action)
+	_, ok := <-disconnect
+	c.False(ok)
+
+	c.Equal(int64(3), (<-dropOldest).Sequence)
+}
+
+func TestMemDriver_RunInTxEvents(t *testing.T) {
+	c := require.New(t)
+	ctx := context.Background()
+	d := NewMemDriver()
+
+	writeApps := func(tx driver.Writer) error {
+		for i := 0; i < 2; i++ {
+			_, err := tx.WriteApplication(ctx, &types.Application{Limit: types.AppLimit{PayPlan: types.PayPlan{Type: types.FreetierV0}}})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	c.NoError(d.RunInTx(ctx, writeApps))
+
+	// the events of the transaction are committed in order once it succeeds
+	events, err := d.ReadEventsSince(ctx, 0)
+	c.NoError(err)
+	c.Len(events, 6)
+	for i, event := range events {
+		c.Equal(int64(i+1), event.Sequence)
+	}
+
+	failed := errors.New("failed")
+	c.ErrorIs(d.RunInTx(ctx, func(tx driver.Writer) error {
+		if err := writeApps(tx); err != nil {
+			return err
+		}
+		return failed
+	}), failed)
+
+	events, err = d.ReadEventsSince(ctx, 0)
+	c.NoError(err)
+	c.Len(events, 6)
+
+	apps, err := d.ReadApplications(ctx)
+	c.NoError(err)
+	c.Len(apps, 2)
+}
@@ -0,0 +1,472 @@
+package memdriver
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pokt-foundation/portal-db/types"
+)
+
+/* ReadLoadBalancers returns all LoadBalancers ordered by ID */
+func (d *MemDriver) ReadLoadBalancers(ctx context.Context) ([]*types.LoadBalancer, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	var loadBalancers []*types.LoadBalancer
+	for _, id := range d.loadBalancerIDs() {
+		loadBalancers = append(loadBalancers, d.loadBalancer(id))
+	}
+
+	return loadBalancers, nil
+}
+
+/* ReadLoadBalancer returns a single LoadBalancer by its ID */
+func (d *MemDriver) ReadLoadBalancer(ctx context.Context, id string) (*types.LoadBalancer, error) {
+	if id == "" {
+		return nil, ErrMissingID
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if _, ok := d.loadBalancers[id]; !ok {
+		return nil, fmt.Errorf("%w: load balancer %s", ErrNotFound, id)
+	}
+
+	return d.loadBalancer(id), nil
+}
+
+/* ReadLoadBalancersPage returns one page of the LoadBalancers matching the query options, ordered by ID */
+func (d *MemDriver) ReadLoadBalancersPage(ctx context.Context, options *types.QueryOptions) (*types.LoadBalancersPage, error) {
+	if options == nil {
+		options = &types.QueryOptions{}
+	}
+
+	err := options.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	limit := options.PageLimit()
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	page := &types.LoadBalancersPage{LoadBalancers: []*types.LoadBalancer{}}
+	for _, id := range d.loadBalancerIDs() {
+		lb := d.loadBalancers[id]
+		if lb.ID <= options.Cursor ||
+			(options.UserID != "" && lb.UserID != options.UserID) ||
+			(!options.CreatedAfter.IsZero() && !lb.CreatedAt.After(options.CreatedAfter)) {
+			continue
+		}
+
+		if len(page.LoadBalancers) == limit {
+			page.NextCursor = page.LoadBalancers[limit-1].ID
+			break
+		}
+
+		page.LoadBalancers = append(page.LoadBalancers, d.loadBalancer(id))
+	}
+
+	return page, nil
+}
+
+// ReadLoadBalancersUpdatedSince returns all LoadBalancers whose row or child table rows changed after the given time.
+// Like Postgres comparing with NULL, the zero time matches nothing
+func (d *MemDriver) ReadLoadBalancersUpdatedSince(ctx context.Context, since time.Time) ([]*types.LoadBalancer, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	var loadBalancers []*types.LoadBalancer
+	for _, id := range d.loadBalancerIDs() {
+		if d.loadBalancerUpdatedSince(id, since) {
+			loadBalancers = append(loadBalancers, d.loadBalancer(id))
+		}
+	}
+
+	return loadBalancers, nil
+}
+
+func (d *MemDriver) loadBalancerUpdatedSince(id string, since time.Time) bool {
+	lb, ok := d.loadBalancers[id]
+
+	return ok && !since.IsZero() && lb.UpdatedAt.After(since)
+}
+
+func (d *MemDriver) loadBalancerIDs() []string {
+	ids := make([]string, 0, len(d.loadBalancers))
+	for id := range d.loadBalancers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+// loadBalancer joins the load balancer's row with its child table rows like SelectLoadBalancers.
+// As the app IDs are aggregated into a string and split again, a load balancer without apps has a single empty one
+func (d *MemDriver) loadBalancer(id string) *types.LoadBalancer {
+	lb := *d.loadBalancers[id]
+
+	lb.ApplicationIDs = nil
+	for _, lbApp := range d.lbApps {
+		if lbApp.LbID == id {
+			lb.ApplicationIDs = append(lb.ApplicationIDs, lbApp.AppID)
+		}
+	}
+	if len(lb.ApplicationIDs) == 0 {
+		lb.ApplicationIDs = []string{""}
+	}
+
+	if options, ok := d.stickinessOptions[id]; ok {
+		lb.StickyOptions = *options
+		lb.StickyOptions.ID = ""
+	}
+
+	lb.Users = []types.UserAccess{}
+	for _, userAccess := range d.userAccess {
+		if userAccess.ID == id {
+			user := *userAccess
+			user.ID = ""
+			lb.Users = append(lb.Users, user)
+		}
+	}
+
+	return &lb
+}
+
+/* ReadUserRoles returns all User Roles as a map that takes the form map[User ID]map[LB ID][]types.PermissionsEnum */
+func (d *MemDriver) ReadUserRoles(ctx context.Context) (map[string]map[string][]types.PermissionsEnum, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.userRolesMap(func(*types.UserAccess) bool { return true }), nil
+}
+
+// ReadUserRolesUpdatedSince returns the User Roles of every LoadBalancer whose user access changed after the given time,
+// in the same form as ReadUserRoles. Consumers should replace all roles they hold for the LoadBalancers present in the result,
+// those left without users are returned under an empty user ID with no permissions
+func (d *MemDriver) ReadUserRolesUpdatedSince(ctx context.Context, since time.Time) (map[string]map[string][]types.PermissionsEnum, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	userRoles := d.userRolesMap(func(userAccess *types.UserAccess) bool {
+		return d.loadBalancerUpdatedSince(userAccess.ID, since)
+	})
+
+	for _, id := range d.loadBalancerIDs() {
+		if !d.loadBalancerUpdatedSince(id, since) || d.hasUserAccess(id) {
+			continue
+		}
+
+		if userRoles[""] == nil {
+			userRoles[""] = make(map[string][]types.PermissionsEnum)
+		}
+		userRoles[""][id] = nil
+	}
+
+	return userRoles, nil
+}
+
+func (d *MemDriver) hasUserAccess(lbID string) bool {
+	for _, userAccess := range d.userAccess {
+		if userAccess.ID == lbID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (d *MemDriver) userRolesMap(include func(*types.UserAccess) bool) map[string]map[string][]types.PermissionsEnum {
+	userRolesMap := make(map[string]map[string][]types.PermissionsEnum)
+	for _, userAccess := range d.userAccess {
+		if !include(userAccess) {
+			continue
+		}
+
+		if userRolesMap[userAccess.UserID] == nil {
+			userRolesMap[userAccess.UserID] = make(map[string][]types.PermissionsEnum)
+		}
+		userRolesMap[userAccess.UserID][userAccess.ID] = d.userRoles[userAccess.RoleName]
+	}
+
+	return userRolesMap
+}
+
+/* WriteLoadBalancer saves input LoadBalancer */
+func (d *MemDriver) WriteLoadBalancer(ctx context.Context, loadBalancer *types.LoadBalancer) (*types.LoadBalancer, error) {
+	if len(loadBalancer.Users) < 1 {
+		return nil, ErrLBMustHaveUser
+	}
+
+	id, err := generateRandomID()
+	if err != nil {
+		return nil, err
+	}
+
+	loadBalancer.Users[0].RoleName = types.RoleOwner // The first User will be the initial creater (owner) of the LoadBalancer
+
+	err = d.write(func(tx *tx) error {
+		if _, ok := d.userRoles[types.RoleOwner]; !ok {
+			return fmt.Errorf("%w: user role %q", ErrInvalidReference, types.RoleOwner)
+		}
+
+		appIDs := make(map[string]bool, len(loadBalancer.ApplicationIDs))
+		for _, appID := range loadBalancer.ApplicationIDs {
+			if _, ok := d.applications[appID]; !ok {
+				return fmt.Errorf("%w: application %q", ErrInvalidReference, appID)
+			}
+			if appIDs[appID] {
+				return fmt.Errorf("%w: load balancer app %q", ErrAlreadyExists, appID)
+			}
+			appIDs[appID] = true
+		}
+
+		loadBalancer.ID = id
+		loadBalancer.CreatedAt = tx.now
+		loadBalancer.UpdatedAt = tx.now
+
+		row := &types.LoadBalancer{
+			ID:                loadBalancer.ID,
+			Name:              loadBalancer.Name,
+			UserID:            loadBalancer.UserID,
+			RequestTimeout:    loadBalancer.RequestTimeout,
+			Gigastake:         loadBalancer.Gigastake,
+			GigastakeRedirect: loadBalancer.GigastakeRedirect,
+			CreatedAt:         loadBalancer.CreatedAt,
+			UpdatedAt:         loadBalancer.UpdatedAt,
+		}
+		d.loadBalancers[id] = row
+		tx.insert(row)
+
+		options := loadBalancer.StickyOptions
+		if options.Duration != "" || len(options.StickyOrigins) > 0 || options.StickyMax != 0 {
+			options.ID = id
+			d.stickinessOptions[id] = &options
+			tx.insert(&options)
+			tx.touchLoadBalancer(id)
+		}
+
+		owner := loadBalancer.Users[0]
+		owner.ID = id
+		owner.Accepted = true // New LB owners always start with accepted = true
+		d.userAccess = append(d.userAccess, &owner)
+		tx.insert(&owner)
+		tx.touchLoadBalancer(id)
+
+		for _, appID := range loadBalancer.ApplicationIDs {
+			lbApp := &types.LbApp{LbID: id, AppID: appID}
+			d.lbApps = append(d.lbApps, lbApp)
+			tx.insert(lbApp)
+			tx.touchLoadBalancer(id)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return loadBalancer, nil
+}
+
+/* WriteLoadBalancerUser saves input UserAccess for the LoadBalancer */
+func (d *MemDriver) WriteLoadBalancerUser(ctx context.Context, lbID string, userAccess types.UserAccess) error {
+	if lbID == "" {
+		return ErrMissingID
+	}
+	if userAccess.RoleName == types.RoleOwner {
+		return ErrCannotSetToOwner
+	}
+
+	switch "" {
+	case userAccess.UserID:
+		return fmt.Errorf("%w: %s", ErrUserInputIsMissingField, "UserID")
+	case string(userAccess.RoleName):
+		return fmt.Errorf("%w: %s", ErrUserInputIsMissingField, "RoleName")
+	case userAccess.Email:
+		return fmt.Errorf("%w: %s", ErrUserInputIsMissingField, "Email")
+	}
+
+	return d.write(func(tx *tx) error {
+		if _, ok := d.loadBalancers[lbID]; !ok {
+			return fmt.Errorf("%w: load balancer %q", ErrInvalidReference, lbID)
+		}
+		if _, ok := d.userRoles[userAccess.RoleName]; !ok {
+			return fmt.Errorf("%w: user role %q", ErrInvalidReference, userAccess.RoleName)
+		}
+		if d.userAccessIndex(userAccess.UserID, lbID) >= 0 {
+			return fmt.Errorf("%w: user %s of load balancer %s", ErrAlreadyExists, userAccess.UserID, lbID)
+		}
+
+		user := &types.UserAccess{
+			ID:       lbID,
+			UserID:   userAccess.UserID,
+			RoleName: userAccess.RoleName,
+			Email:    userAccess.Email,
+			Accepted: false, // New LB users always start with accepted = false
+		}
+		d.userAccess = append(d.userAccess, user)
+		tx.insert(user)
+		tx.touchLoadBalancer(lbID)
+
+		return nil
+	})
+}
+
+func (d *MemDriver) userAccessIndex(userID, lbID string) int {
+	for i, userAccess := range d.userAccess {
+		if userAccess.UserID == userID && userAccess.ID == lbID {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// UpdateLoadBalancer updates LoadBalancer and related table rows, fields left empty keep their value.
+// When ExpectedUpdatedAt is set the update fails with ErrConflict if the load balancer was updated at another time
+func (d *MemDriver) UpdateLoadBalancer(ctx context.Context, id string, update *types.UpdateLoadBalancer) error {
+	if id == "" {
+		return ErrMissingID
+	}
+
+	return d.write(func(tx *tx) error {
+		options := update.StickyOptions
+		upsertOptions := options != nil &&
+			(options.Duration != "" || options.StickyMax != 0 || options.Stickiness != nil || len(options.StickyOrigins) != 0)
+
+		previous, ok := d.loadBalancers[id]
+		if expected := update.ExpectedUpdatedAt; expected != nil {
+			if !ok {
+				return fmt.Errorf("%w: load balancer %s", ErrNotFound, id)
+			}
+			if !previous.UpdatedAt.Equal(*expected) {
+				return fmt.Errorf("%w: load balancer %s was updated at %s", ErrConflict, id, previous.UpdatedAt.Format(time.RFC3339Nano))
+			}
+		}
+		if !ok {
+			if upsertOptions {
+				return fmt.Errorf("%w: load balancer %q", ErrInvalidReference, id)
+			}
+
+			return nil
+		}
+
+		lb := *previous
+		lb.Name = stringValue(update.Name, lb.Name)
+		lb.UpdatedAt = tx.now
+		d.loadBalancers[id] = &lb
+		tx.update(previous, &lb)
+
+		if upsertOptions {
+			tx.upsertStickinessOptions(id, options)
+			tx.touchLoadBalancer(id)
+		}
+
+		return nil
+	})
+}
+
+func (tx *tx) upsertStickinessOptions(id string, update *types.UpdateStickyOptions) {
+	options := types.StickyOptions{ID: id}
+
+	previous, ok := tx.d.stickinessOptions[id]
+	if ok {
+		options = *previous
+	}
+
+	options.Duration = stringValue(update.Duration, options.Duration)
+	if update.StickyMax != 0 {
+		options.StickyMax = update.StickyMax
+	}
+	options.Stickiness = boolValue(update.Stickiness, options.Stickiness)
+	if update.StickyOrigins != nil {
+		options.StickyOrigins = update.StickyOrigins
+	}
+
+	tx.d.stickinessOptions[id] = &options
+	if ok {
+		tx.update(previous, &options)
+		return
+	}
+
+	tx.insert(&options)
+}
+
+/* UpdateUserAccessRole updates the RoleName for a UserAccess row */
+func (d *MemDriver) UpdateUserAccessRole(ctx context.Context, userID, lbID string, roleName types.RoleName) error {
+	if userID == "" || lbID == "" {
+		return ErrMissingID
+	}
+	if roleName == types.RoleOwner {
+		return ErrCannotSetToOwner
+	}
+
+	return d.write(func(tx *tx) error {
+		i := d.userAccessIndex(userID, lbID)
+		if i < 0 {
+			return nil
+		}
+
+		if _, ok := d.userRoles[roleName]; roleName != "" && !ok {
+			return fmt.Errorf("%w: user role %q", ErrInvalidReference, roleName)
+		}
+
+		previous := d.userAccess[i]
+		user := *previous
+		user.RoleName = types.RoleName(stringValue(string(roleName), string(user.RoleName)))
+		d.userAccess[i] = &user
+		tx.update(previous, &user)
+		tx.touchLoadBalancer(lbID)
+
+		return nil
+	})
+}
+
+/* RemoveLoadBalancer sets the user ID to an empty string (will not appear in Portal API or UI) */
+func (d *MemDriver) RemoveLoadBalancer(ctx context.Context, id string) error {
+	if id == "" {
+		return ErrMissingID
+	}
+
+	return d.write(func(tx *tx) error {
+		previous, ok := d.loadBalancers[id]
+		if !ok {
+			return nil
+		}
+
+		lb := *previous
+		lb.UserID = ""
+		lb.UpdatedAt = tx.now
+		d.loadBalancers[id] = &lb
+		tx.update(previous, &lb)
+
+		return nil
+	})
+}
+
+/* RemoveUserAccess deletes a UserAccess row */
+func (d *MemDriver) RemoveUserAccess(ctx context.Context, userID, lbID string) error {
+	if userID == "" || lbID == "" {
+		return ErrMissingID
+	}
+
+	return d.write(func(tx *tx) error {
+		i := d.userAccessIndex(userID, lbID)
+		if i < 0 {
+			return nil
+		}
+
+		user := d.userAccess[i]
+		d.userAccess = append(d.userAccess[:i:i], d.userAccess[i+1:]...)
+		tx.delete(user)
+		tx.touchLoadBalancer(lbID)
+
+		return nil
+	})
+}
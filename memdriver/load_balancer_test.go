@@ -0,0 +1,96 @@
+package memdriver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pokt-foundation/portal-db/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemDriver_LoadBalancers(t *testing.T) {
+	c := require.New(t)
+	ctx := context.Background()
+	d := NewMemDriver()
+
+	app, err := d.WriteApplication(ctx, &types.Application{Limit: types.AppLimit{PayPlan: types.PayPlan{Type: types.FreetierV0}}})
+	c.NoError(err)
+
+	_, err = d.WriteLoadBalancer(ctx, &types.LoadBalancer{Name: "pokt_lb_1"})
+	c.ErrorIs(err, ErrLBMustHaveUser)
+	_, err = d.WriteLoadBalancer(ctx, &types.LoadBalancer{
+		ApplicationIDs: []string{"missing"},
+		Users:          []types.UserAccess{{UserID: "user_1"}},
+	})
+	c.ErrorIs(err, ErrInvalidReference)
+
+	lb, err := d.WriteLoadBalancer(ctx, &types.LoadBalancer{
+		Name:           "pokt_lb_1",
+		UserID:         "user_1",
+		ApplicationIDs: []string{app.ID},
+		StickyOptions:  types.StickyOptions{Duration: "40", StickyMax: 300},
+		Users:          []types.UserAccess{{UserID: "user_1", Email: "owner@test.com", RoleName: types.RoleMember}},
+	})
+	c.NoError(err)
+	c.Len(lb.ID, idLength)
+
+	empty, err := d.WriteLoadBalancer(ctx, &types.LoadBalancer{Users: []types.UserAccess{{UserID: "user_1"}}})
+	c.NoError(err)
+
+	read, err := d.ReadLoadBalancer(ctx, lb.ID)
+	c.NoError(err)
+	c.Equal([]string{app.ID}, read.ApplicationIDs)
+	c.Equal(types.StickyOptions{Duration: "40", StickyMax: 300}, read.StickyOptions)
+	c.Equal([]types.UserAccess{{UserID: "user_1", Email: "owner@test.com", RoleName: types.RoleOwner, Accepted: true}}, read.Users)
+
+	read, err = d.ReadLoadBalancer(ctx, empty.ID)
+	c.NoError(err)
+	c.Equal([]string{""}, read.ApplicationIDs)
+
+	_, err = d.ReadLoadBalancer(ctx, "missing")
+	c.ErrorIs(err, ErrNotFound)
+
+	c.ErrorIs(d.WriteLoadBalancerUser(ctx, "", types.UserAccess{}), ErrMissingID)
+	c.ErrorIs(d.WriteLoadBalancerUser(ctx, lb.ID, types.UserAccess{RoleName: types.RoleOwner}), ErrCannotSetToOwner)
+	c.ErrorIs(d.WriteLoadBalancerUser(ctx, lb.ID, types.UserAccess{UserID: "user_2", RoleName: types.RoleMember}), ErrUserInputIsMissingField)
+
+	member := types.UserAccess{UserID: "user_2", Email: "member@test.com", RoleName: types.RoleMember}
+	c.NoError(d.WriteLoadBalancerUser(ctx, lb.ID, member))
+	c.ErrorIs(d.WriteLoadBalancerUser(ctx, lb.ID, member), ErrAlreadyExists)
+	c.ErrorIs(d.WriteLoadBalancerUser(ctx, "missing", member), ErrInvalidReference)
+
+	userRoles, err := d.ReadUserRoles(ctx)
+	c.NoError(err)
+	c.Equal(map[string]map[string][]types.PermissionsEnum{
+		"user_1": {lb.ID: {types.ReadEndpoint, types.WriteEndpoint}, empty.ID: {types.ReadEndpoint, types.WriteEndpoint}},
+		"user_2": {lb.ID: {types.ReadEndpoint}},
+	}, userRoles)
+
+	c.ErrorIs(d.UpdateUserAccessRole(ctx, "user_2", lb.ID, types.RoleOwner), ErrCannotSetToOwner)
+	c.NoError(d.UpdateUserAccessRole(ctx, "user_2", lb.ID, types.RoleAdmin))
+
+	stickiness := true
+	c.NoError(d.UpdateLoadBalancer(ctx, lb.ID, &types.UpdateLoadBalancer{
+		Name:          "pokt_lb_renamed",
+		StickyOptions: &types.UpdateStickyOptions{Stickiness: &stickiness},
+	}))
+
+	read, err = d.ReadLoadBalancer(ctx, lb.ID)
+	c.NoError(err)
+	c.Equal("pokt_lb_renamed", read.Name)
+	c.Equal(types.StickyOptions{Duration: "40", StickyMax: 300, Stickiness: true}, read.StickyOptions)
+	c.Equal(types.RoleAdmin, read.Users[1].RoleName)
+
+	c.NoError(d.RemoveUserAccess(ctx, "user_2", lb.ID))
+	c.NoError(d.RemoveLoadBalancer(ctx, lb.ID))
+
+	read, err = d.ReadLoadBalancer(ctx, lb.ID)
+	c.NoError(err)
+	c.Empty(read.UserID)
+	c.Len(read.Users, 1)
+
+	page, err := d.ReadLoadBalancersPage(ctx, &types.QueryOptions{Limit: 1})
+	c.NoError(err)
+	c.Len(page.LoadBalancers, 1)
+	c.NotEmpty(page.NextCursor)
+}
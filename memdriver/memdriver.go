@@ -0,0 +1,412 @@
+// Package memdriver provides an in-memory implementation of driver.Driver for tests that can't run Postgres.
+// It mirrors PostgresDriver: the same validation, constraint checks, ID generation, timestamps with
+// microsecond precision and the Notifications the database triggers emit, including their sequences.
+package memdriver
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pokt-foundation/portal-db/driver"
+	"github.com/pokt-foundation/portal-db/types"
+)
+
+const (
+	idLength = 24
+)
+
+var (
+	// Errors shared with every Driver so callers can check them the same way
+	ErrMissingID               = driver.ErrMissingID
+	ErrNotFound                = driver.ErrNotFound
+	ErrAlreadyExists           = driver.ErrAlreadyExists
+	ErrInvalidReference        = driver.ErrInvalidReference
+	ErrConflict                = driver.ErrConflict
+	ErrLBMustHaveUser          = driver.ErrLBMustHaveUser
+	ErrCannotSetToOwner        = driver.ErrCannotSetToOwner
+	ErrUserInputIsMissingField = driver.ErrUserInputIsMissingField
+	ErrListenerClosed          = driver.ErrListenerClosed
+	ErrBlockchainInUse         = driver.ErrBlockchainInUse
+
+	defaultPayPlans = []*types.PayPlan{
+		{Type: types.Enterprise, Limit: 0},
+		{Type: types.FreetierV0, Limit: 250000},
+		{Type: types.PayAsYouGoV0, Limit: 0},
+		{Type: types.TestPlan10K, Limit: 10000},
+		{Type: types.TestPlan90k, Limit: 90000},
+		{Type: types.TestPlanV0, Limit: 100},
+	}
+	defaultUserRoles = map[types.RoleName][]types.PermissionsEnum{
+		types.RoleAdmin:  {types.ReadEndpoint, types.WriteEndpoint},
+		types.RoleOwner:  {types.ReadEndpoint, types.WriteEndpoint},
+		types.RoleMember: {types.ReadEndpoint},
+	}
+)
+
+type (
+	// MemDriver is a driver.Driver keeping every table in memory.
+	// Rows are never modified in place, writes replace them so notifications can share them
+	MemDriver struct {
+		mu sync.RWMutex
+
+		payPlans  map[types.PayPlanType]*types.PayPlan
+		userRoles map[types.RoleName][]types.PermissionsEnum
+
+		applications         map[string]*types.Application
+		appLimits            map[string]*types.AppLimit
+		gatewayAATs          map[string]*types.GatewayAAT
+		gatewaySettings      map[string]*types.GatewaySettings
+		notificationSettings map[string]*types.NotificationSettings
+
+		loadBalancers     map[string]*types.LoadBalancer
+		stickinessOptions map[string]*types.StickyOptions
+		userAccess        []*types.UserAccess
+		lbApps            []*types.LbApp
+
+		blockchains      map[string]*types.Blockchain
+		syncCheckOptions map[string]*syncCheckOptionsRow
+		redirects        []*types.Redirect
+
+		// events is the outbox, the event with sequence n is at index n-1. appended is closed and replaced on every commit
+		events   []*types.Notification
+		appended chan struct{}
+
+		notification     chan *types.Notification
+		notificationOnce sync.Once
+		closed           chan struct{}
+		closeOnce        sync.Once
+	}
+
+	/* syncCheckOptionsRow keeps the synccheck column that is part of the Blockchain and not of its SyncCheckOptions */
+	syncCheckOptionsRow struct {
+		types.SyncCheckOptions
+		syncCheck string
+	}
+
+	Option func(*MemDriver)
+
+	/* tx collects the events of a write, they are committed together once it succeeded */
+	tx struct {
+		d      *MemDriver
+		now    time.Time
+		events []*types.Notification
+	}
+)
+
+/* WithPayPlans replaces the default pay plans, which are the ones of the test database */
+func WithPayPlans(payPlans []*types.PayPlan) Option {
+	return func(d *MemDriver) {
+		d.payPlans = make(map[types.PayPlanType]*types.PayPlan, len(payPlans))
+		for _, payPlan := range payPlans {
+			d.payPlans[payPlan.Type] = payPlan
+		}
+	}
+}
+
+/* WithUserRoles replaces the default user roles, which are the ones of the test database */
+func WithUserRoles(userRoles map[types.RoleName][]types.PermissionsEnum) Option {
+	return func(d *MemDriver) {
+		d.userRoles = userRoles
+	}
+}
+
+/* NewMemDriver returns an empty MemDriver with the pay plans and user roles of the test database */
+func NewMemDriver(options ...Option) *MemDriver {
+	d := &MemDriver{
+		userRoles: defaultUserRoles,
+
+		applications:         make(map[string]*types.Application),
+		appLimits:            make(map[string]*types.AppLimit),
+		gatewayAATs:          make(map[string]*types.GatewayAAT),
+		gatewaySettings:      make(map[string]*types.GatewaySettings),
+		notificationSettings: make(map[string]*types.NotificationSettings),
+
+		loadBalancers:     make(map[string]*types.LoadBalancer),
+		stickinessOptions: make(map[string]*types.StickyOptions),
+
+		blockchains:      make(map[string]*types.Blockchain),
+		syncCheckOptions: make(map[string]*syncCheckOptionsRow),
+
+		appended:     make(chan struct{}),
+		notification: make(chan *types.Notification),
+		closed:       make(chan struct{}),
+	}
+
+	WithPayPlans(defaultPayPlans)(d)
+	for _, option := range options {
+		option(d)
+	}
+
+	return d
+}
+
+/* Close closes the Notification channel and every subscription, the driver can still be read and written */
+func (d *MemDriver) Close(ctx context.Context) error {
+	d.closeOnce.Do(func() { close(d.closed) })
+
+	return nil
+}
+
+/* write runs fn holding the write lock and commits its events if it succeeds */
+func (d *MemDriver) write(fn func(tx *tx) error) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	tx := &tx{d: d, now: timestamp(time.Now())}
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	d.commit(tx.events)
+
+	return nil
+}
+
+/* commit appends the events to the log with their sequences and wakes up the subscriptions, d.mu must be held */
+func (d *MemDriver) commit(events []*types.Notification) {
+	for _, event := range events {
+		event.Sequence = int64(len(d.events) + 1)
+		d.events = append(d.events, event)
+	}
+	if len(events) > 0 {
+		close(d.appended)
+		d.appended = make(chan struct{})
+	}
+}
+
+// RunInTx runs fn against a copy of the tables, which replaces them and commits the events of its writes once fn returns nil.
+// Other writes wait for it, so fn must only write through tx
+func (d *MemDriver) RunInTx(ctx context.Context, fn func(tx driver.Writer) error) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	view := d.cloneTables()
+	if err := fn(view); err != nil {
+		return err
+	}
+
+	d.applications, d.appLimits, d.gatewayAATs = view.applications, view.appLimits, view.gatewayAATs
+	d.gatewaySettings, d.notificationSettings = view.gatewaySettings, view.notificationSettings
+	d.loadBalancers, d.stickinessOptions, d.userAccess, d.lbApps = view.loadBalancers, view.stickinessOptions, view.userAccess, view.lbApps
+	d.blockchains, d.syncCheckOptions, d.redirects = view.blockchains, view.syncCheckOptions, view.redirects
+
+	d.commit(view.events)
+
+	return nil
+}
+
+/* cloneTables returns a driver holding copies of the tables, the rows are shared as they are never modified in place */
+func (d *MemDriver) cloneTables() *MemDriver {
+	return &MemDriver{
+		payPlans:  d.payPlans,
+		userRoles: d.userRoles,
+
+		applications:         copyMap(d.applications),
+		appLimits:            copyMap(d.appLimits),
+		gatewayAATs:          copyMap(d.gatewayAATs),
+		gatewaySettings:      copyMap(d.gatewaySettings),
+		notificationSettings: copyMap(d.notificationSettings),
+
+		loadBalancers:     copyMap(d.loadBalancers),
+		stickinessOptions: copyMap(d.stickinessOptions),
+		userAccess:        append([]*types.UserAccess(nil), d.userAccess...),
+		lbApps:            append([]*types.LbApp(nil), d.lbApps...),
+
+		blockchains:      copyMap(d.blockchains),
+		syncCheckOptions: copyMap(d.syncCheckOptions),
+		redirects:        append([]*types.Redirect(nil), d.redirects...),
+
+		appended:     make(chan struct{}),
+		notification: make(chan *types.Notification),
+		closed:       make(chan struct{}),
+	}
+}
+
+func copyMap[K comparable, V any](m map[K]V) map[K]V {
+	copied := make(map[K]V, len(m))
+	for key, value := range m {
+		copied[key] = value
+	}
+
+	return copied
+}
+
+func (tx *tx) insert(row types.SavedOnDB) {
+	tx.events = append(tx.events, &types.Notification{Table: row.Table(), Action: types.ActionInsert, Data: row})
+}
+
+func (tx *tx) update(previous, row types.SavedOnDB) {
+	tx.events = append(tx.events, &types.Notification{
+		Table:         row.Table(),
+		Action:        types.ActionUpdate,
+		Data:          row,
+		Previous:      previous,
+		ChangedFields: changedFields(previous, row),
+	})
+}
+
+func (tx *tx) delete(row types.SavedOnDB) {
+	tx.events = append(tx.events, &types.Notification{Table: row.Table(), Action: types.ActionDelete, Data: row})
+}
+
+/* touchApplication bumps the app's updated_at like the touch_parent trigger of its child tables, without notifying it */
+func (tx *tx) touchApplication(id string) {
+	previous, ok := tx.d.applications[id]
+	if !ok {
+		return
+	}
+
+	app := *previous
+	app.UpdatedAt = tx.now
+	tx.d.applications[id] = &app
+}
+
+/* touchLoadBalancer bumps the load balancer's updated_at like the touch_parent trigger of its child tables, without notifying it */
+func (tx *tx) touchLoadBalancer(id string) {
+	previous, ok := tx.d.loadBalancers[id]
+	if !ok {
+		return
+	}
+
+	lb := *previous
+	lb.UpdatedAt = tx.now
+	tx.d.loadBalancers[id] = &lb
+}
+
+/* touchBlockchain bumps the blockchain's updated_at like the touch_parent trigger of its child tables, without notifying it */
+func (tx *tx) touchBlockchain(id string) {
+	previous, ok := tx.d.blockchains[id]
+	if !ok {
+		return
+	}
+
+	blockchain := *previous
+	blockchain.UpdatedAt = tx.now
+	tx.d.blockchains[id] = &blockchain
+}
+
+// changedFields returns the sorted names of the columns that differ between both rows, like the listener does.
+// Rows of different tables are not compared
+func changedFields(previous, current types.SavedOnDB) []string {
+	previousColumns, currentColumns := columns(previous), columns(current)
+
+	var fields []string
+	for column, value := range currentColumns {
+		if !reflect.DeepEqual(previousColumns[column], value) {
+			fields = append(fields, column)
+		}
+	}
+
+	sort.Strings(fields)
+
+	return fields
+}
+
+/* columns returns the row's values by the name of their column */
+func columns(row types.SavedOnDB) map[string]any {
+	switch row := row.(type) {
+	case *types.Application:
+		return map[string]any{
+			"application_id": row.ID, "user_id": row.UserID, "name": row.Name, "contact_email": row.ContactEmail,
+			"description": row.Description, "owner": row.Owner, "url": row.URL, "status": row.Status, "dummy": row.Dummy,
+			"first_date_surpassed": row.FirstDateSurpassed, "created_at": row.CreatedAt, "updated_at": row.UpdatedAt,
+		}
+	case *types.AppLimit:
+		return map[string]any{"application_id": row.ID, "pay_plan": row.PayPlan.Type, "custom_limit": row.CustomLimit}
+	case *types.GatewayAAT:
+		return map[string]any{
+			"application_id": row.ID, "address": row.Address, "client_public_key": row.ClientPublicKey, "private_key": row.PrivateKey,
+			"public_key": row.ApplicationPublicKey, "signature": row.ApplicationSignature, "version": row.Version,
+		}
+	case *types.GatewaySettings:
+		return map[string]any{
+			"application_id": row.ID, "secret_key": row.SecretKey, "secret_key_required": row.SecretKeyRequired,
+			"whitelist_contracts": row.WhitelistContracts, "whitelist_methods": row.WhitelistMethods,
+			"whitelist_origins": row.WhitelistOrigins, "whitelist_user_agents": row.WhitelistUserAgents,
+			"whitelist_blockchains": row.WhitelistBlockchains,
+		}
+	case *types.NotificationSettings:
+		return map[string]any{
+			"application_id": row.ID, "signed_up": row.SignedUp, "on_quarter": row.Quarter, "on_half": row.Half,
+			"on_three_quarters": row.ThreeQuarters, "on_full": row.Full,
+		}
+
+	case *types.LoadBalancer:
+		return map[string]any{
+			"lb_id": row.ID, "name": row.Name, "user_id": row.UserID, "request_timeout": row.RequestTimeout,
+			"gigastake": row.Gigastake, "gigastake_redirect": row.GigastakeRedirect,
+			"created_at": row.CreatedAt, "updated_at": row.UpdatedAt,
+		}
+	case *types.StickyOptions:
+		return map[string]any{
+			"lb_id": row.ID, "duration": row.Duration, "origins": row.StickyOrigins, "sticky_max": row.StickyMax,
+			"stickiness": row.Stickiness,
+		}
+	case *types.UserAccess:
+		return map[string]any{
+			"lb_id": row.ID, "user_id": row.UserID, "role_name": row.RoleName, "email": row.Email, "accepted": row.Accepted,
+		}
+	case *types.LbApp:
+		return map[string]any{"lb_id": row.LbID, "app_id": row.AppID}
+
+	case *types.Blockchain:
+		return map[string]any{
+			"blockchain_id": row.ID, "altruist": row.Altruist, "blockchain": row.Blockchain, "chain_id": row.ChainID,
+			"chain_id_check": row.ChainIDCheck, "path": row.Path, "description": row.Description,
+			"enforce_result": row.EnforceResult, "network": row.Network, "ticker": row.Ticker,
+			"blockchain_aliases": row.BlockchainAliases, "log_limit_blocks": row.LogLimitBlocks,
+			"request_timeout": row.RequestTimeout, "active": row.Active, "created_at": row.CreatedAt, "updated_at": row.UpdatedAt,
+		}
+	case *types.SyncCheckOptions:
+		return map[string]any{
+			"blockchain_id": row.BlockchainID, "body": row.Body, "path": row.Path, "result_key": row.ResultKey,
+			"allowance": row.Allowance,
+		}
+	case *types.Redirect:
+		return map[string]any{
+			"blockchain_id": row.BlockchainID, "alias": row.Alias, "loadbalancer": row.LoadBalancerID, "domain": row.Domain,
+			"created_at": row.CreatedAt, "updated_at": row.UpdatedAt,
+		}
+	}
+
+	return nil
+}
+
+func generateRandomID() (string, error) {
+	bytes := make([]byte, idLength/2)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(bytes), nil
+}
+
+/* timestamp returns t as Postgres stores it in a TIMESTAMP column */
+func timestamp(t time.Time) time.Time {
+	if t.IsZero() {
+		return time.Time{}
+	}
+
+	return t.UTC().Truncate(time.Microsecond)
+}
+
+func boolValue(value *bool, fallback bool) bool {
+	if value == nil {
+		return fallback
+	}
+
+	return *value
+}
+
+func stringValue(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+
+	return value
+}
@@ -0,0 +1,172 @@
+package postgresdriver
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/pokt-foundation/portal-db/types"
+)
+
+var (
+	applicationTables = map[types.Table]bool{
+		types.TableApplications:         true,
+		types.TableAppLimits:            true,
+		types.TableGatewayAAT:           true,
+		types.TableGatewaySettings:      true,
+		types.TableNotificationSettings: true,
+	}
+	loadBalancerTables = map[types.Table]bool{
+		types.TableLoadBalancers:     true,
+		types.TableStickinessOptions: true,
+		types.TableUserAccess:        true,
+		types.TableLbApps:            true,
+	}
+)
+
+type (
+	/* aggregateKey identifies the Application or LoadBalancer a notification belongs to */
+	aggregateKey struct {
+		table types.Table
+		id    string
+	}
+
+	/* aggregator turns notifications of an Application or LoadBalancer and their child tables into a single one holding the freshly read entity */
+	aggregator struct {
+		readApplication  func(ctx context.Context, id string) (*types.Application, error)
+		readLoadBalancer func(ctx context.Context, id string) (*types.LoadBalancer, error)
+	}
+)
+
+/* aggregateKeyOf returns the aggregate the notification belongs to, false if its table is not part of one */
+func aggregateKeyOf(n *types.Notification) (aggregateKey, bool) {
+	ids := n.EntityIDs()
+	if len(ids) == 0 {
+		return aggregateKey{}, false
+	}
+
+	switch {
+	case applicationTables[n.Table]:
+		return aggregateKey{table: types.TableApplications, id: ids[0]}, true
+	case loadBalancerTables[n.Table]:
+		return aggregateKey{table: types.TableLoadBalancers, id: ids[0]}, true
+	}
+
+	return aggregateKey{}, false
+}
+
+/* sameAggregate returns true if both notifications can be merged into one */
+func sameAggregate(a, b *types.Notification) bool {
+	aKey, ok := aggregateKeyOf(a)
+	if !ok {
+		return false
+	}
+	bKey, ok := aggregateKeyOf(b)
+
+	return ok && aKey == bKey
+}
+
+/* groupByAggregate splits notifications into runs of consecutive notifications of the same aggregate */
+func groupByAggregate(notifications []*types.Notification) [][]*types.Notification {
+	var groups [][]*types.Notification
+
+	for _, n := range notifications {
+		last := len(groups) - 1
+		if last >= 0 && sameAggregate(groups[last][0], n) {
+			groups[last] = append(groups[last], n)
+			continue
+		}
+
+		groups = append(groups, []*types.Notification{n})
+	}
+
+	return groups
+}
+
+/* mergeFields returns the sorted union of both field lists */
+func mergeFields(fields, others []string) []string {
+	for _, other := range others {
+		if !containsField(fields, other) {
+			fields = append(fields, other)
+		}
+	}
+
+	sort.Strings(fields)
+
+	return fields
+}
+
+func containsField(fields []string, field string) bool {
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+// aggregate merges notifications of the same aggregate into one with the whole Application or LoadBalancer as Data.
+// Its action is INSERT or DELETE when the main table row was inserted or deleted and UPDATE otherwise,
+// updates of the main table row keep their earliest Previous and the changed fields of all of them.
+// Notifications of other tables are returned unchanged
+func (a *aggregator) aggregate(ctx context.Context, group []*types.Notification) (*types.Notification, error) {
+	key, ok := aggregateKeyOf(group[0])
+	if !ok {
+		return group[0], nil
+	}
+
+	aggregated := &types.Notification{
+		Table:  key.table,
+		Action: types.ActionUpdate,
+	}
+
+	for _, n := range group {
+		if n.Sequence > aggregated.Sequence {
+			aggregated.Sequence = n.Sequence
+		}
+
+		if n.Table != key.table {
+			continue
+		}
+
+		switch n.Action {
+		case types.ActionUpdate:
+			if aggregated.Previous == nil {
+				aggregated.Previous = n.Previous
+			}
+			aggregated.ChangedFields = mergeFields(aggregated.ChangedFields, n.ChangedFields)
+		case types.ActionDelete:
+			aggregated.Action = types.ActionDelete
+			aggregated.Data = n.Data
+		case types.ActionInsert:
+			if aggregated.Action != types.ActionDelete {
+				aggregated.Action = types.ActionInsert
+			}
+		}
+	}
+
+	if aggregated.Action == types.ActionDelete {
+		return aggregated, nil
+	}
+
+	var err error
+
+	switch key.table {
+	case types.TableApplications:
+		aggregated.Data, err = a.readApplication(ctx, key.id)
+		if errors.Is(err, ErrNotFound) {
+			aggregated.Action, aggregated.Data, err = types.ActionDelete, &types.Application{ID: key.id}, nil
+		}
+	case types.TableLoadBalancers:
+		aggregated.Data, err = a.readLoadBalancer(ctx, key.id)
+		if errors.Is(err, ErrNotFound) {
+			aggregated.Action, aggregated.Data, err = types.ActionDelete, &types.LoadBalancer{ID: key.id}, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return aggregated, nil
+}
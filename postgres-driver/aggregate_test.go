@@ -0,0 +1,150 @@
+package postgresdriver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lib/pq"
+	"github.com/pokt-foundation/portal-db/types"
+)
+
+var errReadingAggregate = errors.New("error reading aggregate")
+
+func TestAggregateNotifications(t *testing.T) {
+	stub := &aggregator{
+		readApplication: func(ctx context.Context, id string) (*types.Application, error) {
+			switch id {
+			case "321":
+				return &types.Application{ID: "321", Name: "app"}, nil
+			case "broken":
+				return nil, errReadingAggregate
+			}
+
+			return nil, fmt.Errorf("%w: application %s", ErrNotFound, id)
+		},
+		readLoadBalancer: func(ctx context.Context, id string) (*types.LoadBalancer, error) {
+			return &types.LoadBalancer{ID: id, Name: "lb"}, nil
+		},
+	}
+
+	var pqNotifications []*pq.Notification
+	pqNotifications = append(pqNotifications, mockContent(types.ActionInsert, types.ActionInsert, &types.Application{
+		ID:                   "321",
+		Limit:                types.AppLimit{PayPlan: types.PayPlan{Type: types.FreetierV0}},
+		GatewayAAT:           types.GatewayAAT{Address: "123"},
+		GatewaySettings:      types.GatewaySettings{SecretKey: "123"},
+		NotificationSettings: types.NotificationSettings{Full: true},
+	})...)
+	pqNotifications = append(pqNotifications, mockContent(types.ActionUpdate, types.ActionUpdate, &types.Blockchain{ID: "0021"})...)
+	pqNotifications = append(pqNotifications, mockContent(types.ActionUpdate, types.ActionInsert, &types.LoadBalancer{
+		ID:             "123",
+		ApplicationIDs: []string{"321"},
+		Users:          []types.UserAccess{{UserID: "test_user_1dbffbdfeeb225", RoleName: types.RoleMember}},
+	})...)
+	pqNotifications = append(pqNotifications, mockContent(types.ActionUpdate, types.ActionUpdate, &types.AppLimit{
+		ID:      "removed",
+		PayPlan: types.PayPlan{Type: types.FreetierV0},
+	})...)
+	pqNotifications = append(pqNotifications, mockContent(types.ActionUpdate, types.ActionUpdate, &types.AppLimit{
+		ID:      "broken",
+		PayPlan: types.PayPlan{Type: types.FreetierV0},
+	})...)
+	pqNotifications = append(pqNotifications, mockContent(types.ActionDelete, types.ActionDelete, &types.UserAccess{
+		ID:     "456",
+		UserID: "test_user_1dbffbdfeeb225",
+	})...)
+	pqNotifications = append(pqNotifications, mockContent(types.ActionDelete, types.ActionDelete, &types.LoadBalancer{ID: "456"})...)
+
+	inCh := make(chan notification, len(pqNotifications))
+	for _, pqNotification := range pqNotifications {
+		n, err := parsePQNotification(pqNotification)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		inCh <- n
+	}
+	close(inCh)
+
+	outCh := make(chan *types.Notification, len(pqNotifications))
+	var errs []error
+
+	deliverNotifications(inCh, outCh, listenConfig{
+		aggregator: stub,
+		onError: func(err error) {
+			errs = append(errs, err)
+		},
+	})
+	close(outCh)
+
+	var notifications []*types.Notification
+	for n := range outCh {
+		notifications = append(notifications, n)
+	}
+
+	expectedNotifications := []*types.Notification{
+		{
+			Table:  types.TableApplications,
+			Action: types.ActionInsert,
+			Data:   &types.Application{ID: "321", Name: "app"},
+		},
+		{
+			Table:  types.TableBlockchains,
+			Action: types.ActionUpdate,
+			Data:   &types.Blockchain{ID: "0021"},
+		},
+		{
+			Table:  types.TableLoadBalancers,
+			Action: types.ActionUpdate,
+			Data:   &types.LoadBalancer{ID: "123", Name: "lb"},
+		},
+		{
+			Table:  types.TableApplications,
+			Action: types.ActionDelete,
+			Data:   &types.Application{ID: "removed"},
+		},
+		{
+			Action: types.ActionResync,
+		},
+		{
+			Table:  types.TableLoadBalancers,
+			Action: types.ActionDelete,
+			Data:   &types.LoadBalancer{ID: "456"},
+		},
+	}
+	if diff := cmp.Diff(expectedNotifications, notifications); diff != "" {
+		t.Errorf("unexpected value (-want +got):\n%s", diff)
+	}
+
+	if len(errs) != 1 || !errors.Is(errs[0], errReadingAggregate) {
+		t.Errorf("expected a single read error, got %v", errs)
+	}
+}
+
+func TestGroupByAggregate(t *testing.T) {
+	notifications := []*types.Notification{
+		{Sequence: 1, Table: types.TableApplications, Data: &types.Application{ID: "321"}},
+		{Sequence: 2, Table: types.TableAppLimits, Data: &types.AppLimit{ID: "321"}},
+		{Sequence: 3, Table: types.TableLbApps, Data: &types.LbApp{LbID: "123", AppID: "321"}},
+		{Sequence: 4, Table: types.TableUserAccess, Data: &types.UserAccess{ID: "123"}},
+		{Sequence: 5, Table: types.TableRedirects, Data: &types.Redirect{LoadBalancerID: "123"}},
+		{Sequence: 6, Table: types.TableRedirects, Data: &types.Redirect{LoadBalancerID: "123"}},
+		{Sequence: 7, Table: types.TableGatewayAAT, Data: &types.GatewayAAT{ID: "321"}},
+	}
+
+	var groups [][]int64
+	for _, group := range groupByAggregate(notifications) {
+		var sequences []int64
+		for _, n := range group {
+			sequences = append(sequences, n.Sequence)
+		}
+		groups = append(groups, sequences)
+	}
+
+	expectedGroups := [][]int64{{1, 2}, {3, 4}, {5}, {6}, {7}}
+	if diff := cmp.Diff(expectedGroups, groups); diff != "" {
+		t.Errorf("unexpected value (-want +got):\n%s", diff)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"strings"
 	"time"
 
@@ -12,7 +14,9 @@ import (
 
 /* ReadApplications returns all Applications in the database */
 func (p *PostgresDriver) ReadApplications(ctx context.Context) ([]*types.Application, error) {
-	dbApplications, err := p.SelectApplications(ctx)
+	dbApplications, err := read(ctx, p, func(q *Queries) ([]SelectApplicationsRow, error) {
+		return q.SelectApplications(ctx)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -26,6 +30,92 @@ func (p *PostgresDriver) ReadApplications(ctx context.Context) ([]*types.Applica
 
 }
 
+/* ReadApplication returns a single Application by its ID */
+func (p *PostgresDriver) ReadApplication(ctx context.Context, id string) (*types.Application, error) {
+	if id == "" {
+		return nil, ErrMissingID
+	}
+
+	dbApplication, err := read(ctx, p, func(q *Queries) (SelectOneApplicationRow, error) {
+		return q.SelectOneApplication(ctx, id)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: application %s", ErrNotFound, id)
+		}
+		return nil, err
+	}
+
+	application := SelectApplicationsRow(dbApplication)
+
+	return application.toApplication(), nil
+}
+
+/* ReadApplicationsPage returns one page of the Applications matching the query options, ordered by ID */
+func (p *PostgresDriver) ReadApplicationsPage(ctx context.Context, options *types.QueryOptions) (*types.ApplicationsPage, error) {
+	if options == nil {
+		options = &types.QueryOptions{}
+	}
+
+	err := options.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	limit := options.PageLimit()
+
+	// One extra row is requested to know whether there is a next page
+	dbApplications, err := read(ctx, p, func(q *Queries) ([]SelectApplicationsPageRow, error) {
+		return q.SelectApplicationsPage(ctx, extractSelectApplicationsPage(options, limit+1))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	page := &types.ApplicationsPage{Applications: []*types.Application{}}
+	for i, dbApplication := range dbApplications {
+		if i == limit {
+			page.NextCursor = page.Applications[limit-1].ID
+			break
+		}
+
+		application := SelectApplicationsRow(dbApplication)
+		page.Applications = append(page.Applications, application.toApplication())
+	}
+
+	return page, nil
+}
+
+func extractSelectApplicationsPage(options *types.QueryOptions, maxResults int) SelectApplicationsPageParams {
+	return SelectApplicationsPageParams{
+		Cursor:       options.Cursor,
+		UserID:       newSQLNullString(options.UserID),
+		Status:       newSQLNullString(string(options.Status)),
+		PayPlan:      newSQLNullString(string(options.PayPlan)),
+		Dummy:        newSQLNullBool(options.Dummy),
+		CreatedAfter: newSQLNullTime(options.CreatedAfter),
+		MaxResults:   int32(maxResults),
+	}
+}
+
+/* ReadApplicationsUpdatedSince returns all Applications whose row or child table rows changed after the given time */
+func (p *PostgresDriver) ReadApplicationsUpdatedSince(ctx context.Context, since time.Time) ([]*types.Application, error) {
+	dbApplications, err := read(ctx, p, func(q *Queries) ([]SelectApplicationsUpdatedSinceRow, error) {
+		return q.SelectApplicationsUpdatedSince(ctx, newSQLNullTime(since))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var applications []*types.Application
+	for _, dbApplication := range dbApplications {
+		application := SelectApplicationsRow(dbApplication)
+		applications = append(applications, application.toApplication())
+	}
+
+	return applications, nil
+}
+
 func (a *SelectApplicationsRow) toApplication() *types.Application {
 	return &types.Application{
 		ID:                 a.ApplicationID,
@@ -84,9 +174,17 @@ func nullStringToWhitelistContracts(rawContracts sql.NullString) []types.Whiteli
 	return stringToWhitelistContracts(rawContracts.String)
 }
 func stringToWhitelistContracts(rawContracts string) []types.WhitelistContract {
+	contracts, _ := parseWhitelistContracts(rawContracts)
+	return contracts
+}
+func parseWhitelistContracts(rawContracts string) ([]types.WhitelistContract, error) {
 	contracts := []types.WhitelistContract{}
 
-	_ = json.Unmarshal([]byte(rawContracts), &contracts)
+	if rawContracts != "" {
+		if err := json.Unmarshal([]byte(rawContracts), &contracts); err != nil {
+			return []types.WhitelistContract{}, err
+		}
+	}
 
 	for i, contract := range contracts {
 		for j, inContract := range contract.Contracts {
@@ -94,7 +192,7 @@ func stringToWhitelistContracts(rawContracts string) []types.WhitelistContract {
 		}
 	}
 
-	return contracts
+	return contracts, nil
 }
 
 func nullStringToWhitelistMethods(rawMethods sql.NullString) []types.WhitelistMethod {
@@ -105,9 +203,17 @@ func nullStringToWhitelistMethods(rawMethods sql.NullString) []types.WhitelistMe
 	return stringToWhitelistMethods(rawMethods.String)
 }
 func stringToWhitelistMethods(rawMethods string) []types.WhitelistMethod {
+	methods, _ := parseWhitelistMethods(rawMethods)
+	return methods
+}
+func parseWhitelistMethods(rawMethods string) ([]types.WhitelistMethod, error) {
 	methods := []types.WhitelistMethod{}
 
-	_ = json.Unmarshal([]byte(rawMethods), &methods)
+	if rawMethods != "" {
+		if err := json.Unmarshal([]byte(rawMethods), &methods); err != nil {
+			return []types.WhitelistMethod{}, err
+		}
+	}
 
 	for i, method := range methods {
 		for j, inMethod := range method.Methods {
@@ -115,12 +221,14 @@ func stringToWhitelistMethods(rawMethods string) []types.WhitelistMethod {
 		}
 	}
 
-	return methods
+	return methods, nil
 }
 
 /* ReadPayPlans returns all pay plans in the database and marshals to types struct */
 func (p *PostgresDriver) ReadPayPlans(ctx context.Context) ([]*types.PayPlan, error) {
-	dbPayPlans, err := p.SelectPayPlans(ctx)
+	dbPayPlans, err := read(ctx, p, func(q *Queries) ([]SelectPayPlansRow, error) {
+		return q.SelectPayPlans(ctx)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -166,46 +274,46 @@ func (p *PostgresDriver) WriteApplication(ctx context.Context, app *types.Applic
 	}
 
 	app.ID = id
-	time := time.Now()
-	app.CreatedAt = time
-	app.UpdatedAt = time
+	app.CreatedAt = time.Now()
 
-	tx, err := p.db.Begin()
+	tx, err := p.beginTx(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = tx.Rollback() }()
 
-	qtx := p.WithTx(tx)
+	qtx := p.WithTx(tx.Tx)
 
-	err = qtx.InsertApplication(ctx, extractInsertDBApp(app))
+	// updated_at is set by the database, like the parent bumps of its child tables
+	updatedAt, err := qtx.InsertApplication(ctx, extractInsertDBApp(app))
 	if err != nil {
-		return nil, err
+		return nil, driverError(err)
 	}
+	app.UpdatedAt = updatedAt.Time
 
 	err = qtx.InsertAppLimit(ctx, extractInsertDBAppLimit(app))
 	if err != nil {
-		return nil, err
+		return nil, driverError(err)
 	}
 	gatewayAATParams := extractInsertDBGatewayAAT(app)
 	if gatewayAATParams.isNotNull() {
 		err = qtx.InsertGatewayAAT(ctx, gatewayAATParams)
 		if err != nil {
-			return nil, err
+			return nil, driverError(err)
 		}
 	}
 	gatewaySettingsParams := extractInsertDBGatewaySettings(app)
 	if gatewaySettingsParams.isNotNull() {
 		err = qtx.InsertGatewaySettings(ctx, gatewaySettingsParams)
 		if err != nil {
-			return nil, err
+			return nil, driverError(err)
 		}
 	}
 	notificationSettingsParams := extractInsertDBNotificationSettings(app)
 	if notificationSettingsParams.isNotNull() {
 		err = qtx.InsertNotificationSettings(ctx, notificationSettingsParams)
 		if err != nil {
-			return nil, err
+			return nil, driverError(err)
 		}
 	}
 
@@ -229,7 +337,6 @@ func extractInsertDBApp(app *types.Application) InsertApplicationParams {
 		Status:        newSQLNullString(string(app.Status)),
 		Dummy:         newSQLNullBool(&app.Dummy),
 		CreatedAt:     newSQLNullTime(app.CreatedAt),
-		UpdatedAt:     newSQLNullTime(app.UpdatedAt),
 	}
 }
 
@@ -303,7 +410,8 @@ func (i *InsertNotificationSettingsParams) isNotNull() bool {
 	return true
 }
 
-/* UpdateApplication updates Application and related table rows */
+// UpdateApplication updates Application and related table rows.
+// When ExpectedUpdatedAt is set the update fails with ErrConflict if the application was updated at another time
 func (p *PostgresDriver) UpdateApplication(ctx context.Context, id string, update *types.UpdateApplication) error {
 	if id == "" {
 		return ErrMissingID
@@ -314,38 +422,52 @@ func (p *PostgresDriver) UpdateApplication(ctx context.Context, id string, updat
 		return invalidUpdate
 	}
 
-	tx, err := p.db.Begin()
+	tx, err := p.beginTx(ctx)
 	if err != nil {
 		return err
 	}
 	defer func() { _ = tx.Rollback() }()
 
-	qtx := p.WithTx(tx)
+	qtx := p.WithTx(tx.Tx)
+
+	if update.ExpectedUpdatedAt != nil {
+		updatedAt, err := qtx.LockApplication(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("%w: application %s", ErrNotFound, id)
+			}
+			return err
+		}
+
+		if !updatedAt.Time.Equal(*update.ExpectedUpdatedAt) {
+			return fmt.Errorf("%w: application %s was updated at %s", ErrConflict, id, updatedAt.Time.Format(time.RFC3339Nano))
+		}
+	}
 
 	err = qtx.UpsertApplication(ctx, extractUpsertApplication(id, update))
 	if err != nil {
-		return err
+		return driverError(err)
 	}
 
 	appLimitParams := extractUpsertAppLimit(id, update)
 	if appLimitParams.isNotNull() {
 		err = qtx.UpsertAppLimit(ctx, *appLimitParams)
 		if err != nil {
-			return err
+			return driverError(err)
 		}
 	}
 	gatewaySettingsParams := extractUpsertGatewaySettings(id, update)
 	if gatewaySettingsParams.isNotNull() {
 		err = qtx.UpsertGatewaySettings(ctx, *gatewaySettingsParams)
 		if err != nil {
-			return err
+			return driverError(err)
 		}
 	}
 	notificationSettingsParams := extractUpsertNotificationSettings(id, update)
 	if notificationSettingsParams.isNotNull() {
 		err = qtx.UpsertNotificationSettings(ctx, *notificationSettingsParams)
 		if err != nil {
-			return err
+			return driverError(err)
 		}
 	}
 
@@ -363,7 +485,6 @@ func extractUpsertApplication(id string, update *types.UpdateApplication) Upsert
 		Name:               newSQLNullString(update.Name),
 		Status:             newSQLNullString(string(update.Status)),
 		FirstDateSurpassed: newSQLNullTime(update.FirstDateSurpassed),
-		UpdatedAt:          newSQLNullTime(time.Now()),
 	}
 }
 
@@ -437,7 +558,7 @@ func (p *PostgresDriver) UpdateAppFirstDateSurpassed(ctx context.Context, update
 
 	err := p.UpdateFirstDateSurpassed(ctx, params)
 	if err != nil {
-		return err
+		return driverError(err)
 	}
 
 	return nil
@@ -456,7 +577,7 @@ func (p *PostgresDriver) RemoveApplication(ctx context.Context, id string) error
 
 	err := p.RemoveApp(ctx, params)
 	if err != nil {
-		return err
+		return driverError(err)
 	}
 
 	return nil
@@ -512,7 +633,20 @@ type (
 	}
 )
 
-func (j dbAppJSON) toOutput() *types.Application {
+func (j dbAppJSON) toOutput() (*types.Application, error) {
+	createdAt, err := psqlDateToTime(j.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	updatedAt, err := psqlDateToTime(j.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	firstDateSurpassed, err := psqlDateToTime(j.FirstDateSurpassed)
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.Application{
 		ID:                 j.ApplicationID,
 		UserID:             j.UserID,
@@ -522,22 +656,22 @@ func (j dbAppJSON) toOutput() *types.Application {
 		Owner:              j.Owner,
 		URL:                j.URL,
 		Status:             types.AppStatus(j.Status),
-		CreatedAt:          psqlDateToTime(j.CreatedAt),
-		UpdatedAt:          psqlDateToTime(j.UpdatedAt),
-		FirstDateSurpassed: psqlDateToTime(j.FirstDateSurpassed),
+		CreatedAt:          createdAt,
+		UpdatedAt:          updatedAt,
+		FirstDateSurpassed: firstDateSurpassed,
 		Dummy:              j.Dummy,
-	}
+	}, nil
 }
-func (j dbAppLimitJSON) toOutput() *types.AppLimit {
+func (j dbAppLimitJSON) toOutput() (*types.AppLimit, error) {
 	return &types.AppLimit{
 		ID: j.ApplicationID,
 		PayPlan: types.PayPlan{
 			Type: j.PlanType,
 		},
 		CustomLimit: j.CustomLimit,
-	}
+	}, nil
 }
-func (j dbGatewayAATJSON) toOutput() *types.GatewayAAT {
+func (j dbGatewayAATJSON) toOutput() (*types.GatewayAAT, error) {
 	return &types.GatewayAAT{
 		ID:                   j.ApplicationID,
 		Address:              j.Address,
@@ -546,21 +680,30 @@ func (j dbGatewayAATJSON) toOutput() *types.GatewayAAT {
 		ApplicationPublicKey: j.PublicKey,
 		ApplicationSignature: j.Signature,
 		Version:              j.Version,
-	}
+	}, nil
 }
-func (j dbGatewaySettingsJSON) toOutput() *types.GatewaySettings {
+func (j dbGatewaySettingsJSON) toOutput() (*types.GatewaySettings, error) {
+	contracts, err := parseWhitelistContracts(j.WhitelistContracts)
+	if err != nil {
+		return nil, err
+	}
+	methods, err := parseWhitelistMethods(j.WhitelistMethods)
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.GatewaySettings{
 		ID:                   j.ApplicationID,
 		SecretKey:            j.SecretKey,
 		SecretKeyRequired:    j.SecretKeyRequired,
-		WhitelistContracts:   stringToWhitelistContracts(j.WhitelistContracts),
-		WhitelistMethods:     stringToWhitelistMethods(j.WhitelistMethods),
+		WhitelistContracts:   contracts,
+		WhitelistMethods:     methods,
 		WhitelistOrigins:     j.WhitelistOrigins,
 		WhitelistUserAgents:  j.WhitelistUserAgents,
 		WhitelistBlockchains: j.WhitelistBlockchains,
-	}
+	}, nil
 }
-func (j dbNotificationSettingsJSON) toOutput() *types.NotificationSettings {
+func (j dbNotificationSettingsJSON) toOutput() (*types.NotificationSettings, error) {
 	return &types.NotificationSettings{
 		ID:            j.ApplicationID,
 		SignedUp:      j.SignedUp,
@@ -568,5 +711,5 @@ func (j dbNotificationSettingsJSON) toOutput() *types.NotificationSettings {
 		Half:          j.Half,
 		ThreeQuarters: j.ThreeQuarters,
 		Full:          j.Full,
-	}
+	}, nil
 }
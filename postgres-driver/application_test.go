@@ -100,6 +100,165 @@ func (ts *PGDriverTestSuite) Test_ReadApplications() {
 	}
 }
 
+func (ts *PGDriverTestSuite) Test_ReadApplication() {
+	tests := []struct {
+		name  string
+		appID string
+		app   *types.Application
+		err   error
+	}{
+		{
+			name:  "Should return a single Application from the database by its ID",
+			appID: "test_app_5hdf7sh23jd828",
+			app: &types.Application{
+				ID:     "test_app_5hdf7sh23jd828",
+				UserID: "test_user_04228205bd261a",
+				Name:   "pokt_app_456",
+				URL:    "https://test.app456.io",
+				Dummy:  true,
+				Status: types.InService,
+				Limit: types.AppLimit{
+					PayPlan:     types.PayPlan{Type: types.Enterprise},
+					CustomLimit: 2_000_000,
+				},
+			},
+			err: nil,
+		},
+		{
+			name:  "Should fail if the Application does not exist",
+			appID: "test_app_does_not_exist",
+			err:   ErrNotFound,
+		},
+		{
+			name:  "Should fail if the application ID is not provided",
+			appID: "",
+			err:   ErrMissingID,
+		},
+	}
+
+	for _, test := range tests {
+		app, err := ts.driver.ReadApplication(testCtx, test.appID)
+		ts.ErrorIs(err, test.err)
+		if test.err == nil {
+			ts.Equal(test.app.ID, app.ID)
+			ts.Equal(test.app.UserID, app.UserID)
+			ts.Equal(test.app.Name, app.Name)
+			ts.Equal(test.app.URL, app.URL)
+			ts.Equal(test.app.Dummy, app.Dummy)
+			ts.Equal(test.app.Status, app.Status)
+			ts.Equal(test.app.Limit, app.Limit)
+			ts.NotEmpty(app.CreatedAt)
+			ts.NotEmpty(app.UpdatedAt)
+		}
+	}
+}
+
+func (ts *PGDriverTestSuite) Test_ReadApplicationsPage() {
+	tests := []struct {
+		name               string
+		options            *types.QueryOptions
+		expectedAppIDs     []string
+		expectedNextCursor string
+		err                error
+	}{
+		{
+			name:               "Should return the first page of Applications and a cursor to the next one",
+			options:            &types.QueryOptions{Limit: 1},
+			expectedAppIDs:     []string{"test_app_47hfnths73j2se"},
+			expectedNextCursor: "test_app_47hfnths73j2se",
+			err:                nil,
+		},
+		{
+			name:               "Should return the last page of Applications without a cursor",
+			options:            &types.QueryOptions{Limit: 1, Cursor: "test_app_47hfnths73j2se"},
+			expectedAppIDs:     []string{"test_app_5hdf7sh23jd828"},
+			expectedNextCursor: "",
+			err:                nil,
+		},
+		{
+			name:               "Should only return Applications matching the filters",
+			options:            &types.QueryOptions{UserID: "test_user_04228205bd261a", PayPlan: types.Enterprise, Dummy: boolPointer(true)},
+			expectedAppIDs:     []string{"test_app_5hdf7sh23jd828"},
+			expectedNextCursor: "",
+			err:                nil,
+		},
+		{
+			name:               "Should return an empty page if no Applications match the filters",
+			options:            &types.QueryOptions{Status: types.Decomissioned},
+			expectedAppIDs:     []string{},
+			expectedNextCursor: "",
+			err:                nil,
+		},
+		{
+			name:    "Should fail if the limit is out of range",
+			options: &types.QueryOptions{Limit: types.MaxPageLimit + 1},
+			err:     types.ErrInvalidPageLimit,
+		},
+	}
+
+	for _, test := range tests {
+		page, err := ts.driver.ReadApplicationsPage(testCtx, test.options)
+		ts.Equal(test.err, err)
+		if test.err == nil {
+			appIDs := []string{}
+			for _, app := range page.Applications {
+				appIDs = append(appIDs, app.ID)
+			}
+			ts.Equal(test.expectedAppIDs, appIDs)
+			ts.Equal(test.expectedNextCursor, page.NextCursor)
+		}
+	}
+}
+
+func (ts *PGDriverTestSuite) Test_ReadApplicationsUpdatedSince() {
+	tests := []struct {
+		name           string
+		since          time.Time
+		expectedAppIDs []string
+		err            error
+	}{
+		{
+			name:           "Should return all Applications when reading since the zero time",
+			since:          time.Time{},
+			expectedAppIDs: []string{"test_app_47hfnths73j2se", "test_app_5hdf7sh23jd828"},
+			err:            nil,
+		},
+		{
+			name:           "Should return no Applications when reading since a future time",
+			since:          time.Now().Add(24 * time.Hour),
+			expectedAppIDs: nil,
+			err:            nil,
+		},
+	}
+
+	for _, test := range tests {
+		applications, err := ts.driver.ReadApplicationsUpdatedSince(testCtx, test.since)
+		ts.Equal(test.err, err)
+
+		var appIDs []string
+		for _, app := range applications {
+			appIDs = append(appIDs, app.ID)
+		}
+		ts.Equal(test.expectedAppIDs, appIDs)
+	}
+
+	// A change in a child table must bump the parent Application's updated_at
+	appBefore, err := ts.driver.ReadApplication(testCtx, "test_app_47hfnths73j2se")
+	ts.NoError(err)
+
+	err = ts.driver.UpsertAppLimit(testCtx, UpsertAppLimitParams{
+		ApplicationID: "test_app_47hfnths73j2se",
+		PayPlan:       string(types.FreetierV0),
+	})
+	ts.NoError(err)
+
+	applications, err := ts.driver.ReadApplicationsUpdatedSince(testCtx, appBefore.UpdatedAt)
+	ts.NoError(err)
+	ts.Len(applications, 1)
+	ts.Equal("test_app_47hfnths73j2se", applications[0].ID)
+	ts.True(applications[0].UpdatedAt.After(appBefore.UpdatedAt))
+}
+
 func (ts *PGDriverTestSuite) Test_ReadPayPlans() {
 	tests := []struct {
 		name     string
@@ -2,21 +2,26 @@ package postgresdriver
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
+	"github.com/pokt-foundation/portal-db/driver"
 	"github.com/pokt-foundation/portal-db/types"
 )
 
 var (
 	ErrInvalidRedirectJSON = errors.New("error: redirect JSON is invalid")
+	ErrBlockchainInUse     = driver.ErrBlockchainInUse
 )
 
 /* ReadBlockchains returns all blockchains in the database and marshals to types struct */
 func (p *PostgresDriver) ReadBlockchains(ctx context.Context) ([]*types.Blockchain, error) {
-	dbBlockchains, err := p.SelectBlockchains(ctx)
+	dbBlockchains, err := read(ctx, p, func(q *Queries) ([]SelectBlockchainsRow, error) {
+		return q.SelectBlockchains(ctx)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -34,6 +39,29 @@ func (p *PostgresDriver) ReadBlockchains(ctx context.Context) ([]*types.Blockcha
 	return blockchains, nil
 }
 
+/* ReadBlockchainsUpdatedSince returns all blockchains whose row, redirects or sync check options changed after the given time */
+func (p *PostgresDriver) ReadBlockchainsUpdatedSince(ctx context.Context, since time.Time) ([]*types.Blockchain, error) {
+	dbBlockchains, err := read(ctx, p, func(q *Queries) ([]SelectBlockchainsUpdatedSinceRow, error) {
+		return q.SelectBlockchainsUpdatedSince(ctx, newSQLNullTime(since))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var blockchains []*types.Blockchain
+	for _, dbBlockchain := range dbBlockchains {
+		row := SelectBlockchainsRow(dbBlockchain)
+		blockchain, err := row.toBlockchain()
+		if err != nil {
+			return nil, err
+		}
+
+		blockchains = append(blockchains, blockchain)
+	}
+
+	return blockchains, nil
+}
+
 func (b *SelectBlockchainsRow) toBlockchain() (*types.Blockchain, error) {
 	blockchain := types.Blockchain{
 		ID:                b.BlockchainID,
@@ -72,30 +100,50 @@ func (b *SelectBlockchainsRow) toBlockchain() (*types.Blockchain, error) {
 	return &blockchain, nil
 }
 
+/* ReadBlockchain returns a single Blockchain by its ID */
+func (p *PostgresDriver) ReadBlockchain(ctx context.Context, id string) (*types.Blockchain, error) {
+	if id == "" {
+		return nil, ErrMissingID
+	}
+
+	dbBlockchain, err := read(ctx, p, func(q *Queries) (SelectOneBlockchainRow, error) {
+		return q.SelectOneBlockchain(ctx, id)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: blockchain %s", ErrNotFound, id)
+		}
+		return nil, err
+	}
+
+	blockchain := SelectBlockchainsRow(dbBlockchain)
+
+	return blockchain.toBlockchain()
+}
+
 /* WriteBlockchain saves input Blockchain struct to the database */
 func (p *PostgresDriver) WriteBlockchain(ctx context.Context, blockchain *types.Blockchain) (*types.Blockchain, error) {
-	time := time.Now()
-	blockchain.CreatedAt = time
-	blockchain.UpdatedAt = time
+	blockchain.CreatedAt = time.Now()
 
-	tx, err := p.db.Begin()
+	tx, err := p.beginTx(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = tx.Rollback() }()
 
-	qtx := p.WithTx(tx)
+	qtx := p.WithTx(tx.Tx)
 
-	err = qtx.InsertBlockchain(ctx, extractInsertDBBlockchain(blockchain))
+	updatedAt, err := qtx.InsertBlockchain(ctx, extractInsertDBBlockchain(blockchain))
 	if err != nil {
-		return nil, err
+		return nil, driverError(err)
 	}
+	blockchain.UpdatedAt = updatedAt.Time
 
 	syncCheckOptionsParams := extractInsertSyncCheckOptions(blockchain)
 	if syncCheckOptionsParams.isNotNull() {
 		err = qtx.InsertSyncCheckOptions(ctx, syncCheckOptionsParams)
 		if err != nil {
-			return nil, err
+			return nil, driverError(err)
 		}
 	}
 
@@ -124,7 +172,6 @@ func extractInsertDBBlockchain(blockchain *types.Blockchain) InsertBlockchainPar
 		RequestTimeout:    newSQLNullInt32(int32(blockchain.RequestTimeout), false),
 		Active:            newSQLNullBool(&blockchain.Active),
 		CreatedAt:         newSQLNullTime(blockchain.CreatedAt),
-		UpdatedAt:         newSQLNullTime(blockchain.UpdatedAt),
 	}
 }
 
@@ -143,17 +190,204 @@ func (i *InsertSyncCheckOptionsParams) isNotNull() bool {
 	return i.Synccheck.Valid || i.Body.Valid || i.Path.Valid || i.ResultKey.Valid || i.Allowance.Valid
 }
 
+/* UpdateBlockchain updates Blockchain and its sync check options, fields left empty keep their value */
+func (p *PostgresDriver) UpdateBlockchain(ctx context.Context, id string, update *types.UpdateBlockchain) error {
+	if id == "" {
+		return ErrMissingID
+	}
+
+	invalidUpdate := update.Validate()
+	if invalidUpdate != nil {
+		return invalidUpdate
+	}
+
+	tx, err := p.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	qtx := p.WithTx(tx.Tx)
+
+	_, err = qtx.LockBlockchain(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: blockchain %s", ErrNotFound, id)
+		}
+		return err
+	}
+
+	err = qtx.UpdateBlockchain(ctx, extractUpdateBlockchain(id, update))
+	if err != nil {
+		return driverError(err)
+	}
+
+	syncCheckOptionsParams := extractUpsertSyncCheckOptions(id, update)
+	if syncCheckOptionsParams.isNotNull() {
+		err = qtx.UpsertSyncCheckOptions(ctx, *syncCheckOptionsParams)
+		if err != nil {
+			return driverError(err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func extractUpdateBlockchain(id string, update *types.UpdateBlockchain) UpdateBlockchainParams {
+	return UpdateBlockchainParams{
+		BlockchainID:      id,
+		Altruist:          newSQLNullString(update.Altruist),
+		Blockchain:        newSQLNullString(update.Blockchain),
+		ChainID:           newSQLNullString(update.ChainID),
+		ChainIDCheck:      newSQLNullString(update.ChainIDCheck),
+		Description:       newSQLNullString(update.Description),
+		EnforceResult:     newSQLNullString(update.EnforceResult),
+		Network:           newSQLNullString(update.Network),
+		Path:              newSQLNullString(update.Path),
+		Ticker:            newSQLNullString(update.Ticker),
+		BlockchainAliases: update.BlockchainAliases,
+		LogLimitBlocks:    newSQLNullInt32(int32(update.LogLimitBlocks), false),
+		RequestTimeout:    newSQLNullInt32(int32(update.RequestTimeout), false),
+	}
+}
+
+func extractUpsertSyncCheckOptions(id string, update *types.UpdateBlockchain) *UpsertSyncCheckOptionsParams {
+	params := &UpsertSyncCheckOptionsParams{
+		BlockchainID: id,
+		Synccheck:    newSQLNullString(update.SyncCheck),
+	}
+
+	if options := update.SyncCheckOptions; options != nil {
+		params.Body = newSQLNullString(options.Body)
+		params.Path = newSQLNullString(options.Path)
+		params.ResultKey = newSQLNullString(options.ResultKey)
+		params.Allowance = newSQLNullInt32(int32(options.Allowance), false)
+	}
+
+	return params
+}
+func (u *UpsertSyncCheckOptionsParams) isNotNull() bool {
+	return u.Synccheck.Valid || u.Body.Valid || u.Path.Valid || u.ResultKey.Valid || u.Allowance.Valid
+}
+
+// RemoveBlockchain deletes a Blockchain and its sync check options.
+// It fails with ErrBlockchainInUse while redirects or application whitelists reference the blockchain
+func (p *PostgresDriver) RemoveBlockchain(ctx context.Context, id string) error {
+	if id == "" {
+		return ErrMissingID
+	}
+
+	tx, err := p.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	qtx := p.WithTx(tx.Tx)
+
+	_, err = qtx.LockBlockchain(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: blockchain %s", ErrNotFound, id)
+		}
+		return err
+	}
+
+	redirects, err := qtx.CountBlockchainRedirects(ctx, id)
+	if err != nil {
+		return err
+	}
+	if redirects > 0 {
+		return fmt.Errorf("%w: blockchain %s has %d redirects", ErrBlockchainInUse, id, redirects)
+	}
+
+	whitelists, err := qtx.SelectBlockchainWhitelists(ctx, id)
+	if err != nil {
+		return err
+	}
+	for _, whitelist := range whitelists {
+		if whitelist.references(id) {
+			return fmt.Errorf("%w: application %s whitelists blockchain %s", ErrBlockchainInUse, whitelist.ApplicationID, id)
+		}
+	}
+
+	err = qtx.DeleteSyncCheckOptions(ctx, id)
+	if err != nil {
+		return driverError(err)
+	}
+
+	err = qtx.DeleteBlockchain(ctx, id)
+	if err != nil {
+		return driverError(err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+/* references returns true if the gateway settings whitelist the blockchain, its contracts or its methods */
+func (w *SelectBlockchainWhitelistsRow) references(id string) bool {
+	for _, blockchainID := range w.WhitelistBlockchains {
+		if blockchainID == id {
+			return true
+		}
+	}
+	for _, contract := range nullStringToWhitelistContracts(w.WhitelistContracts) {
+		if contract.BlockchainID == id {
+			return true
+		}
+	}
+	for _, method := range nullStringToWhitelistMethods(w.WhitelistMethods) {
+		if method.BlockchainID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
 /* WriteRedirect saves input Redirect struct to the database.
 It must be called separately from WriteBlockchain due to how new chains are added to the dB */
 func (p *PostgresDriver) WriteRedirect(ctx context.Context, redirect *types.Redirect) (*types.Redirect, error) {
-	time := time.Now()
-	redirect.CreatedAt = time
-	redirect.UpdatedAt = time
+	invalidRedirect := redirect.Validate()
+	if invalidRedirect != nil {
+		return nil, invalidRedirect
+	}
 
-	err := p.InsertRedirect(ctx, extractInsertDBRedirect(redirect))
+	tx, err := p.beginTx(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer func() { _ = tx.Rollback() }()
+
+	qtx := p.WithTx(tx.Tx)
+
+	err = checkLoadBalancerExists(ctx, qtx, redirect.LoadBalancerID)
+	if err != nil {
+		return nil, err
+	}
+
+	redirect.CreatedAt = time.Now()
+
+	updatedAt, err := qtx.InsertRedirect(ctx, extractInsertDBRedirect(redirect))
+	if err != nil {
+		return nil, driverError(err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	redirect.UpdatedAt = updatedAt.Time
 
 	return redirect, nil
 }
@@ -165,21 +399,128 @@ func extractInsertDBRedirect(redirect *types.Redirect) InsertRedirectParams {
 		Loadbalancer: redirect.LoadBalancerID,
 		Domain:       redirect.Domain,
 		CreatedAt:    newSQLNullTime(redirect.CreatedAt),
-		UpdatedAt:    newSQLNullTime(redirect.UpdatedAt),
 	}
 }
 
+/* ReadRedirects returns the redirects of the blockchain and load balancer set in the filter, all of them if it is nil */
+func (p *PostgresDriver) ReadRedirects(ctx context.Context, filter *types.RedirectFilter) ([]*types.Redirect, error) {
+	params := SelectRedirectsParams{}
+	if filter != nil {
+		params.BlockchainID = newSQLNullString(filter.BlockchainID)
+		params.Loadbalancer = newSQLNullString(filter.LoadBalancerID)
+	}
+
+	dbRedirects, err := read(ctx, p, func(q *Queries) ([]SelectRedirectsRow, error) {
+		return q.SelectRedirects(ctx, params)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var redirects []*types.Redirect
+	for _, dbRedirect := range dbRedirects {
+		redirects = append(redirects, &types.Redirect{
+			BlockchainID:   dbRedirect.BlockchainID,
+			Alias:          dbRedirect.Alias,
+			LoadBalancerID: dbRedirect.Loadbalancer,
+			Domain:         dbRedirect.Domain,
+			CreatedAt:      dbRedirect.CreatedAt.Time,
+			UpdatedAt:      dbRedirect.UpdatedAt.Time,
+		})
+	}
+
+	return redirects, nil
+}
+
+/* UpdateRedirect updates the Redirect of the blockchain to the domain, fields left empty keep their value */
+func (p *PostgresDriver) UpdateRedirect(ctx context.Context, blockchainID, domain string, update *types.UpdateRedirect) error {
+	if blockchainID == "" || domain == "" {
+		return ErrMissingID
+	}
+
+	invalidUpdate := update.Validate()
+	if invalidUpdate != nil {
+		return invalidUpdate
+	}
+
+	tx, err := p.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	qtx := p.WithTx(tx.Tx)
+
+	if update.LoadBalancerID != "" {
+		err = checkLoadBalancerExists(ctx, qtx, update.LoadBalancerID)
+		if err != nil {
+			return err
+		}
+	}
+
+	rows, err := qtx.UpdateRedirect(ctx, UpdateRedirectParams{
+		Alias:         newSQLNullString(update.Alias),
+		Domain:        newSQLNullString(update.Domain),
+		Loadbalancer:  newSQLNullString(update.LoadBalancerID),
+		BlockchainID:  blockchainID,
+		CurrentDomain: domain,
+	})
+	if err != nil {
+		return driverError(err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: redirect %s of blockchain %s", ErrNotFound, domain, blockchainID)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+/* RemoveRedirect deletes the Redirect of the blockchain to the domain */
+func (p *PostgresDriver) RemoveRedirect(ctx context.Context, blockchainID, domain string) error {
+	if blockchainID == "" || domain == "" {
+		return ErrMissingID
+	}
+
+	rows, err := p.DeleteRedirect(ctx, DeleteRedirectParams{BlockchainID: blockchainID, Domain: domain})
+	if err != nil {
+		return driverError(err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: redirect %s of blockchain %s", ErrNotFound, domain, blockchainID)
+	}
+
+	return nil
+}
+
+// checkLoadBalancerExists returns ErrInvalidReference when no load balancer has the ID, redirects have no foreign key on it.
+// The load balancer is locked FOR SHARE so it can't be deleted before the transaction writing the redirect commits
+func checkLoadBalancerExists(ctx context.Context, q *Queries, lbID string) error {
+	exists, err := q.SelectLoadBalancerExists(ctx, lbID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%w: load balancer %s", ErrInvalidReference, lbID)
+	}
+
+	return nil
+}
+
 /* Activate chain toggles chain.active field on or off */
 func (p *PostgresDriver) ActivateChain(ctx context.Context, id string, active bool) error {
 	params := ActivateBlockchainParams{
 		BlockchainID: id,
 		Active:       newSQLNullBool(&active),
-		UpdatedAt:    newSQLNullTime(time.Now()),
 	}
 
 	err := p.ActivateBlockchain(ctx, params)
 	if err != nil {
-		return err
+		return driverError(err)
 	}
 
 	return nil
@@ -223,7 +564,16 @@ type (
 	}
 )
 
-func (j dbBlockchainJSON) toOutput() *types.Blockchain {
+func (j dbBlockchainJSON) toOutput() (*types.Blockchain, error) {
+	createdAt, err := psqlDateToTime(j.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	updatedAt, err := psqlDateToTime(j.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.Blockchain{
 		ID:                j.BlockchainID,
 		Altruist:          j.Altruist,
@@ -239,27 +589,36 @@ func (j dbBlockchainJSON) toOutput() *types.Blockchain {
 		LogLimitBlocks:    j.LogLimitBlocks,
 		RequestTimeout:    j.RequestTimeout,
 		Active:            j.Active,
-		CreatedAt:         psqlDateToTime(j.CreatedAt),
-		UpdatedAt:         psqlDateToTime(j.UpdatedAt),
-	}
+		CreatedAt:         createdAt,
+		UpdatedAt:         updatedAt,
+	}, nil
 }
-func (j dbSyncCheckOptionsJSON) toOutput() *types.SyncCheckOptions {
+func (j dbSyncCheckOptionsJSON) toOutput() (*types.SyncCheckOptions, error) {
 	return &types.SyncCheckOptions{
 		BlockchainID: j.BlockchainID,
 		Body:         j.Body,
 		Path:         j.Path,
 		ResultKey:    j.ResultKey,
 		Allowance:    j.Allowance,
-	}
+	}, nil
 }
 
-func (j dbRedirectJSON) toOutput() *types.Redirect {
+func (j dbRedirectJSON) toOutput() (*types.Redirect, error) {
+	createdAt, err := psqlDateToTime(j.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	updatedAt, err := psqlDateToTime(j.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.Redirect{
 		BlockchainID:   j.BlockchainID,
 		Alias:          j.Alias,
 		LoadBalancerID: j.LoadBalancerID,
 		Domain:         j.Domain,
-		CreatedAt:      psqlDateToTime(j.CreatedAt),
-		UpdatedAt:      psqlDateToTime(j.UpdatedAt),
-	}
+		CreatedAt:      createdAt,
+		UpdatedAt:      updatedAt,
+	}, nil
 }
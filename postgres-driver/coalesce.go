@@ -0,0 +1,154 @@
+package postgresdriver
+
+import (
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/pokt-foundation/portal-db/types"
+)
+
+/* coalescer collapses notifications of the same entity received within a window into one */
+type coalescer struct {
+	window  time.Duration
+	pending map[string]*types.Notification
+	order   []string
+	merged  *uint64
+}
+
+// coalesceKey identifies the row of the notification by its table and primary key, so only changes of the same row merge.
+// EntityIDs can't be used for it as it holds only the parent's ID for tables with several rows per parent
+func coalesceKey(n *types.Notification) string {
+	var ids []string
+	switch data := n.Data.(type) {
+	case *types.UserAccess:
+		ids = []string{data.ID, data.UserID}
+	case *types.Redirect:
+		ids = []string{data.BlockchainID, data.Domain}
+	default:
+		ids = n.EntityIDs()
+	}
+
+	return string(n.Table) + "/" + strings.Join(ids, "/")
+}
+
+/* add merges n into the pending notification of its entity, which is moved to the end so notifications are flushed by increasing sequence */
+func (c *coalescer) add(n *types.Notification) {
+	key := coalesceKey(n)
+
+	previous, ok := c.pending[key]
+	if ok {
+		n = mergeNotifications(previous, n)
+		atomic.AddUint64(c.merged, 1)
+
+		for i, pendingKey := range c.order {
+			if pendingKey == key {
+				c.order = append(c.order[:i], c.order[i+1:]...)
+				break
+			}
+		}
+	}
+
+	c.pending[key] = n
+	c.order = append(c.order, key)
+}
+
+func (c *coalescer) flush() []*types.Notification {
+	notifications := make([]*types.Notification, 0, len(c.order))
+	for _, key := range c.order {
+		notifications = append(notifications, c.pending[key])
+	}
+
+	c.pending = make(map[string]*types.Notification)
+	c.order = nil
+
+	return notifications
+}
+
+// mergeNotifications returns the latest notification of an entity with the action it amounts to:
+// an INSERT followed by updates is still an INSERT and anything followed by a DELETE is a DELETE.
+// The earliest previous row and the changed fields of every update are kept
+func mergeNotifications(earlier, later *types.Notification) *types.Notification {
+	merged := *later
+
+	if earlier.Action == types.ActionInsert && later.Action == types.ActionUpdate {
+		merged.Action = types.ActionInsert
+	}
+	if earlier.Sequence > merged.Sequence {
+		merged.Sequence = earlier.Sequence
+	}
+
+	if merged.Action == types.ActionUpdate {
+		if earlier.Previous != nil {
+			merged.Previous = earlier.Previous
+		}
+		merged.ChangedFields = mergeFields(append([]string(nil), earlier.ChangedFields...), later.ChangedFields)
+	} else {
+		merged.Previous, merged.ChangedFields = nil, nil
+	}
+
+	return &merged
+}
+
+/* coalesce forwards notifications from inCh to outCh once per window, collapsing those of the same entity */
+func (d *PostgresDriver) coalesce(inCh <-chan *types.Notification, outCh chan<- *types.Notification) {
+	defer close(outCh)
+
+	c := &coalescer{
+		window:  d.coalescingWindow,
+		pending: make(map[string]*types.Notification),
+		merged:  d.coalescedEvents,
+	}
+
+	var timer <-chan time.Time
+
+	send := func(notifications ...*types.Notification) bool {
+		for _, n := range notifications {
+			select {
+			case outCh <- n:
+			case <-d.abort:
+				return false
+			}
+		}
+
+		return true
+	}
+
+	for {
+		select {
+		case n, ok := <-inCh:
+			if !ok {
+				send(c.flush()...)
+				return
+			}
+
+			// Everything pending is sent before a resync so consumers reload after applying it
+			if n.Action == types.ActionResync {
+				timer = nil
+				if !send(append(c.flush(), n)...) {
+					return
+				}
+				continue
+			}
+
+			c.add(n)
+			if timer == nil {
+				timer = time.After(c.window)
+			}
+		case <-timer:
+			timer = nil
+			if !send(c.flush()...) {
+				return
+			}
+		}
+	}
+}
+
+/* CoalescedEvents returns how many notifications were merged into others by WithCoalescingWindow */
+func (d *PostgresDriver) CoalescedEvents() uint64 {
+	if d.coalescedEvents == nil {
+		return 0
+	}
+
+	return atomic.LoadUint64(d.coalescedEvents)
+}
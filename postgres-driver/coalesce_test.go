@@ -0,0 +1,165 @@
+package postgresdriver
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lib/pq"
+	"github.com/pokt-foundation/portal-db/types"
+)
+
+func appEvent(sequence int64, action types.Action, id string) *pq.Notification {
+	payload, _ := json.Marshal(notification{
+		Sequence: sequence,
+		Table:    types.TableApplications,
+		Action:   action,
+		Data:     dbAppJSON{ApplicationID: id, Name: fmt.Sprint(sequence)},
+	})
+
+	return &pq.Notification{Extra: string(payload)}
+}
+
+func TestCoalescingWindow(t *testing.T) {
+	const apps = 10
+
+	listenerMock := NewListenerMock()
+	driver := NewPostgresDriverFromDBInstance(nil, listenerMock, WithCoalescingWindow(time.Second))
+
+	go func() {
+		var sequence int64
+
+		// a bulk update touching every app twice within the window
+		for round := 0; round < 2; round++ {
+			for i := 0; i < apps; i++ {
+				sequence++
+				listenerMock.Notify <- appEvent(sequence, types.ActionUpdate, fmt.Sprintf("app_%d", i))
+			}
+		}
+
+		sequence++
+		listenerMock.Notify <- appEvent(sequence, types.ActionInsert, "new")
+		sequence++
+		listenerMock.Notify <- appEvent(sequence, types.ActionUpdate, "new")
+	}()
+
+	var notifications []*types.Notification
+	for len(notifications) < apps+1 {
+		select {
+		case n := <-driver.NotificationChannel():
+			notifications = append(notifications, n)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out, got %d notifications", len(notifications))
+		}
+	}
+
+	var lastSequence int64
+	for _, n := range notifications {
+		if n.Sequence <= lastSequence {
+			t.Fatalf("notification %d delivered after %d", n.Sequence, lastSequence)
+		}
+		lastSequence = n.Sequence
+
+		// the latest row of each app is kept
+		if n.Data.(*types.Application).Name != fmt.Sprint(n.Sequence) {
+			t.Errorf("expected the latest row for sequence %d, got %v", n.Sequence, n.Data)
+		}
+	}
+
+	last := notifications[len(notifications)-1]
+	if last.Data.(*types.Application).ID != "new" || last.Action != types.ActionInsert {
+		t.Errorf("expected the new app to be inserted, got %s of %v", last.Action, last.Data)
+	}
+
+	if merged := driver.CoalescedEvents(); merged != apps+1 {
+		t.Errorf("expected %d merged events, got %d", apps+1, merged)
+	}
+}
+
+func userAccessEvent(sequence int64, action types.Action, lbID, userID string) *pq.Notification {
+	payload, _ := json.Marshal(notification{
+		Sequence: sequence,
+		Table:    types.TableUserAccess,
+		Action:   action,
+		Data:     dbUserAccessJSON{LbID: lbID, UserID: userID, RoleName: string(types.RoleMember)},
+	})
+
+	return &pq.Notification{Extra: string(payload)}
+}
+
+func TestCoalescingWindowKeepsRowsOfTheSameParent(t *testing.T) {
+	listenerMock := NewListenerMock()
+	driver := NewPostgresDriverFromDBInstance(nil, listenerMock, WithCoalescingWindow(100*time.Millisecond))
+
+	// user A's access is revoked and user B's granted on the same load balancer within the window
+	go func() {
+		listenerMock.Notify <- userAccessEvent(1, types.ActionDelete, "lb_1", "user_a")
+		listenerMock.Notify <- userAccessEvent(2, types.ActionInsert, "lb_1", "user_b")
+		listenerMock.Notify <- userAccessEvent(3, types.ActionUpdate, "lb_1", "user_b")
+	}()
+
+	var notifications []*types.Notification
+	for len(notifications) < 2 {
+		select {
+		case n := <-driver.NotificationChannel():
+			notifications = append(notifications, n)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out, got %d notifications", len(notifications))
+		}
+	}
+
+	type delivered struct {
+		Action types.Action
+		UserID string
+	}
+	var got []delivered
+	for _, n := range notifications {
+		got = append(got, delivered{n.Action, n.Data.(*types.UserAccess).UserID})
+	}
+
+	expected := []delivered{{types.ActionDelete, "user_a"}, {types.ActionInsert, "user_b"}}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("unexpected notifications (-want +got):\n%s", diff)
+	}
+
+	if merged := driver.CoalescedEvents(); merged != 1 {
+		t.Errorf("expected 1 merged event, got %d", merged)
+	}
+}
+
+func TestMergeNotifications(t *testing.T) {
+	tests := []struct {
+		name           string
+		earlier, later *types.Notification
+		expected       *types.Notification
+	}{
+		{
+			name:     "insert then update is an insert",
+			earlier:  &types.Notification{Sequence: 1, Action: types.ActionInsert, Data: &types.Application{ID: "321", Name: "a"}},
+			later:    &types.Notification{Sequence: 2, Action: types.ActionUpdate, Data: &types.Application{ID: "321", Name: "b"}, Previous: &types.Application{ID: "321", Name: "a"}, ChangedFields: []string{"name"}},
+			expected: &types.Notification{Sequence: 2, Action: types.ActionInsert, Data: &types.Application{ID: "321", Name: "b"}},
+		},
+		{
+			name:     "updates keep the earliest previous row and every changed field",
+			earlier:  &types.Notification{Sequence: 1, Action: types.ActionUpdate, Data: &types.Application{ID: "321", Name: "b"}, Previous: &types.Application{ID: "321", Name: "a"}, ChangedFields: []string{"name"}},
+			later:    &types.Notification{Sequence: 2, Action: types.ActionUpdate, Data: &types.Application{ID: "321", Name: "b", Status: types.Ready}, Previous: &types.Application{ID: "321", Name: "b"}, ChangedFields: []string{"status"}},
+			expected: &types.Notification{Sequence: 2, Action: types.ActionUpdate, Data: &types.Application{ID: "321", Name: "b", Status: types.Ready}, Previous: &types.Application{ID: "321", Name: "a"}, ChangedFields: []string{"name", "status"}},
+		},
+		{
+			name:     "anything then delete is a delete",
+			earlier:  &types.Notification{Sequence: 1, Action: types.ActionUpdate, Data: &types.Application{ID: "321"}, ChangedFields: []string{"name"}},
+			later:    &types.Notification{Sequence: 2, Action: types.ActionDelete, Data: &types.Application{ID: "321"}},
+			expected: &types.Notification{Sequence: 2, Action: types.ActionDelete, Data: &types.Application{ID: "321"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if diff := cmp.Diff(test.expected, mergeNotifications(test.earlier, test.later)); diff != "" {
+				t.Errorf("unexpected value (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package postgresdriver_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pokt-foundation/portal-db/driver"
+	"github.com/pokt-foundation/portal-db/drivertest"
+	postgresdriver "github.com/pokt-foundation/portal-db/postgres-driver"
+	"github.com/stretchr/testify/require"
+)
+
+// Test_RunPGDriverConformanceSuite runs the drivertest suite against the test database of TEST_DATABASE_URL, skipping it when it is not set.
+// It is an external test so drivertest can import this package, and runs after Test_RunPGDriverSuite which initializes and seeds the database
+func Test_RunPGDriverConformanceSuite(t *testing.T) {
+	connectionString := os.Getenv("TEST_DATABASE_URL")
+	if connectionString == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	drivertest.Run(t, func(t *testing.T) driver.Driver {
+		listener := pq.NewListener(connectionString, 10*time.Second, time.Minute, nil)
+
+		d, err := postgresdriver.NewPostgresDriver(connectionString, listener)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = d.Close(context.Background()) })
+
+		return d
+	})
+}
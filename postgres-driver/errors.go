@@ -0,0 +1,33 @@
+package postgresdriver
+
+import (
+	"errors"
+
+	"github.com/lib/pq"
+	"github.com/pokt-foundation/portal-db/driver"
+)
+
+const (
+	uniqueViolation     = "23505"
+	foreignKeyViolation = "23503"
+)
+
+/* driverError maps unique and foreign key violations to ErrAlreadyExists and ErrInvalidReference, other errors are returned as is */
+func driverError(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+
+	var mapped error
+	switch pqErr.Code {
+	case uniqueViolation:
+		mapped = ErrAlreadyExists
+	case foreignKeyViolation:
+		mapped = ErrInvalidReference
+	default:
+		return err
+	}
+
+	return &driver.Error{Err: mapped, Code: string(pqErr.Code), Constraint: pqErr.Constraint, Cause: err}
+}
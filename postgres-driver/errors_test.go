@@ -0,0 +1,65 @@
+package postgresdriver
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/pokt-foundation/portal-db/driver"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDriverError(t *testing.T) {
+	tests := []struct {
+		name               string
+		err                error
+		expectedErr        error
+		expectedConstraint string
+	}{
+		{
+			name:               "Should map a unique violation to ErrAlreadyExists",
+			err:                &pq.Error{Code: "23505", Constraint: "blockchains_blockchain_id_key"},
+			expectedErr:        ErrAlreadyExists,
+			expectedConstraint: "blockchains_blockchain_id_key",
+		},
+		{
+			name:               "Should map a wrapped foreign key violation to ErrInvalidReference",
+			err:                fmt.Errorf("insert: %w", &pq.Error{Code: "23503", Constraint: "fk_role"}),
+			expectedErr:        ErrInvalidReference,
+			expectedConstraint: "fk_role",
+		},
+		{
+			name:        "Should return other database errors as is",
+			err:         &pq.Error{Code: "42P01"},
+			expectedErr: &pq.Error{Code: "42P01"},
+		},
+		{
+			name:        "Should return other errors as is",
+			err:         ErrMissingID,
+			expectedErr: ErrMissingID,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := require.New(t)
+
+			err := driverError(test.err)
+			if test.expectedConstraint == "" {
+				c.Equal(test.expectedErr, err)
+				return
+			}
+
+			c.ErrorIs(err, test.expectedErr)
+
+			var driverErr *driver.Error
+			c.True(errors.As(err, &driverErr))
+			c.Equal(test.expectedConstraint, driverErr.Constraint)
+
+			// the database error stays reachable
+			var pqErr *pq.Error
+			c.True(errors.As(err, &pqErr))
+		})
+	}
+}
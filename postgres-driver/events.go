@@ -0,0 +1,530 @@
+package postgresdriver
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pokt-foundation/portal-db/driver"
+	"github.com/pokt-foundation/portal-db/types"
+)
+
+const (
+	// eventsPageSize is how many events are read from the outbox at a time
+	eventsPageSize = 1000
+	// eventPruneInterval is how often WithEventRetention deletes the events past their retention
+	eventPruneInterval = time.Hour
+)
+
+var (
+	ErrListenerClosed = driver.ErrListenerClosed
+)
+
+type (
+	subscription struct {
+		live    chan *types.Notification
+		done    <-chan struct{}
+		options *types.SubscriptionOptions
+		// disconnected is set before live is closed when the subscriber could not keep up
+		disconnected bool
+	}
+
+	subscriptions struct {
+		mu     sync.Mutex
+		subs   map[*subscription]struct{}
+		closed bool
+	}
+
+	// sequenceTracker follows the sequences the listener dispatches until they are broadcast or dropped,
+	// so subscriptions know which replayed sequences can no longer arrive live
+	sequenceTracker struct {
+		mu sync.Mutex
+		// pending holds the sequences handed to each worker in dispatch order
+		pending map[int][]int64
+		workers map[int64]int
+		highest int64
+	}
+)
+
+/* ReadEventsSince returns the events saved after the given sequence in the order they were committed */
+func (d *PostgresDriver) ReadEventsSince(ctx context.Context, sequence int64) ([]*types.Notification, error) {
+	var notifications []*types.Notification
+
+	for {
+		page, next, err := d.readEventsPage(ctx, sequence)
+		if err != nil {
+			return nil, err
+		}
+		if next == sequence {
+			return notifications, nil
+		}
+
+		notifications = append(notifications, page...)
+		sequence = next
+	}
+}
+
+// readEventsPage returns up to eventsPageSize events saved after the given sequence and the sequence of the last one read,
+// which is the given sequence once there are no more
+func (d *PostgresDriver) readEventsPage(ctx context.Context, sequence int64) ([]*types.Notification, int64, error) {
+	events, err := d.SelectEventsSince(ctx, SelectEventsSinceParams{Sequence: sequence, MaxResults: eventsPageSize})
+	if err != nil {
+		return nil, sequence, err
+	}
+
+	var notifications []*types.Notification
+
+	for _, event := range events {
+		sequence = event.Sequence
+
+		n, err := event.toNotification()
+		if err == nil {
+			var parsed *types.Notification
+			parsed, err = n.parseNotification()
+			if err == nil {
+				notifications = append(notifications, parsed)
+				continue
+			}
+		}
+
+		// Events for tables without a Notification type are skipped the same way the listener does
+		d.reportError(&NotificationError{Payload: string(event.Data), Err: err})
+	}
+
+	return notifications, sequence, nil
+}
+
+// Subscribe returns an independent channel with the notifications that pass the options' filter.
+// When FromSequence is set, the events saved after it are replayed first, a page at a time, and live notifications already
+// replayed are skipped, missed events are replayed as well when the listener reconnects.
+// The channel is closed when ctx is done, the driver is closed, replaying fails or the overflow policy disconnects it
+func (d *PostgresDriver) Subscribe(ctx context.Context, options *types.SubscriptionOptions) (<-chan *types.Notification, error) {
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+	if options == nil {
+		options = &types.SubscriptionOptions{}
+	}
+
+	sub, err := d.subscriptions.add(ctx.Done(), options)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastSequence, replayedUntil int64
+	var replayed []*types.Notification
+
+	// The first page is replayed here so errors reading it are returned
+	if options.FromSequence != nil {
+		lastSequence = *options.FromSequence
+
+		replayed, replayedUntil, err = d.replay(ctx, lastSequence)
+		if err != nil {
+			d.subscriptions.remove(sub)
+			return nil, err
+		}
+	}
+
+	out := make(chan *types.Notification)
+
+	go d.serveSubscription(ctx, sub, replayed, replayedUntil, lastSequence, out)
+
+	return out, nil
+}
+
+// replay reads the next page of events saved after sequence, merged into their aggregates when WithAggregateNotifications is used,
+// and returns the sequence of the last event read
+func (d *PostgresDriver) replay(ctx context.Context, sequence int64) ([]*types.Notification, int64, error) {
+	events, next, err := d.replayEvents(ctx, sequence)
+	if err != nil || d.aggregator == nil {
+		return events, next, err
+	}
+
+	var aggregated []*types.Notification
+
+	for _, group := range groupByAggregate(events) {
+		n, err := d.aggregator.aggregate(ctx, group)
+		if err != nil {
+			return nil, sequence, err
+		}
+
+		aggregated = append(aggregated, n)
+	}
+
+	return aggregated, next, nil
+}
+
+// serveSubscription sends the replayed notifications and then the live ones to out.
+// Live notifications are only skipped when they were replayed, not by comparing them with the last sequence sent,
+// as with several listener workers the notifications of different entities don't arrive in sequence order
+func (d *PostgresDriver) serveSubscription(ctx context.Context, sub *subscription, replayed []*types.Notification,
+	replayedUntil, lastSequence int64, out chan *types.Notification) {
+	defer close(out)
+	defer d.subscriptions.remove(sub)
+
+	offset := lastSequence
+	replayedSequences := make(map[int64]struct{})
+
+	// Replayed sequences never received live are dropped once the live notifications pending when the watermark was
+	// read are consumed, as anything at or below it was broadcast before and can't arrive anymore
+	var watermark int64
+	pendingLive := -1
+
+	prune := func() {
+		if pendingLive == 0 {
+			for sequence := range replayedSequences {
+				if sequence <= watermark {
+					delete(replayedSequences, sequence)
+				}
+			}
+			pendingLive = -1
+		}
+
+		if pendingLive < 0 && len(replayedSequences) > 0 {
+			watermark = d.sequences.watermark()
+			pendingLive = len(sub.live)
+		}
+	}
+
+	deliver := func(n *types.Notification) bool {
+		select {
+		case out <- n:
+		case <-ctx.Done():
+			return false
+		}
+
+		if n.Sequence > lastSequence {
+			lastSequence = n.Sequence
+		}
+
+		return true
+	}
+
+	sendReplayed := func(notifications ...*types.Notification) bool {
+		for _, n := range notifications {
+			if _, ok := replayedSequences[n.Sequence]; ok || !sub.options.Matches(n) {
+				continue
+			}
+			replayedSequences[n.Sequence] = struct{}{}
+
+			if !deliver(n) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	send := func(n *types.Notification) bool {
+		if !sub.options.Matches(n) {
+			return true
+		}
+
+		// Notifications without a sequence were not read from the outbox and can't be deduplicated
+		if n.Sequence != 0 && sub.options.FromSequence != nil {
+			if n.Sequence <= offset {
+				return true
+			}
+			if _, ok := replayedSequences[n.Sequence]; ok {
+				delete(replayedSequences, n.Sequence)
+				return true
+			}
+		}
+
+		return deliver(n)
+	}
+
+	// replayFrom sends the events saved after sequence a page at a time
+	replayFrom := func(sequence int64) bool {
+		for {
+			missed, next, err := d.replay(ctx, sequence)
+			if err != nil {
+				d.reportError(err)
+				return false
+			}
+			if next == sequence {
+				return true
+			}
+			if !sendReplayed(missed...) {
+				return false
+			}
+			sequence = next
+		}
+	}
+
+	if !sendReplayed(replayed...) {
+		return
+	}
+	if replayedUntil != offset && !replayFrom(replayedUntil) {
+		return
+	}
+
+	for {
+		prune()
+
+		var n *types.Notification
+		var ok bool
+
+		select {
+		case n, ok = <-sub.live:
+			if !ok {
+				if sub.disconnected {
+					send(&types.Notification{Action: types.ActionResync})
+				}
+				return
+			}
+			if pendingLive > 0 {
+				pendingLive--
+			}
+		case <-ctx.Done():
+			return
+		}
+
+		// Subscriptions without an offset can't replay what they missed so the resync is passed on
+		if n.Action != types.ActionResync || sub.options.FromSequence == nil {
+			if !send(n) {
+				return
+			}
+			continue
+		}
+
+		if !replayFrom(lastSequence) {
+			return
+		}
+	}
+}
+
+/* pruneEvents deletes the events older than the retention every eventPruneInterval until stop is closed */
+func (d *PostgresDriver) pruneEvents(stop <-chan struct{}) {
+	ticker := time.NewTicker(eventPruneInterval)
+	defer ticker.Stop()
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), eventReadTimeout)
+		_, err := d.DeleteEventsBefore(ctx, d.eventRetention.Seconds())
+		cancel()
+
+		if err != nil {
+			d.reportError(err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (d *PostgresDriver) reportError(err error) {
+	if d.errorHandler != nil {
+		d.errorHandler(err)
+	}
+}
+
+/* broadcast delivers notifications to the Notification channel, once it is read, and every subscription, closing them once inCh is closed */
+func (d *PostgresDriver) broadcast(inCh <-chan *types.Notification) {
+	for n := range inCh {
+		if atomic.LoadUint32(&d.notificationRead) == 1 {
+			select {
+			case d.notification <- n:
+			case <-d.abort:
+				continue
+			}
+		} else {
+			select {
+			case d.notification <- n:
+			default:
+			}
+		}
+
+		d.subscriptions.send(n, d.abort)
+		d.sequences.delivered(n.Sequence)
+	}
+
+	close(d.notification)
+	d.subscriptions.close()
+}
+
+func (s *subscriptions) add(done <-chan struct{}, options *types.SubscriptionOptions) (*subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, ErrListenerClosed
+	}
+	if s.subs == nil {
+		s.subs = make(map[*subscription]struct{})
+	}
+
+	sub := &subscription{
+		live:    make(chan *types.Notification, options.Buffer()),
+		done:    done,
+		options: options,
+	}
+	s.subs[sub] = struct{}{}
+
+	return sub, nil
+}
+
+func (s *subscriptions) remove(sub *subscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.subs, sub)
+}
+
+func (s *subscriptions) send(n *types.Notification, abort <-chan struct{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for sub := range s.subs {
+		if !sub.options.Matches(n) {
+			continue
+		}
+
+		switch sub.options.Policy() {
+		case types.OverflowDropOldest:
+			sub.sendDropOldest(n)
+		case types.OverflowDisconnect:
+			select {
+			case sub.live <- n:
+			default:
+				sub.disconnected = true
+				close(sub.live)
+				delete(s.subs, sub)
+			}
+		default:
+			select {
+			case sub.live <- n:
+			case <-sub.done:
+			case <-abort:
+			}
+		}
+	}
+}
+
+/* sendDropOldest makes room for n by discarding the oldest buffered notifications, only the broadcaster sends to live */
+func (sub *subscription) sendDropOldest(n *types.Notification) {
+	for {
+		select {
+		case sub.live <- n:
+			return
+		default:
+		}
+
+		select {
+		case <-sub.live:
+		default:
+		}
+	}
+}
+
+func (s *subscriptions) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for sub := range s.subs {
+		close(sub.live)
+	}
+	s.subs = nil
+	s.closed = true
+}
+
+func newSequenceTracker() *sequenceTracker {
+	return &sequenceTracker{
+		pending: make(map[int][]int64),
+		workers: make(map[int64]int),
+	}
+}
+
+/* dispatched records that the notification with the given sequence was handed to the worker */
+func (t *sequenceTracker) dispatched(worker int, sequence int64) {
+	if t == nil || sequence == 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.pending[worker] = append(t.pending[worker], sequence)
+	t.workers[sequence] = worker
+	if sequence > t.highest {
+		t.highest = sequence
+	}
+}
+
+// delivered marks the sequence and those handed to its worker before it as broadcast, as workers keep their order
+// and the notifications merged by aggregation or coalescing carry the latest sequence of the ones they hold
+func (t *sequenceTracker) delivered(sequence int64) {
+	if t == nil || sequence == 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	worker, ok := t.workers[sequence]
+	if !ok {
+		return
+	}
+
+	pending := t.pending[worker]
+	for len(pending) > 0 {
+		done := pending[0]
+		pending = pending[1:]
+		delete(t.workers, done)
+
+		if done == sequence {
+			break
+		}
+	}
+	t.pending[worker] = pending
+}
+
+/* dropped marks the sequences of notifications the listener discarded as done */
+func (t *sequenceTracker) dropped(sequences ...int64) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, sequence := range sequences {
+		worker, ok := t.workers[sequence]
+		if !ok {
+			continue
+		}
+		delete(t.workers, sequence)
+
+		pending := t.pending[worker]
+		for i, pendingSequence := range pending {
+			if pendingSequence == sequence {
+				t.pending[worker] = append(pending[:i], pending[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// watermark returns the sequence at or below which every notification dispatched was broadcast or dropped.
+// It relies on notifications arriving by increasing sequence, which holds as events are numbered under a lock kept until they commit
+func (t *sequenceTracker) watermark() int64 {
+	if t == nil {
+		return 0
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	mark := t.highest
+	for _, pending := range t.pending {
+		if len(pending) > 0 && pending[0]-1 < mark {
+			mark = pending[0] - 1
+		}
+	}
+
+	return mark
+}
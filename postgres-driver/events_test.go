@@ -0,0 +1,478 @@
+package postgresdriver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pokt-foundation/portal-db/types"
+)
+
+func (ts *PGDriverTestSuite) Test_ReadEventsSince() {
+	events, err := ts.driver.ReadEventsSince(testCtx, 0)
+	ts.NoError(err)
+
+	var lastSequence int64
+	for _, event := range events {
+		ts.Greater(event.Sequence, lastSequence)
+		lastSequence = event.Sequence
+	}
+
+	err = ts.driver.ActivateChain(testCtx, "0001", true)
+	ts.NoError(err)
+
+	events, err = ts.driver.ReadEventsSince(testCtx, lastSequence)
+	ts.NoError(err)
+	ts.Len(events, 1)
+	ts.Greater(events[0].Sequence, lastSequence)
+	ts.Equal(types.TableBlockchains, events[0].Table)
+	ts.Equal(types.ActionUpdate, events[0].Action)
+	ts.Equal("0001", events[0].Data.(*types.Blockchain).ID)
+	ts.Equal("0001", events[0].Previous.(*types.Blockchain).ID)
+	ts.Equal([]string{"updated_at"}, events[0].ChangedFields)
+}
+
+func (ts *PGDriverTestSuite) Test_DeleteEventsBefore() {
+	events, err := ts.driver.ReadEventsSince(testCtx, 0)
+	ts.NoError(err)
+	ts.NotEmpty(events)
+
+	// the events were all saved within the last hour
+	deleted, err := ts.driver.DeleteEventsBefore(testCtx, time.Hour.Seconds())
+	ts.NoError(err)
+	ts.Zero(deleted)
+
+	retained, err := ts.driver.ReadEventsSince(testCtx, 0)
+	ts.NoError(err)
+	ts.Len(retained, len(events))
+}
+
+type eventStoreMock struct {
+	mu     sync.Mutex
+	events []*types.Notification
+}
+
+func (s *eventStoreMock) add(sequence int64) *pq.Notification {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events = append(s.events, &types.Notification{
+		Sequence: sequence,
+		Table:    types.TableLbApps,
+		Action:   types.ActionInsert,
+		Data:     &types.LbApp{LbID: "123", AppID: "a123"},
+	})
+
+	payload, _ := json.Marshal(notification{
+		Sequence: sequence,
+		Table:    types.TableLbApps,
+		Action:   types.ActionInsert,
+		Data:     types.LbApp{LbID: "123", AppID: "a123"},
+	})
+
+	return &pq.Notification{Extra: string(payload)}
+}
+
+/* readEventsPage returns the events after sequence two at a time so replaying goes through several pages */
+func (s *eventStoreMock) readEventsPage(ctx context.Context, sequence int64) ([]*types.Notification, int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var events []*types.Notification
+	for _, event := range s.events {
+		if event.Sequence > sequence && len(events) < 2 {
+			events = append(events, event)
+			sequence = event.Sequence
+		}
+	}
+
+	return events, sequence, nil
+}
+
+func drainNotifications(driver *PostgresDriver) {
+	go func() {
+		for range driver.NotificationChannel() {
+		}
+	}()
+}
+
+func receiveSequences(t *testing.T, ch <-chan *types.Notification, count int) []int64 {
+	t.Helper()
+
+	var sequences []int64
+	for i := 0; i < count; i++ {
+		select {
+		case n := <-ch:
+			sequences = append(sequences, n.Sequence)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for notifications, got %v", sequences)
+		}
+	}
+
+	return sequences
+}
+
+func TestSubscribe(t *testing.T) {
+	store := &eventStoreMock{}
+	listenerMock := NewListenerMock()
+	driver := NewPostgresDriverFromDBInstance(nil, listenerMock)
+	driver.replayEvents = store.readEventsPage
+	drainNotifications(driver)
+
+	for sequence := int64(1); sequence <= 3; sequence++ {
+		store.add(sequence)
+	}
+
+	fromSequence := int64(1)
+
+	subscription, err := driver.Subscribe(context.Background(), &types.SubscriptionOptions{FromSequence: &fromSequence})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Already replayed, must not be delivered twice
+	listenerMock.Notify <- &pq.Notification{Extra: `{"sequence":3,"table":"lb_apps","action":"INSERT","data":{"lb_id":"123","app_id":"a123"}}`}
+	listenerMock.Notify <- store.add(4)
+
+	// Event 5 is missed while the listener reconnects and must be replayed
+	store.add(5)
+	listenerMock.Notify <- nil
+	listenerMock.Notify <- &pq.Notification{Extra: `{"sequence":5,"table":"lb_apps","action":"INSERT","data":{"lb_id":"123","app_id":"a123"}}`}
+	listenerMock.Notify <- store.add(6)
+
+	sequences := receiveSequences(t, subscription, 5)
+	expectedSequences := []int64{2, 3, 4, 5, 6}
+
+	for i := range expectedSequences {
+		if sequences[i] != expectedSequences[i] {
+			t.Fatalf("expected sequences %v, got %v", expectedSequences, sequences)
+		}
+	}
+
+	if err := driver.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for n := range subscription {
+		t.Errorf("unexpected notification after close: %v", n)
+	}
+
+	if _, err := driver.Subscribe(context.Background(), nil); !errors.Is(err, ErrListenerClosed) {
+		t.Errorf("expected ErrListenerClosed, got %v", err)
+	}
+}
+
+func TestSubscribeWithWorkers(t *testing.T) {
+	const replayed, live = 3, 50
+
+	store := &eventStoreMock{}
+	listenerMock := NewListenerMock()
+	driver := NewPostgresDriverFromDBInstance(nil, listenerMock, WithListenerWorkers(4))
+	driver.replayEvents = store.readEventsPage
+	drainNotifications(driver)
+
+	for sequence := int64(1); sequence <= replayed; sequence++ {
+		store.add(sequence)
+	}
+
+	fromSequence := int64(0)
+
+	subscription, err := driver.Subscribe(context.Background(), &types.SubscriptionOptions{FromSequence: &fromSequence})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The workers deliver the notifications of different entities out of sequence order, none may be skipped
+	go func() {
+		listenerMock.Notify <- &pq.Notification{Extra: `{"sequence":2,"table":"lb_apps","action":"INSERT","data":{"lb_id":"123","app_id":"a123"}}`}
+
+		for sequence := replayed + live; sequence > replayed; sequence-- {
+			payload, _ := json.Marshal(notification{
+				Sequence: int64(sequence),
+				Table:    types.TableLbApps,
+				Action:   types.ActionInsert,
+				Data:     types.LbApp{LbID: fmt.Sprintf("lb_%d", sequence), AppID: "a123"},
+			})
+			listenerMock.Notify <- &pq.Notification{Extra: string(payload)}
+		}
+	}()
+
+	received := make(map[int64]int)
+	for _, sequence := range receiveSequences(t, subscription, replayed+live) {
+		received[sequence]++
+	}
+
+	for sequence := int64(1); sequence <= replayed+live; sequence++ {
+		if received[sequence] != 1 {
+			t.Errorf("expected sequence %d once, got it %d times", sequence, received[sequence])
+		}
+	}
+
+	select {
+	case n := <-subscription:
+		t.Errorf("unexpected notification %d", n.Sequence)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	if err := driver.Close(context.Background()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestSubscribeWithoutReadingNotificationChannel(t *testing.T) {
+	const events = 100
+
+	listenerMock := NewListenerMock()
+	driver := NewPostgresDriverFromDBInstance(nil, listenerMock)
+
+	subscription, err := driver.Subscribe(context.Background(), &types.SubscriptionOptions{BufferSize: events})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// NotificationChannel is never read, its buffer filling up must not hold back the subscription
+	go func() {
+		for sequence := 1; sequence <= events; sequence++ {
+			listenerMock.Notify <- &pq.Notification{Extra: fmt.Sprintf(`{"sequence":%d,"table":"lb_apps","action":"INSERT","data":{"lb_id":"123","app_id":"a123"}}`, sequence)}
+		}
+	}()
+
+	sequences := receiveSequences(t, subscription, events)
+	for i, sequence := range sequences {
+		if sequence != int64(i+1) {
+			t.Fatalf("expected sequence %d, got %d", i+1, sequence)
+		}
+	}
+
+	if err := driver.Close(context.Background()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestSubscribeCancel(t *testing.T) {
+	store := &eventStoreMock{}
+	listenerMock := NewListenerMock()
+	driver := NewPostgresDriverFromDBInstance(nil, listenerMock)
+	driver.replayEvents = store.readEventsPage
+	drainNotifications(driver)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	subscription, err := driver.Subscribe(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cancel()
+
+	select {
+	case _, open := <-subscription:
+		if open {
+			t.Error("expected no notifications")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription was not closed")
+	}
+
+	// The driver keeps delivering once the subscription is gone
+	listenerMock.Notify <- store.add(1)
+
+	if err := driver.Close(context.Background()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestSubscribeFilters(t *testing.T) {
+	listenerMock := NewListenerMock()
+	driver := NewPostgresDriverFromDBInstance(nil, listenerMock)
+
+	subscribe := func(filter types.SubscriptionFilter) <-chan *types.Notification {
+		subscription, err := driver.Subscribe(context.Background(), &types.SubscriptionOptions{
+			SubscriptionFilter: filter,
+			BufferSize:         64,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		return subscription
+	}
+
+	all := subscribe(types.SubscriptionFilter{})
+	loadBalancers := subscribe(types.SubscriptionFilter{Tables: []types.Table{types.TableLoadBalancers}})
+	deletes := subscribe(types.SubscriptionFilter{Actions: []types.Action{types.ActionDelete}})
+	app := subscribe(types.SubscriptionFilter{EntityID: "a123"})
+
+	listenerMock.MockEvent(types.ActionInsert, types.ActionInsert, &types.LoadBalancer{ID: "lb123"})
+	listenerMock.MockEvent(types.ActionDelete, types.ActionDelete, &types.LbApp{LbID: "lb123", AppID: "a123"})
+	listenerMock.MockEvent(types.ActionUpdate, types.ActionUpdate, &types.Blockchain{ID: "0021"})
+	listenerMock.Notify <- nil
+
+	tests := []struct {
+		name            string
+		subscription    <-chan *types.Notification
+		expectedActions []types.Action
+		expectedTables  []types.Table
+	}{
+		{
+			name:            "no filter",
+			subscription:    all,
+			expectedActions: []types.Action{types.ActionInsert, types.ActionDelete, types.ActionUpdate, types.ActionResync},
+			expectedTables:  []types.Table{types.TableLoadBalancers, types.TableLbApps, types.TableBlockchains, ""},
+		},
+		{
+			name:            "table filter",
+			subscription:    loadBalancers,
+			expectedActions: []types.Action{types.ActionInsert, types.ActionResync},
+			expectedTables:  []types.Table{types.TableLoadBalancers, ""},
+		},
+		{
+			name:            "action filter",
+			subscription:    deletes,
+			expectedActions: []types.Action{types.ActionDelete, types.ActionResync},
+			expectedTables:  []types.Table{types.TableLbApps, ""},
+		},
+		{
+			name:            "entity filter",
+			subscription:    app,
+			expectedActions: []types.Action{types.ActionDelete, types.ActionResync},
+			expectedTables:  []types.Table{types.TableLbApps, ""},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for i := range tc.expectedActions {
+				select {
+				case n := <-tc.subscription:
+					if n.Action != tc.expectedActions[i] || n.Table != tc.expectedTables[i] {
+						t.Errorf("expected %s on %s, got %s on %s", tc.expectedActions[i], tc.expectedTables[i], n.Action, n.Table)
+					}
+				case <-time.After(5 * time.Second):
+					t.Fatal("timed out waiting for notification")
+				}
+			}
+		})
+	}
+
+	if _, err := driver.Subscribe(context.Background(), &types.SubscriptionOptions{Overflow: "SOMETIMES"}); !errors.Is(err, types.ErrInvalidOverflowPolicy) {
+		t.Errorf("expected ErrInvalidOverflowPolicy, got %v", err)
+	}
+}
+
+func TestSubscribeOverflow(t *testing.T) {
+	const total = 10
+
+	t.Run("drop oldest", func(t *testing.T) {
+		store := &eventStoreMock{}
+		listenerMock := NewListenerMock()
+		driver := NewPostgresDriverFromDBInstance(nil, listenerMock)
+
+		slow, err := driver.Subscribe(context.Background(), &types.SubscriptionOptions{BufferSize: 2, Overflow: types.OverflowDropOldest})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		fast, err := driver.Subscribe(context.Background(), &types.SubscriptionOptions{BufferSize: total})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for sequence := int64(1); sequence <= total; sequence++ {
+			listenerMock.Notify <- store.add(sequence)
+		}
+
+		// the fast subscriber isn't held back by the slow one
+		fastSequences := receiveSequences(t, fast, total)
+		if fastSequences[total-1] != total {
+			t.Errorf("expected every notification, got %v", fastSequences)
+		}
+
+		time.Sleep(100 * time.Millisecond)
+
+		var slowSequences []int64
+	receive:
+		for {
+			select {
+			case n := <-slow:
+				slowSequences = append(slowSequences, n.Sequence)
+			case <-time.After(100 * time.Millisecond):
+				break receive
+			}
+		}
+
+		// besides the buffer the subscription may hold one notification while waiting for the reader
+		count := len(slowSequences)
+		if count < 2 || count > 3 || slowSequences[count-2] != total-1 || slowSequences[count-1] != total {
+			t.Errorf("expected the newest notifications, got %v", slowSequences)
+		}
+	})
+
+	t.Run("disconnect", func(t *testing.T) {
+		store := &eventStoreMock{}
+		listenerMock := NewListenerMock()
+		driver := NewPostgresDriverFromDBInstance(nil, listenerMock)
+
+		slow, err := driver.Subscribe(context.Background(), &types.SubscriptionOptions{BufferSize: 2, Overflow: types.OverflowDisconnect})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for sequence := int64(1); sequence <= total; sequence++ {
+			listenerMock.Notify <- store.add(sequence)
+		}
+
+		time.Sleep(100 * time.Millisecond)
+
+		var last *types.Notification
+		for n := range slow {
+			last = n
+		}
+
+		if last == nil || last.Action != types.ActionResync {
+			t.Errorf("expected a resync notification before disconnecting, got %v", last)
+		}
+	})
+}
+
+func TestSequenceTracker(t *testing.T) {
+	tracker := newSequenceTracker()
+
+	expectWatermark := func(expected int64) {
+		t.Helper()
+
+		if watermark := tracker.watermark(); watermark != expected {
+			t.Fatalf("expected watermark %d, got %d", expected, watermark)
+		}
+	}
+
+	tracker.dispatched(0, 1)
+	tracker.dispatched(1, 2)
+	tracker.dispatched(0, 3)
+	expectWatermark(0)
+
+	tracker.delivered(2)
+	expectWatermark(0)
+
+	// 3 was merged with 1, delivering it releases both
+	tracker.delivered(3)
+	expectWatermark(3)
+
+	tracker.dispatched(1, 4)
+	tracker.dispatched(1, 5)
+	tracker.dispatched(0, 6)
+	expectWatermark(3)
+
+	tracker.dropped(4)
+	expectWatermark(4)
+
+	tracker.delivered(6)
+	expectWatermark(4)
+
+	tracker.delivered(5)
+	expectWatermark(6)
+}
@@ -1,216 +1,624 @@
 package postgresdriver
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/lib/pq"
 	"github.com/pokt-foundation/portal-db/types"
 )
 
+const (
+	eventReadTimeout = 10 * time.Second
+	// eventReadAttempts is how many times an outbox event is read before a resync is delivered in its place
+	eventReadAttempts   = 3
+	eventReadRetryDelay = 50 * time.Millisecond
+)
+
+var (
+	ErrUnknownTable = types.ErrUnknownTable
+
+	errNoEventReader = errors.New("no reader for outbox events")
+)
+
 type Listener interface {
 	NotificationChannel() <-chan *pq.Notification
 	Listen(channel string) error
+	Close() error
 }
 
 type notification struct {
-	Table  types.Table  `json:"table"`
-	Action types.Action `json:"action"`
-	Data   any          `json:"data"`
+	Sequence int64        `json:"sequence,omitempty"`
+	Table    types.Table  `json:"table"`
+	Action   types.Action `json:"action"`
+	Data     any          `json:"data"`
+	Previous any          `json:"previous,omitempty"`
+	payload  string
 }
 
-func (n notification) parseLoadBalancerNotification() *types.Notification {
-	rawData, _ := json.Marshal(n.Data)
-	var dbLoadBalancer dbLoadBalancerJSON
-	_ = json.Unmarshal(rawData, &dbLoadBalancer)
+/* NotificationError is reported when a notification can not be parsed, it carries the raw payload received from the database */
+type NotificationError struct {
+	Payload string
+	Err     error
+}
+
+func (e *NotificationError) Error() string {
+	return fmt.Sprintf("error parsing notification: %s, payload: %s", e.Err, e.Payload)
+}
 
-	return &types.Notification{
-		Table:  n.Table,
-		Action: n.Action,
-		Data:   dbLoadBalancer.toOutput(),
+func (e *NotificationError) Unwrap() error {
+	return e.Err
+}
+
+func decodeData(data any, out any) error {
+	rawData, err := json.Marshal(data)
+	if err != nil {
+		return err
 	}
+
+	return json.Unmarshal(rawData, out)
 }
 
-func (n notification) parseStickinessOptionsNotification() *types.Notification {
-	rawData, _ := json.Marshal(n.Data)
-	var dbStickinessOpts dbStickinessOptionsJSON
-	_ = json.Unmarshal(rawData, &dbStickinessOpts)
+func parseLoadBalancer(data any) (types.SavedOnDB, error) {
+	var dbLoadBalancer dbLoadBalancerJSON
+	if err := decodeData(data, &dbLoadBalancer); err != nil {
+		return nil, err
+	}
+
+	return dbLoadBalancer.toOutput()
+}
 
-	return &types.Notification{
-		Table:  n.Table,
-		Action: n.Action,
-		Data:   dbStickinessOpts.toOutput(),
+func parseStickinessOptions(data any) (types.SavedOnDB, error) {
+	var dbStickinessOpts dbStickinessOptionsJSON
+	if err := decodeData(data, &dbStickinessOpts); err != nil {
+		return nil, err
 	}
+
+	return dbStickinessOpts.toOutput()
 }
 
-func (n notification) parseUserAccessNotification() *types.Notification {
-	rawData, _ := json.Marshal(n.Data)
+func parseUserAccess(data any) (types.SavedOnDB, error) {
 	var dbUserAccess dbUserAccessJSON
-	_ = json.Unmarshal(rawData, &dbUserAccess)
-
-	return &types.Notification{
-		Table:  n.Table,
-		Action: n.Action,
-		Data:   dbUserAccess.toOutput(),
+	if err := decodeData(data, &dbUserAccess); err != nil {
+		return nil, err
 	}
+
+	return dbUserAccess.toOutput()
 }
 
-func (n notification) parseLbApps() *types.Notification {
-	rawData, _ := json.Marshal(n.Data)
+func parseLbApp(data any) (types.SavedOnDB, error) {
 	var lbApp types.LbApp
-	_ = json.Unmarshal(rawData, &lbApp)
-
-	return &types.Notification{
-		Table:  n.Table,
-		Action: n.Action,
-		Data:   &lbApp,
+	if err := decodeData(data, &lbApp); err != nil {
+		return nil, err
 	}
+
+	return &lbApp, nil
 }
 
-func (n notification) parseApplicationNotification() *types.Notification {
-	rawData, _ := json.Marshal(n.Data)
+func parseApplication(data any) (types.SavedOnDB, error) {
 	var dbApp dbAppJSON
-	_ = json.Unmarshal(rawData, &dbApp)
-
-	return &types.Notification{
-		Table:  n.Table,
-		Action: n.Action,
-		Data:   dbApp.toOutput(),
+	if err := decodeData(data, &dbApp); err != nil {
+		return nil, err
 	}
+
+	return dbApp.toOutput()
 }
 
-func (n notification) parseAppLimitNotification() *types.Notification {
-	rawData, _ := json.Marshal(n.Data)
+func parseAppLimit(data any) (types.SavedOnDB, error) {
 	var dbAppLimit dbAppLimitJSON
-	_ = json.Unmarshal(rawData, &dbAppLimit)
-
-	return &types.Notification{
-		Table:  n.Table,
-		Action: n.Action,
-		Data:   dbAppLimit.toOutput(),
+	if err := decodeData(data, &dbAppLimit); err != nil {
+		return nil, err
 	}
+
+	return dbAppLimit.toOutput()
 }
 
-func (n notification) parseGatewayAATNotification() *types.Notification {
-	rawData, _ := json.Marshal(n.Data)
+func parseGatewayAAT(data any) (types.SavedOnDB, error) {
 	var dbGatewayAAT dbGatewayAATJSON
-	_ = json.Unmarshal(rawData, &dbGatewayAAT)
-
-	return &types.Notification{
-		Table:  n.Table,
-		Action: n.Action,
-		Data:   dbGatewayAAT.toOutput(),
+	if err := decodeData(data, &dbGatewayAAT); err != nil {
+		return nil, err
 	}
+
+	return dbGatewayAAT.toOutput()
 }
 
-func (n notification) parseGatewaySettingsNotification() *types.Notification {
-	rawData, _ := json.Marshal(n.Data)
+func parseGatewaySettings(data any) (types.SavedOnDB, error) {
 	var dbGatewaySettings dbGatewaySettingsJSON
-	_ = json.Unmarshal(rawData, &dbGatewaySettings)
-
-	return &types.Notification{
-		Table:  n.Table,
-		Action: n.Action,
-		Data:   dbGatewaySettings.toOutput(),
+	if err := decodeData(data, &dbGatewaySettings); err != nil {
+		return nil, err
 	}
+
+	return dbGatewaySettings.toOutput()
 }
 
-func (n notification) parseNotificationSettingsNotification() *types.Notification {
-	rawData, _ := json.Marshal(n.Data)
+func parseNotificationSettings(data any) (types.SavedOnDB, error) {
 	var dbNotificationSettings dbNotificationSettingsJSON
-	_ = json.Unmarshal(rawData, &dbNotificationSettings)
-
-	return &types.Notification{
-		Table:  n.Table,
-		Action: n.Action,
-		Data:   dbNotificationSettings.toOutput(),
+	if err := decodeData(data, &dbNotificationSettings); err != nil {
+		return nil, err
 	}
+
+	return dbNotificationSettings.toOutput()
 }
 
-func (n notification) parseBlockchainNotification() *types.Notification {
-	rawData, _ := json.Marshal(n.Data)
+func parseBlockchain(data any) (types.SavedOnDB, error) {
 	var dbBlockchain dbBlockchainJSON
-	_ = json.Unmarshal(rawData, &dbBlockchain)
-
-	return &types.Notification{
-		Table:  n.Table,
-		Action: n.Action,
-		Data:   dbBlockchain.toOutput(),
+	if err := decodeData(data, &dbBlockchain); err != nil {
+		return nil, err
 	}
+
+	return dbBlockchain.toOutput()
 }
 
-func (n notification) parseRedirectNotification() *types.Notification {
-	rawData, _ := json.Marshal(n.Data)
+func parseRedirect(data any) (types.SavedOnDB, error) {
 	var dbRedirect dbRedirectJSON
-	_ = json.Unmarshal(rawData, &dbRedirect)
-
-	return &types.Notification{
-		Table:  n.Table,
-		Action: n.Action,
-		Data:   dbRedirect.toOutput(),
+	if err := decodeData(data, &dbRedirect); err != nil {
+		return nil, err
 	}
+
+	return dbRedirect.toOutput()
 }
 
-func (n notification) parseSyncOptionsNotification() *types.Notification {
-	rawData, _ := json.Marshal(n.Data)
+func parseSyncOptions(data any) (types.SavedOnDB, error) {
 	var dbSyncOpts dbSyncCheckOptionsJSON
-	_ = json.Unmarshal(rawData, &dbSyncOpts)
-
-	return &types.Notification{
-		Table:  n.Table,
-		Action: n.Action,
-		Data:   dbSyncOpts.toOutput(),
+	if err := decodeData(data, &dbSyncOpts); err != nil {
+		return nil, err
 	}
+
+	return dbSyncOpts.toOutput()
 }
 
-func (n notification) parseNotification() *types.Notification {
-	switch n.Table {
+func tableParser(table types.Table) (func(data any) (types.SavedOnDB, error), error) {
+	switch table {
 	case types.TableLoadBalancers:
-		return n.parseLoadBalancerNotification()
+		return parseLoadBalancer, nil
 	case types.TableStickinessOptions:
-		return n.parseStickinessOptionsNotification()
+		return parseStickinessOptions, nil
 	case types.TableUserAccess:
-		return n.parseUserAccessNotification()
+		return parseUserAccess, nil
 
 	case types.TableLbApps:
-		return n.parseLbApps()
+		return parseLbApp, nil
 
 	case types.TableApplications:
-		return n.parseApplicationNotification()
+		return parseApplication, nil
 	case types.TableAppLimits:
-		return n.parseAppLimitNotification()
+		return parseAppLimit, nil
 	case types.TableGatewayAAT:
-		return n.parseGatewayAATNotification()
+		return parseGatewayAAT, nil
 	case types.TableGatewaySettings:
-		return n.parseGatewaySettingsNotification()
+		return parseGatewaySettings, nil
 	case types.TableNotificationSettings:
-		return n.parseNotificationSettingsNotification()
+		return parseNotificationSettings, nil
 
 	case types.TableBlockchains:
-		return n.parseBlockchainNotification()
+		return parseBlockchain, nil
 	case types.TableRedirects:
-		return n.parseRedirectNotification()
+		return parseRedirect, nil
 	case types.TableSyncCheckOptions:
-		return n.parseSyncOptionsNotification()
+		return parseSyncOptions, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnknownTable, table)
+}
+
+func (n notification) parseNotification() (*types.Notification, error) {
+	if n.Action == types.ActionResync {
+		return &types.Notification{Action: types.ActionResync}, nil
+	}
+
+	parse, err := tableParser(n.Table)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := parse(n.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := &types.Notification{
+		Sequence: n.Sequence,
+		Table:    n.Table,
+		Action:   n.Action,
+		Data:     data,
+	}
+
+	if n.Previous != nil {
+		parsed.Previous, err = parse(n.Previous)
+		if err != nil {
+			return nil, err
+		}
+
+		parsed.ChangedFields = changedFields(n.Previous, n.Data)
+	}
+
+	return parsed, nil
+}
+
+/* changedFields returns the sorted names of the columns whose value differs between both rows */
+func changedFields(previous, current any) []string {
+	previousRow, _ := previous.(map[string]any)
+	currentRow, _ := current.(map[string]any)
+
+	var fields []string
+
+	for field, value := range currentRow {
+		if previousValue, ok := previousRow[field]; !ok || !reflect.DeepEqual(previousValue, value) {
+			fields = append(fields, field)
+		}
+	}
+	for field := range previousRow {
+		if _, ok := currentRow[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+
+	sort.Strings(fields)
+
+	return fields
+}
+
+func parsePQNotification(n *pq.Notification) (notification, error) {
+	var notification notification
+
+	// lib/pq sends a nil notification after reconnecting, anything sent meanwhile was lost
+	if n == nil {
+		notification.Action = types.ActionResync
+		return notification, nil
+	}
+
+	err := json.Unmarshal([]byte(n.Extra), &notification)
+	notification.payload = n.Extra
+
+	return notification, err
+}
+
+/* isOutboxReference returns true when the notification only holds the sequence of an event saved in the outbox */
+func (n notification) isOutboxReference() bool {
+	return n.Table == "" && n.Sequence != 0
+}
+
+func (e Event) toNotification() (notification, error) {
+	n := notification{
+		Sequence: e.Sequence,
+		Table:    types.Table(e.TableName),
+		Action:   types.Action(e.Action),
+		payload:  string(e.Data),
+	}
+
+	if err := json.Unmarshal(e.Data, &n.Data); err != nil {
+		return n, err
+	}
+	if len(e.Previous) != 0 {
+		if err := json.Unmarshal(e.Previous, &n.Previous); err != nil {
+			return n, err
+		}
 	}
 
-	return nil
+	return n, nil
 }
 
-func parsePQNotification(n *pq.Notification, outCh chan *types.Notification) {
-	if n != nil {
-		var notification notification
-		_ = json.Unmarshal([]byte(n.Extra), &notification)
-		outCh <- notification.parseNotification()
+/* readEvent reads the event with the given sequence from the outbox */
+func (d *PostgresDriver) readEvent(sequence int64) (notification, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), eventReadTimeout)
+	defer cancel()
+
+	event, err := d.SelectEvent(ctx, sequence)
+	if err != nil {
+		return notification{}, err
+	}
+
+	return event.toNotification()
+}
+
+/* fetchNotification reads the event of outbox references, retrying failed reads unless the event does not exist */
+func (c listenConfig) fetchNotification(n notification) (notification, error) {
+	if !n.isOutboxReference() {
+		return n, nil
+	}
+	if c.readEvent == nil {
+		return n, errNoEventReader
+	}
+
+	for attempt := 1; ; attempt++ {
+		event, err := c.readEvent(n.Sequence)
+		if err == nil || errors.Is(err, sql.ErrNoRows) || attempt == eventReadAttempts {
+			return event, err
+		}
+
+		select {
+		case <-time.After(eventReadRetryDelay):
+		case <-c.abort:
+			return n, err
+		}
 	}
 }
 
+func deliverNotifications(inCh <-chan notification, outCh chan *types.Notification, config listenConfig) {
+	parse := func(n notification) *types.Notification {
+		parsed, err := n.parseNotification()
+		if err != nil {
+			config.reportError(&NotificationError{Payload: n.payload, Err: err})
+			config.sequences.dropped(n.Sequence)
+		}
+		return parsed
+	}
+
+	var next *types.Notification
+	open := true
+
+	for open || next != nil {
+		group := []*types.Notification{next}
+		next = nil
+
+		if group[0] == nil {
+			n, ok := <-inCh
+			if !ok {
+				return
+			}
+			if group[0] = parse(n); group[0] == nil {
+				continue
+			}
+		}
+
+		// Notifications of the same aggregate already waiting are merged with this one
+	collect:
+		for config.aggregator != nil {
+			select {
+			case n, ok := <-inCh:
+				if !ok {
+					open = false
+					break collect
+				}
+
+				parsed := parse(n)
+				if parsed == nil {
+					continue
+				}
+				if !sameAggregate(group[0], parsed) {
+					next = parsed
+					break collect
+				}
+
+				group = append(group, parsed)
+			default:
+				break collect
+			}
+		}
+
+		delivered := group[0]
+		if config.aggregator != nil {
+			var err error
+			delivered, err = config.aggregateGroup(group)
+
+			// The entity could not be read so consumers are told to resync instead of missing its changes
+			if err != nil {
+				config.reportError(err)
+				for _, n := range group {
+					config.sequences.dropped(n.Sequence)
+				}
+				delivered = &types.Notification{Action: types.ActionResync}
+			}
+		}
+
+		select {
+		case outCh <- delivered:
+		case <-config.abort:
+			return
+		}
+	}
+}
+
+/* entityKey returns the ID of the entity the notification belongs to, side tables share the key of their parent */
+func (n notification) entityKey() string {
+	data, ok := n.Data.(map[string]any)
+	if !ok {
+		return ""
+	}
+
+	for _, field := range []string{"application_id", "lb_id", "blockchain_id"} {
+		if key, ok := data[field].(string); ok {
+			return key
+		}
+	}
+
+	return ""
+}
+
+func (n notification) partition(workers int) int {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(n.entityKey()))
+
+	return int(hash.Sum32() % uint32(workers))
+}
+
+/* Listen parses incoming notifications one at a time and delivers them in the order they were received */
 func Listen(inCh <-chan *pq.Notification, outCh chan *types.Notification) {
+	ListenWithWorkers(inCh, outCh, 1)
+}
+
+// ListenWithWorkers parses incoming notifications using a bounded pool of workers.
+// Notifications for the same entity are always handled by the same worker so their order is
+// kept, while notifications for different entities may be delivered out of order
+func ListenWithWorkers(inCh <-chan *pq.Notification, outCh chan *types.Notification, workers int) {
+	listen(inCh, outCh, listenConfig{workers: workers})
+}
+
+type listenConfig struct {
+	workers int
+	stop    <-chan struct{}
+	abort   <-chan struct{}
+	onError func(error)
+	// readEvent reads events from the outbox when notifications only carry their sequence
+	readEvent func(sequence int64) (notification, error)
+	// aggregator is set when notifications are merged into their Application or LoadBalancer
+	aggregator *aggregator
+	sequences  *sequenceTracker
+}
+
+func (c listenConfig) aggregateGroup(group []*types.Notification) (*types.Notification, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), eventReadTimeout)
+	defer cancel()
+
+	return c.aggregator.aggregate(ctx, group)
+}
+
+func (c listenConfig) reportError(err error) {
+	if c.onError != nil {
+		c.onError(err)
+	}
+}
+
+/* listen runs until inCh is closed or stop is closed, waiting for the workers to deliver what they hold unless abort is closed */
+func listen(inCh <-chan *pq.Notification, outCh chan *types.Notification, config listenConfig) {
+	workers := config.workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	queues := make([]chan notification, workers)
+	var wg sync.WaitGroup
+
+	for i := range queues {
+		queues[i] = make(chan notification, 32)
+		wg.Add(1)
+
+		go func(queue <-chan notification) {
+			defer wg.Done()
+			deliverNotifications(queue, outCh, config)
+		}(queues[i])
+	}
+
+dispatch:
 	for {
-		n := <-inCh
-		go parsePQNotification(n, outCh)
+		select {
+		case <-config.stop:
+			break dispatch
+		case n, ok := <-inCh:
+			if !ok {
+				break dispatch
+			}
+
+			received, err := parsePQNotification(n)
+			if err != nil {
+				config.reportError(&NotificationError{Payload: n.Extra, Err: err})
+				continue
+			}
+
+			// An event that can't be read is replaced by a resync, subscriptions replay it and caches reload
+			received, err = config.fetchNotification(received)
+			if err != nil {
+				config.reportError(&NotificationError{Payload: n.Extra, Err: err})
+				received = notification{Action: types.ActionResync}
+			}
+
+			worker := received.partition(workers)
+			config.sequences.dispatched(worker, received.Sequence)
+
+			select {
+			case queues[worker] <- received:
+			case <-config.abort:
+				break dispatch
+			}
+		}
+	}
+
+	for _, queue := range queues {
+		close(queue)
+	}
+	wg.Wait()
+}
+
+func (d *PostgresDriver) startListening() {
+	d.stop = make(chan struct{})
+	d.abort = make(chan struct{})
+	d.listening = make(chan struct{})
+
+	d.sequences = newSequenceTracker()
+	parsed := make(chan *types.Notification, 32)
+
+	go func() {
+		listen(d.listener.NotificationChannel(), parsed, listenConfig{
+			workers:    d.workers,
+			stop:       d.stop,
+			abort:      d.abort,
+			onError:    d.errorHandler,
+			readEvent:  d.readEvent,
+			aggregator: d.aggregator,
+			sequences:  d.sequences,
+		})
+		close(parsed)
+	}()
+
+	delivered := parsed
+	if d.coalescingWindow > 0 {
+		coalesced := make(chan *types.Notification, 32)
+		go d.coalesce(parsed, coalesced)
+		delivered = coalesced
+	}
+
+	go func() {
+		d.broadcast(delivered)
+		close(d.listening)
+	}()
+
+	if d.eventRetention > 0 {
+		go d.pruneEvents(d.stop)
+	}
+}
+
+/* stopListening stops the listen loop and waits for in-flight notifications to be delivered or for ctx to be done */
+func (d *PostgresDriver) stopListening(ctx context.Context) error {
+	d.stopOnce.Do(func() {
+		close(d.stop)
+	})
+
+	select {
+	case <-d.listening:
+		return nil
+	case <-ctx.Done():
+		d.abortOnce.Do(func() {
+			close(d.abort)
+		})
+		<-d.listening
+
+		return ctx.Err()
 	}
 }
 
+/* CloseListener stops the listen loop and closes the Notification channel once in-flight notifications are delivered */
 func (d *PostgresDriver) CloseListener() {
-	close(d.notification)
+	_ = d.stopListening(context.Background())
+}
+
+// Close stops the listen loop, waits for in-flight notifications to be delivered and closes the listener and the database pools.
+// If ctx is done before the notifications are consumed they are dropped and ctx error is returned
+func (d *PostgresDriver) Close(ctx context.Context) error {
+	d.closeOnce.Do(func() {
+		d.closeErr = d.stopListening(ctx)
+
+		if err := d.listener.Close(); err != nil && d.closeErr == nil {
+			d.closeErr = err
+		}
+
+		if d.db != nil {
+			if err := d.db.Close(); err != nil && d.closeErr == nil {
+				d.closeErr = err
+			}
+		}
+
+		if err := d.closeReplicas(); err != nil && d.closeErr == nil {
+			d.closeErr = err
+		}
+	})
+
+	return d.closeErr
 }
@@ -2,13 +2,15 @@ package postgresdriver
 
 import (
 	"encoding/json"
+	"sync"
 
 	"github.com/lib/pq"
 	"github.com/pokt-foundation/portal-db/types"
 )
 
 type ListenerMock struct {
-	Notify chan *pq.Notification
+	Notify    chan *pq.Notification
+	closeOnce sync.Once
 }
 
 func NewListenerMock() *ListenerMock {
@@ -25,6 +27,14 @@ func (l *ListenerMock) Listen(channel string) error {
 	return nil
 }
 
+func (l *ListenerMock) Close() error {
+	l.closeOnce.Do(func() {
+		close(l.Notify)
+	})
+
+	return nil
+}
+
 func gatewaySettingsIsNull(settings types.GatewaySettings) bool {
 	return settings.SecretKey == "" &&
 		len(settings.WhitelistOrigins) == 0 &&
@@ -266,6 +276,32 @@ func redirectInput(action types.Action, content types.SavedOnDB) inputStruct {
 	}
 }
 
+func userAccessInput(action types.Action, content types.SavedOnDB) inputStruct {
+	userAccess := content.(*types.UserAccess)
+
+	return inputStruct{
+		action: action,
+		table:  types.TableUserAccess,
+		input: dbUserAccessJSON{
+			LbID:     userAccess.ID,
+			UserID:   userAccess.UserID,
+			RoleName: string(userAccess.RoleName),
+			Email:    userAccess.Email,
+			Accepted: userAccess.Accepted,
+		},
+	}
+}
+
+func lbAppInput(action types.Action, content types.SavedOnDB) inputStruct {
+	lbApp := content.(*types.LbApp)
+
+	return inputStruct{
+		action: action,
+		table:  types.TableLbApps,
+		input:  *lbApp,
+	}
+}
+
 type inputStruct struct {
 	action types.Action
 	table  types.Table
@@ -298,6 +334,10 @@ func mockContent(mainTableAction, sideTablesAction types.Action, content types.S
 		inputs = loadBalancerInputs(mainTableAction, sideTablesAction, content)
 	case *types.Redirect:
 		inputs = []inputStruct{redirectInput(mainTableAction, content)}
+	case *types.UserAccess:
+		inputs = []inputStruct{userAccessInput(mainTableAction, content)}
+	case *types.LbApp:
+		inputs = []inputStruct{lbAppInput(mainTableAction, content)}
 	default:
 		panic("type not supported")
 	}
@@ -1,10 +1,17 @@
 package postgresdriver
 
 import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/lib/pq"
 	"github.com/pokt-foundation/portal-db/types"
 )
 
@@ -207,3 +214,483 @@ func TestListen(t *testing.T) {
 		})
 	}
 }
+
+func TestListenDelete(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		content               types.SavedOnDB
+		expectedNotifications map[types.Table]*types.Notification
+	}{
+		{
+			name: "application",
+			content: &types.Application{
+				ID: "321",
+				Limit: types.AppLimit{
+					PayPlan: types.PayPlan{Type: types.FreetierV0},
+				},
+				NotificationSettings: types.NotificationSettings{
+					Half: true,
+				},
+			},
+			expectedNotifications: map[types.Table]*types.Notification{
+				types.TableApplications: {
+					Table:  types.TableApplications,
+					Action: types.ActionDelete,
+					Data: &types.Application{
+						ID: "321",
+					},
+				},
+				types.TableAppLimits: {
+					Table:  types.TableAppLimits,
+					Action: types.ActionDelete,
+					Data: &types.AppLimit{
+						ID:      "321",
+						PayPlan: types.PayPlan{Type: types.FreetierV0},
+					},
+				},
+				types.TableNotificationSettings: {
+					Table:  types.TableNotificationSettings,
+					Action: types.ActionDelete,
+					Data: &types.NotificationSettings{
+						ID:   "321",
+						Half: true,
+					},
+				},
+			},
+		},
+		{
+			name: "blockchain",
+			content: &types.Blockchain{
+				ID: "0021",
+			},
+			expectedNotifications: map[types.Table]*types.Notification{
+				types.TableBlockchains: {
+					Table:  types.TableBlockchains,
+					Action: types.ActionDelete,
+					Data: &types.Blockchain{
+						ID: "0021",
+					},
+				},
+			},
+		},
+		{
+			name: "load balancer",
+			content: &types.LoadBalancer{
+				ID: "123",
+			},
+			expectedNotifications: map[types.Table]*types.Notification{
+				types.TableLoadBalancers: {
+					Table:  types.TableLoadBalancers,
+					Action: types.ActionDelete,
+					Data: &types.LoadBalancer{
+						ID: "123",
+					},
+				},
+			},
+		},
+		{
+			name: "user access",
+			content: &types.UserAccess{
+				ID:       "123",
+				UserID:   "test_user_member1234",
+				RoleName: types.RoleMember,
+				Email:    "member1@test.com",
+			},
+			expectedNotifications: map[types.Table]*types.Notification{
+				types.TableUserAccess: {
+					Table:  types.TableUserAccess,
+					Action: types.ActionDelete,
+					Data: &types.UserAccess{
+						ID:       "123",
+						UserID:   "test_user_member1234",
+						RoleName: types.RoleMember,
+						Email:    "member1@test.com",
+					},
+				},
+			},
+		},
+		{
+			name: "lb app",
+			content: &types.LbApp{
+				LbID:  "123",
+				AppID: "a123",
+			},
+			expectedNotifications: map[types.Table]*types.Notification{
+				types.TableLbApps: {
+					Table:  types.TableLbApps,
+					Action: types.ActionDelete,
+					Data: &types.LbApp{
+						LbID:  "123",
+						AppID: "a123",
+					},
+				},
+			},
+		},
+		{
+			name: "redirect",
+			content: &types.Redirect{
+				BlockchainID: "0021",
+				Alias:        "test-mainnet",
+			},
+			expectedNotifications: map[types.Table]*types.Notification{
+				types.TableRedirects: {
+					Table:  types.TableRedirects,
+					Action: types.ActionDelete,
+					Data: &types.Redirect{
+						BlockchainID: "0021",
+						Alias:        "test-mainnet",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			listenerMock := NewListenerMock()
+			driver := NewPostgresDriverFromDBInstance(nil, listenerMock)
+
+			listenerMock.MockEvent(types.ActionDelete, types.ActionDelete, tc.content)
+
+			time.Sleep(1 * time.Second)
+			driver.CloseListener()
+
+			nMap := make(map[types.Table]*types.Notification)
+
+			for n := range driver.NotificationChannel() {
+				nMap[n.Table] = n
+			}
+
+			if diff := cmp.Diff(tc.expectedNotifications, nMap); diff != "" {
+				t.Errorf("unexpected value (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestListenErrors(t *testing.T) {
+	testCases := []struct {
+		name        string
+		payload     string
+		expectedErr error
+	}{
+		{
+			name:        "unknown table",
+			payload:     `{"table":"user_roles","action":"DELETE","data":{"name":"ADMIN"}}`,
+			expectedErr: types.ErrUnknownTable,
+		},
+		{
+			name:    "malformed payload",
+			payload: `{"table":"applications",`,
+		},
+		{
+			name:    "malformed data",
+			payload: `{"table":"app_limits","action":"UPDATE","data":{"application_id":"321","custom_limit":"lots"}}`,
+		},
+		{
+			name:    "malformed date",
+			payload: `{"table":"applications","action":"INSERT","data":{"application_id":"321","created_at":"yesterday"}}`,
+		},
+		{
+			name:    "malformed whitelist",
+			payload: `{"table":"gateway_settings","action":"UPDATE","data":{"application_id":"321","whitelist_contracts":"[{"}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			errCh := make(chan error, 1)
+
+			listenerMock := NewListenerMock()
+			driver := NewPostgresDriverFromDBInstance(nil, listenerMock, WithErrorHandler(func(err error) {
+				errCh <- err
+			}))
+
+			listenerMock.Notify <- &pq.Notification{Extra: tc.payload}
+			listenerMock.MockEvent(types.ActionInsert, types.ActionInsert, &types.LbApp{LbID: "123", AppID: "a123"})
+
+			if n := <-driver.NotificationChannel(); n.Table != types.TableLbApps {
+				t.Errorf("expected the next valid notification, got %v", n)
+			}
+
+			err := <-errCh
+
+			var notificationErr *NotificationError
+			if !errors.As(err, &notificationErr) {
+				t.Fatalf("expected NotificationError, got %v", err)
+			}
+			if notificationErr.Payload != tc.payload {
+				t.Errorf("expected payload %s, got %s", tc.payload, notificationErr.Payload)
+			}
+			if tc.expectedErr != nil && !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestListenOrder(t *testing.T) {
+	const total = 5000
+
+	listenerMock := NewListenerMock()
+	driver := NewPostgresDriverFromDBInstance(nil, listenerMock)
+
+	go func() {
+		for i := 0; i < total; i++ {
+			listenerMock.MockEvent(types.ActionInsert, types.ActionInsert, &types.LbApp{
+				LbID:  fmt.Sprintf("lb_%d", i%10),
+				AppID: fmt.Sprint(i),
+			})
+		}
+	}()
+
+	for i := 0; i < total; i++ {
+		n := <-driver.NotificationChannel()
+		if got := n.Data.(*types.LbApp).AppID; got != fmt.Sprint(i) {
+			t.Fatalf("notification %d delivered out of order, got %s", i, got)
+		}
+	}
+}
+
+func TestListenWithWorkersOrder(t *testing.T) {
+	const (
+		total    = 5000
+		entities = 10
+	)
+
+	listenerMock := NewListenerMock()
+	driver := NewPostgresDriverFromDBInstance(nil, listenerMock, WithListenerWorkers(4))
+
+	go func() {
+		for i := 0; i < total; i++ {
+			listenerMock.MockEvent(types.ActionInsert, types.ActionInsert, &types.LbApp{
+				LbID:  fmt.Sprintf("lb_%d", i%entities),
+				AppID: fmt.Sprint(i),
+			})
+		}
+	}()
+
+	lastSeen := make(map[string]int)
+
+	for i := 0; i < total; i++ {
+		lbApp := (<-driver.NotificationChannel()).Data.(*types.LbApp)
+
+		seq, _ := strconv.Atoi(lbApp.AppID)
+
+		if last, ok := lastSeen[lbApp.LbID]; ok && seq <= last {
+			t.Fatalf("notification %d for %s delivered after %d", seq, lbApp.LbID, last)
+		}
+		lastSeen[lbApp.LbID] = seq
+	}
+
+	if len(lastSeen) != entities {
+		t.Errorf("expected notifications for %d entities, got %d", entities, len(lastSeen))
+	}
+}
+
+func TestListenResync(t *testing.T) {
+	listenerMock := NewListenerMock()
+	driver := NewPostgresDriverFromDBInstance(nil, listenerMock)
+
+	listenerMock.MockEvent(types.ActionInsert, types.ActionInsert, &types.LbApp{LbID: "123", AppID: "1"})
+	listenerMock.Notify <- nil
+	listenerMock.MockEvent(types.ActionInsert, types.ActionInsert, &types.LbApp{LbID: "123", AppID: "2"})
+
+	expectedActions := []types.Action{types.ActionInsert, types.ActionResync, types.ActionInsert}
+
+	for _, expectedAction := range expectedActions {
+		if n := <-driver.NotificationChannel(); n.Action != expectedAction {
+			t.Errorf("expected action %s, got %s", expectedAction, n.Action)
+		}
+	}
+}
+
+func TestClose(t *testing.T) {
+	t.Run("delivers in-flight notifications", func(t *testing.T) {
+		listenerMock := NewListenerMock()
+		driver := NewPostgresDriverFromDBInstance(nil, listenerMock)
+
+		listenerMock.MockEvent(types.ActionInsert, types.ActionInsert, &types.LbApp{LbID: "123", AppID: "1"})
+		time.Sleep(100 * time.Millisecond)
+
+		if err := driver.Close(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var received int
+		for range driver.NotificationChannel() {
+			received++
+		}
+
+		if received != 1 {
+			t.Errorf("expected 1 notification, got %d", received)
+		}
+
+		if _, open := <-listenerMock.Notify; open {
+			t.Error("expected listener to be closed")
+		}
+
+		if err := driver.Close(context.Background()); err != nil {
+			t.Errorf("unexpected error on second close: %v", err)
+		}
+	})
+
+	t.Run("drops notifications nobody reads once ctx is done", func(t *testing.T) {
+		listenerMock := NewListenerMock()
+		driver := NewPostgresDriverFromDBInstance(nil, listenerMock)
+		// taking the channel makes the driver wait for it to be read
+		notifications := driver.NotificationChannel()
+
+		// more than the Notification channel can buffer but less than the whole pipeline holds
+		for i := 0; i < 64; i++ {
+			listenerMock.MockEvent(types.ActionInsert, types.ActionInsert, &types.LbApp{LbID: "123", AppID: fmt.Sprint(i)})
+		}
+		time.Sleep(100 * time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		if err := driver.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected deadline exceeded, got %v", err)
+		}
+
+		for range notifications {
+		}
+	})
+}
+
+func TestListenOutbox(t *testing.T) {
+	contracts := make([]types.WhitelistContract, 200)
+	for i := range contracts {
+		contracts[i] = types.WhitelistContract{
+			BlockchainID: "0021",
+			Contracts:    []string{fmt.Sprintf("0x%040d", i)},
+		}
+	}
+	rawContracts, _ := marshalWhitelistContractsAndMethods(contracts, nil)
+
+	rawData, _ := json.Marshal(dbGatewaySettingsJSON{
+		ApplicationID:      "321",
+		WhitelistContracts: rawContracts,
+	})
+	if len(rawData) <= 8000 {
+		t.Fatalf("expected payload over the pg_notify limit, got %d bytes", len(rawData))
+	}
+
+	events := map[int64]Event{
+		7: {Sequence: 7, TableName: string(types.TableGatewaySettings), Action: string(types.ActionUpdate), Data: rawData},
+	}
+	// Reading event 7 fails once and is retried
+	failures := map[int64]int{7: 1}
+	readEvent := func(sequence int64) (notification, error) {
+		if failures[sequence] > 0 {
+			failures[sequence]--
+			return notification{}, errors.New("connection reset")
+		}
+
+		event, ok := events[sequence]
+		if !ok {
+			return notification{}, sql.ErrNoRows
+		}
+
+		return event.toNotification()
+	}
+
+	inCh := make(chan *pq.Notification, 2)
+	outCh := make(chan *types.Notification, 2)
+	var errs []error
+
+	inCh <- &pq.Notification{Extra: `{"sequence":8}`}
+	inCh <- &pq.Notification{Extra: `{"sequence":7}`}
+	close(inCh)
+
+	listen(inCh, outCh, listenConfig{
+		workers:   1,
+		readEvent: readEvent,
+		onError: func(err error) {
+			errs = append(errs, err)
+		},
+	})
+	close(outCh)
+
+	var notifications []*types.Notification
+	for n := range outCh {
+		notifications = append(notifications, n)
+	}
+
+	// The missing event is replaced by a resync
+	expectedNotifications := []*types.Notification{
+		{Action: types.ActionResync},
+		{
+			Sequence: 7,
+			Table:    types.TableGatewaySettings,
+			Action:   types.ActionUpdate,
+			Data: &types.GatewaySettings{
+				ID:                 "321",
+				WhitelistContracts: contracts,
+				WhitelistMethods:   []types.WhitelistMethod{},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedNotifications, notifications); diff != "" {
+		t.Errorf("unexpected value (-want +got):\n%s", diff)
+	}
+
+	if len(errs) != 1 || !errors.Is(errs[0], sql.ErrNoRows) {
+		t.Errorf("expected a single missing event error, got %v", errs)
+	}
+}
+
+func TestListenUpdateDiff(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		payload              string
+		expectedNotification *types.Notification
+	}{
+		{
+			name: "status transition",
+			payload: `{"table":"applications","action":"UPDATE",` +
+				`"data":{"application_id":"321","name":"app","status":"AWAITING_GRACE_PERIOD","updated_at":"2022-11-02T00:00:00"},` +
+				`"previous":{"application_id":"321","name":"app","status":"IN_SERVICE","updated_at":"2022-11-01T00:00:00"}}`,
+			expectedNotification: &types.Notification{
+				Table:  types.TableApplications,
+				Action: types.ActionUpdate,
+				Data: &types.Application{
+					ID:        "321",
+					Name:      "app",
+					Status:    types.AwaitingGracePeriod,
+					UpdatedAt: time.Date(2022, 11, 2, 0, 0, 0, 0, time.UTC),
+				},
+				Previous: &types.Application{
+					ID:        "321",
+					Name:      "app",
+					Status:    types.InService,
+					UpdatedAt: time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC),
+				},
+				ChangedFields: []string{"status", "updated_at"},
+			},
+		},
+		{
+			name:    "insert without previous row",
+			payload: `{"table":"lb_apps","action":"INSERT","data":{"lb_id":"123","app_id":"321"}}`,
+			expectedNotification: &types.Notification{
+				Table:  types.TableLbApps,
+				Action: types.ActionInsert,
+				Data:   &types.LbApp{LbID: "123", AppID: "321"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			listenerMock := NewListenerMock()
+			driver := NewPostgresDriverFromDBInstance(nil, listenerMock)
+
+			listenerMock.Notify <- &pq.Notification{Extra: tc.payload}
+
+			if diff := cmp.Diff(tc.expectedNotification, <-driver.NotificationChannel()); diff != "" {
+				t.Errorf("unexpected value (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
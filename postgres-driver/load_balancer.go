@@ -2,25 +2,29 @@ package postgresdriver
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/pokt-foundation/portal-db/driver"
 	"github.com/pokt-foundation/portal-db/types"
 )
 
 var (
 	ErrInvalidUsersJSON        = errors.New("error: users JSON is invalid")
-	ErrUserInputIsMissingField = errors.New("error: user access input is missing a required field")
-	ErrLBMustHaveUser          = errors.New("error: a new load balancer must have at least one user")
-	ErrCannotSetToOwner        = errors.New("error: load balancers may only have one owner and the owner role is already set")
+	ErrUserInputIsMissingField = driver.ErrUserInputIsMissingField
+	ErrLBMustHaveUser          = driver.ErrLBMustHaveUser
+	ErrCannotSetToOwner        = driver.ErrCannotSetToOwner
 )
 
 /* ReadLoadBalancers returns all LoadBalancers in the database */
 func (p *PostgresDriver) ReadLoadBalancers(ctx context.Context) ([]*types.LoadBalancer, error) {
-	dbLoadBalancers, err := p.SelectLoadBalancers(ctx)
+	dbLoadBalancers, err := read(ctx, p, func(q *Queries) ([]SelectLoadBalancersRow, error) {
+		return q.SelectLoadBalancers(ctx)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -38,6 +42,78 @@ func (p *PostgresDriver) ReadLoadBalancers(ctx context.Context) ([]*types.LoadBa
 	return loadbalancers, nil
 }
 
+/* ReadLoadBalancersPage returns one page of the LoadBalancers matching the query options, ordered by ID */
+func (p *PostgresDriver) ReadLoadBalancersPage(ctx context.Context, options *types.QueryOptions) (*types.LoadBalancersPage, error) {
+	if options == nil {
+		options = &types.QueryOptions{}
+	}
+
+	err := options.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	limit := options.PageLimit()
+
+	// One extra row is requested to know whether there is a next page
+	dbLoadBalancers, err := read(ctx, p, func(q *Queries) ([]SelectLoadBalancersPageRow, error) {
+		return q.SelectLoadBalancersPage(ctx, extractSelectLoadBalancersPage(options, limit+1))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	page := &types.LoadBalancersPage{LoadBalancers: []*types.LoadBalancer{}}
+	for i, dbLoadBalancer := range dbLoadBalancers {
+		if i == limit {
+			page.NextCursor = page.LoadBalancers[limit-1].ID
+			break
+		}
+
+		row := SelectLoadBalancersRow(dbLoadBalancer)
+		loadBalancer, err := row.toLoadBalancer()
+		if err != nil {
+			return nil, err
+		}
+
+		page.LoadBalancers = append(page.LoadBalancers, loadBalancer)
+	}
+
+	return page, nil
+}
+
+func extractSelectLoadBalancersPage(options *types.QueryOptions, maxResults int) SelectLoadBalancersPageParams {
+	return SelectLoadBalancersPageParams{
+		Cursor:       options.Cursor,
+		UserID:       newSQLNullString(options.UserID),
+		CreatedAfter: newSQLNullTime(options.CreatedAfter),
+		MaxResults:   int32(maxResults),
+	}
+}
+
+/* ReadLoadBalancersUpdatedSince returns all LoadBalancers whose row or child table rows changed after the given time */
+func (p *PostgresDriver) ReadLoadBalancersUpdatedSince(ctx context.Context, since time.Time) ([]*types.LoadBalancer, error) {
+	dbLoadBalancers, err := read(ctx, p, func(q *Queries) ([]SelectLoadBalancersUpdatedSinceRow, error) {
+		return q.SelectLoadBalancersUpdatedSince(ctx, newSQLNullTime(since))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var loadbalancers []*types.LoadBalancer
+	for _, dbLoadBalancer := range dbLoadBalancers {
+		row := SelectLoadBalancersRow(dbLoadBalancer)
+		loadBalancer, err := row.toLoadBalancer()
+		if err != nil {
+			return nil, err
+		}
+
+		loadbalancers = append(loadbalancers, loadBalancer)
+	}
+
+	return loadbalancers, nil
+}
+
 func (lb *SelectLoadBalancersRow) toLoadBalancer() (*types.LoadBalancer, error) {
 	loadBalancer := types.LoadBalancer{
 		ID:                lb.LbID,
@@ -68,13 +144,82 @@ func (lb *SelectLoadBalancersRow) toLoadBalancer() (*types.LoadBalancer, error)
 	return &loadBalancer, nil
 }
 
+/* ReadLoadBalancer returns a single LoadBalancer by its ID */
+func (p *PostgresDriver) ReadLoadBalancer(ctx context.Context, id string) (*types.LoadBalancer, error) {
+	if id == "" {
+		return nil, ErrMissingID
+	}
+
+	dbLoadBalancer, err := read(ctx, p, func(q *Queries) (SelectOneLoadBalancerRow, error) {
+		return q.SelectOneLoadBalancer(ctx, id)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: load balancer %s", ErrNotFound, id)
+		}
+		return nil, err
+	}
+
+	return dbLoadBalancer.toLoadBalancer()
+}
+
+func (lb *SelectOneLoadBalancerRow) toLoadBalancer() (*types.LoadBalancer, error) {
+	row := SelectLoadBalancersRow{
+		LbID:              lb.LbID,
+		Name:              lb.Name,
+		RequestTimeout:    lb.RequestTimeout,
+		Gigastake:         lb.Gigastake,
+		GigastakeRedirect: lb.GigastakeRedirect,
+		UserID:            lb.UserID,
+		SDuration:         lb.Duration,
+		SStickyMax:        lb.StickyMax,
+		SStickiness:       lb.Stickiness,
+		SOrigins:          lb.Origins,
+		AppIds:            lb.AppIds,
+		Users:             lb.Users,
+		CreatedAt:         lb.CreatedAt,
+		UpdatedAt:         lb.UpdatedAt,
+	}
+
+	return row.toLoadBalancer()
+}
+
 /* ReadUserRoles returns all User Roles in the database as a map that takes the form map[User ID]map[LB ID][]types.PermissionsEnum */
 func (p *PostgresDriver) ReadUserRoles(ctx context.Context) (map[string]map[string][]types.PermissionsEnum, error) {
-	userRoles, err := p.SelectUserRoles(ctx)
+	userRoles, err := read(ctx, p, func(q *Queries) ([]SelectUserRolesRow, error) {
+		return q.SelectUserRoles(ctx)
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	return userRolesToMap(userRoles), nil
+}
+
+// ReadUserRolesUpdatedSince returns the User Roles of every LoadBalancer whose user access changed after the given time,
+// in the same form as ReadUserRoles. Consumers should replace all roles they hold for the LoadBalancers present in the result,
+// those left without users are returned under an empty user ID with no permissions
+func (p *PostgresDriver) ReadUserRolesUpdatedSince(ctx context.Context, since time.Time) (map[string]map[string][]types.PermissionsEnum, error) {
+	dbUserRoles, err := read(ctx, p, func(q *Queries) ([]SelectUserRolesUpdatedSinceRow, error) {
+		return q.SelectUserRolesUpdatedSince(ctx, newSQLNullTime(since))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	userRoles := make([]SelectUserRolesRow, 0, len(dbUserRoles))
+	for _, dbUserRole := range dbUserRoles {
+		userRoles = append(userRoles, SelectUserRolesRow{
+			LbID:        newSQLNullString(dbUserRole.LbID),
+			UserID:      dbUserRole.UserID,
+			Permissions: dbUserRole.Permissions,
+		})
+	}
+
+	return userRolesToMap(userRoles), nil
+}
+
+func userRolesToMap(userRoles []SelectUserRolesRow) map[string]map[string][]types.PermissionsEnum {
 	userRolesMap := make(map[string]map[string][]types.PermissionsEnum)
 	for _, userRoleRow := range userRoles {
 		userID, lbID := userRoleRow.UserID.String, userRoleRow.LbID.String
@@ -88,7 +233,7 @@ func (p *PostgresDriver) ReadUserRoles(ctx context.Context) (map[string]map[stri
 		}
 	}
 
-	return userRolesMap, nil
+	return userRolesMap
 }
 
 /* WriteLoadBalancer saves input LoadBalancer to the database */
@@ -102,38 +247,37 @@ func (p *PostgresDriver) WriteLoadBalancer(ctx context.Context, loadBalancer *ty
 		return nil, err
 	}
 	loadBalancer.ID = id
-	time := time.Now()
-	loadBalancer.CreatedAt = time
-	loadBalancer.UpdatedAt = time
+	loadBalancer.CreatedAt = time.Now()
 
-	tx, err := p.db.Begin()
+	tx, err := p.beginTx(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = tx.Rollback() }()
 
-	qtx := p.WithTx(tx)
+	qtx := p.WithTx(tx.Tx)
 
-	err = qtx.InsertLoadBalancer(ctx, extractInsertLoadBalancer(loadBalancer))
+	updatedAt, err := qtx.InsertLoadBalancer(ctx, extractInsertLoadBalancer(loadBalancer))
 	if err != nil {
-		return nil, err
+		return nil, driverError(err)
 	}
+	loadBalancer.UpdatedAt = updatedAt.Time
 
 	stickinessParams := extractInsertStickinessOptions(loadBalancer)
 	if stickinessParams.isNotNull() {
 		err = qtx.InsertStickinessOptions(ctx, stickinessParams)
 		if err != nil {
-			return nil, err
+			return nil, driverError(err)
 		}
 	}
 
 	loadBalancer.Users[0].RoleName = types.RoleOwner // The first User will be the initial creater (owner) of the LoadBalancer
 	accepted := true                                 // New LB owners always start with accepted = true
-	userAccessParams := extractInsertUserAccess(id, loadBalancer.Users[0], &accepted, time)
+	userAccessParams := extractInsertUserAccess(id, loadBalancer.Users[0], &accepted, loadBalancer.CreatedAt)
 	if userAccessParams.isNotNull() {
 		err = qtx.InsertUserAccess(ctx, userAccessParams)
 		if err != nil {
-			return nil, err
+			return nil, driverError(err)
 		}
 	}
 
@@ -142,7 +286,7 @@ func (p *PostgresDriver) WriteLoadBalancer(ctx context.Context, loadBalancer *ty
 
 	err = qtx.InsertLbApps(ctx, lbAppParams)
 	if err != nil {
-		return nil, err
+		return nil, driverError(err)
 	}
 
 	err = tx.Commit()
@@ -162,7 +306,6 @@ func extractInsertLoadBalancer(loadBalancer *types.LoadBalancer) InsertLoadBalan
 		Gigastake:         newSQLNullBool(&loadBalancer.Gigastake),
 		GigastakeRedirect: newSQLNullBool(&loadBalancer.GigastakeRedirect),
 		CreatedAt:         newSQLNullTime(loadBalancer.CreatedAt),
-		UpdatedAt:         newSQLNullTime(loadBalancer.UpdatedAt),
 	}
 }
 
@@ -187,7 +330,6 @@ func extractInsertUserAccess(lbID string, userAccess types.UserAccess, accepted
 		Email:     newSQLNullString(userAccess.Email),
 		Accepted:  newSQLNullBool(accepted),
 		CreatedAt: newSQLNullTime(createdAt),
-		UpdatedAt: newSQLNullTime(createdAt),
 	}
 }
 func (i *InsertUserAccessParams) isNotNull() bool {
@@ -225,36 +367,51 @@ func (p *PostgresDriver) WriteLoadBalancerUser(ctx context.Context, lbID string,
 
 	err := p.InsertUserAccess(ctx, userAccessParams)
 	if err != nil {
-		return err
+		return driverError(err)
 	}
 
 	return nil
 }
 
-/* UpdateLoadBalancer updates LoadBalancer and related table rows */
+// UpdateLoadBalancer updates LoadBalancer and related table rows.
+// When ExpectedUpdatedAt is set the update fails with ErrConflict if the load balancer was updated at another time
 func (p *PostgresDriver) UpdateLoadBalancer(ctx context.Context, id string, update *types.UpdateLoadBalancer) error {
 	if id == "" {
 		return ErrMissingID
 	}
 
-	tx, err := p.db.Begin()
+	tx, err := p.beginTx(ctx)
 	if err != nil {
 		return err
 	}
 	defer func() { _ = tx.Rollback() }()
 
-	qtx := p.WithTx(tx)
+	qtx := p.WithTx(tx.Tx)
+
+	if update.ExpectedUpdatedAt != nil {
+		updatedAt, err := qtx.LockLoadBalancer(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("%w: load balancer %s", ErrNotFound, id)
+			}
+			return err
+		}
+
+		if !updatedAt.Time.Equal(*update.ExpectedUpdatedAt) {
+			return fmt.Errorf("%w: load balancer %s was updated at %s", ErrConflict, id, updatedAt.Time.Format(time.RFC3339Nano))
+		}
+	}
 
 	err = qtx.UpdateLB(ctx, extractUpsertLoadBalancer(id, update))
 	if err != nil {
-		return err
+		return driverError(err)
 	}
 
 	stickinessOptionsParams := extractUpsertStickinessOptions(id, update)
 	if stickinessOptionsParams.isNotNull() {
 		err = qtx.UpsertStickinessOptions(ctx, *stickinessOptionsParams)
 		if err != nil {
-			return err
+			return driverError(err)
 		}
 	}
 
@@ -268,9 +425,8 @@ func (p *PostgresDriver) UpdateLoadBalancer(ctx context.Context, id string, upda
 
 func extractUpsertLoadBalancer(id string, update *types.UpdateLoadBalancer) UpdateLBParams {
 	return UpdateLBParams{
-		LbID:      id,
-		Name:      newSQLNullString(update.Name),
-		UpdatedAt: newSQLNullTime(time.Now()),
+		LbID: id,
+		Name: newSQLNullString(update.Name),
 	}
 }
 
@@ -301,15 +457,14 @@ func (p *PostgresDriver) UpdateUserAccessRole(ctx context.Context, userID, lbID
 	}
 
 	params := UpdateUserAccessParams{
-		UserID:    newSQLNullString(userID),
-		LbID:      newSQLNullString(lbID),
-		RoleName:  newSQLNullString(string(roleName)),
-		UpdatedAt: newSQLNullTime(time.Now()),
+		UserID:   newSQLNullString(userID),
+		LbID:     newSQLNullString(lbID),
+		RoleName: newSQLNullString(string(roleName)),
 	}
 
 	err := p.UpdateUserAccess(ctx, params)
 	if err != nil {
-		return err
+		return driverError(err)
 	}
 
 	return nil
@@ -321,9 +476,9 @@ func (p *PostgresDriver) RemoveLoadBalancer(ctx context.Context, id string) erro
 		return ErrMissingID
 	}
 
-	err := p.RemoveLB(ctx, RemoveLBParams{LbID: id, UpdatedAt: newSQLNullTime(time.Now())})
+	err := p.RemoveLB(ctx, id)
 	if err != nil {
-		return err
+		return driverError(err)
 	}
 
 	return nil
@@ -342,7 +497,7 @@ func (p *PostgresDriver) RemoveUserAccess(ctx context.Context, userID, lbID stri
 
 	err := p.DeleteUserAccess(ctx, params)
 	if err != nil {
-		return err
+		return driverError(err)
 	}
 
 	return nil
@@ -376,7 +531,16 @@ type (
 	}
 )
 
-func (j dbLoadBalancerJSON) toOutput() *types.LoadBalancer {
+func (j dbLoadBalancerJSON) toOutput() (*types.LoadBalancer, error) {
+	createdAt, err := psqlDateToTime(j.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	updatedAt, err := psqlDateToTime(j.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.LoadBalancer{
 		ID:                j.LbID,
 		Name:              j.Name,
@@ -384,25 +548,25 @@ func (j dbLoadBalancerJSON) toOutput() *types.LoadBalancer {
 		RequestTimeout:    j.RequestTimeout,
 		Gigastake:         j.Gigastake,
 		GigastakeRedirect: j.GigastakeRedirect,
-		CreatedAt:         psqlDateToTime(j.CreatedAt),
-		UpdatedAt:         psqlDateToTime(j.UpdatedAt),
-	}
+		CreatedAt:         createdAt,
+		UpdatedAt:         updatedAt,
+	}, nil
 }
-func (j dbStickinessOptionsJSON) toOutput() *types.StickyOptions {
+func (j dbStickinessOptionsJSON) toOutput() (*types.StickyOptions, error) {
 	return &types.StickyOptions{
 		ID:            j.LbID,
 		Duration:      j.Duration,
 		StickyOrigins: j.Origins,
 		StickyMax:     j.StickyMax,
 		Stickiness:    j.Stickiness,
-	}
+	}, nil
 }
-func (j dbUserAccessJSON) toOutput() *types.UserAccess {
+func (j dbUserAccessJSON) toOutput() (*types.UserAccess, error) {
 	return &types.UserAccess{
 		ID:       j.LbID,
 		UserID:   j.UserID,
 		RoleName: types.RoleName(j.RoleName),
 		Email:    j.Email,
 		Accepted: j.Accepted,
-	}
+	}, nil
 }
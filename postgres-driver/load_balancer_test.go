@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/pokt-foundation/portal-db/types"
 )
@@ -100,6 +101,118 @@ func (ts *PGDriverTestSuite) Test_ReadLoadBalancers() {
 	}
 }
 
+func (ts *PGDriverTestSuite) Test_ReadLoadBalancer() {
+	tests := []struct {
+		name         string
+		lbID         string
+		loadBalancer *types.LoadBalancer
+		err          error
+	}{
+		{
+			name: "Should return a single Load Balancer from the database by its ID",
+			lbID: "test_lb_3890ru23jfi32fj",
+			loadBalancer: &types.LoadBalancer{
+				ID:                "test_lb_3890ru23jfi32fj",
+				Name:              "pokt_app_456",
+				UserID:            "test_user_04228205bd261a",
+				ApplicationIDs:    []string{"test_app_5hdf7sh23jd828"},
+				RequestTimeout:    5_000,
+				Gigastake:         true,
+				GigastakeRedirect: true,
+				StickyOptions: types.StickyOptions{
+					Duration:      "40",
+					StickyOrigins: []string{"chrome-extension://"},
+					StickyMax:     400,
+					Stickiness:    true,
+				},
+				Users: []types.UserAccess{
+					{RoleName: "OWNER", UserID: "test_user_04228205bd261a", Email: "owner2@test.com", Accepted: true},
+					{RoleName: "ADMIN", UserID: "test_user_admin5678", Email: "admin2@test.com", Accepted: true},
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "Should fail if the Load Balancer does not exist",
+			lbID: "test_lb_does_not_exist",
+			err:  ErrNotFound,
+		},
+		{
+			name: "Should fail if the load balancer ID is not provided",
+			lbID: "",
+			err:  ErrMissingID,
+		},
+	}
+
+	for _, test := range tests {
+		loadBalancer, err := ts.driver.ReadLoadBalancer(testCtx, test.lbID)
+		ts.ErrorIs(err, test.err)
+		if test.err == nil {
+			ts.Equal(test.loadBalancer.ID, loadBalancer.ID)
+			ts.Equal(test.loadBalancer.Name, loadBalancer.Name)
+			ts.Equal(test.loadBalancer.UserID, loadBalancer.UserID)
+			ts.Equal(test.loadBalancer.ApplicationIDs, loadBalancer.ApplicationIDs)
+			ts.Equal(test.loadBalancer.RequestTimeout, loadBalancer.RequestTimeout)
+			ts.Equal(test.loadBalancer.Gigastake, loadBalancer.Gigastake)
+			ts.Equal(test.loadBalancer.GigastakeRedirect, loadBalancer.GigastakeRedirect)
+			ts.Equal(test.loadBalancer.StickyOptions, loadBalancer.StickyOptions)
+			ts.Equal(test.loadBalancer.Users, loadBalancer.Users)
+			ts.NotEmpty(loadBalancer.CreatedAt)
+			ts.NotEmpty(loadBalancer.UpdatedAt)
+		}
+	}
+}
+
+func (ts *PGDriverTestSuite) Test_ReadLoadBalancersPage() {
+	tests := []struct {
+		name               string
+		options            *types.QueryOptions
+		expectedLBIDs      []string
+		expectedNextCursor string
+		err                error
+	}{
+		{
+			name:               "Should return the first page of Load Balancers and a cursor to the next one",
+			options:            &types.QueryOptions{Limit: 2},
+			expectedLBIDs:      []string{"test_lb_34987u329rfn23f", "test_lb_34gg4g43g34g5hh"},
+			expectedNextCursor: "test_lb_34gg4g43g34g5hh",
+			err:                nil,
+		},
+		{
+			name:               "Should return the last page of Load Balancers without a cursor",
+			options:            &types.QueryOptions{Limit: 2, Cursor: "test_lb_34gg4g43g34g5hh"},
+			expectedLBIDs:      []string{"test_lb_3890ru23jfi32fj"},
+			expectedNextCursor: "",
+			err:                nil,
+		},
+		{
+			name:               "Should only return Load Balancers matching the user ID",
+			options:            &types.QueryOptions{UserID: "test_user_1dbffbdfeeb225"},
+			expectedLBIDs:      []string{"test_lb_34987u329rfn23f"},
+			expectedNextCursor: "",
+			err:                nil,
+		},
+		{
+			name:    "Should fail if the limit is out of range",
+			options: &types.QueryOptions{Limit: -1},
+			err:     types.ErrInvalidPageLimit,
+		},
+	}
+
+	for _, test := range tests {
+		page, err := ts.driver.ReadLoadBalancersPage(testCtx, test.options)
+		ts.Equal(test.err, err)
+		if test.err == nil {
+			lbIDs := []string{}
+			for _, loadBalancer := range page.LoadBalancers {
+				lbIDs = append(lbIDs, loadBalancer.ID)
+			}
+			ts.Equal(test.expectedLBIDs, lbIDs)
+			ts.Equal(test.expectedNextCursor, page.NextCursor)
+		}
+	}
+}
+
 func (ts *PGDriverTestSuite) Test_ReadUserRoles() {
 	tests := []struct {
 		name         string
@@ -159,6 +272,58 @@ func (ts *PGDriverTestSuite) Test_ReadUserRoles() {
 	}
 }
 
+func (ts *PGDriverTestSuite) Test_ReadLoadBalancersUpdatedSince() {
+	tests := []struct {
+		name          string
+		since         time.Time
+		expectedLBIDs []string
+		err           error
+	}{
+		{
+			name:          "Should return all Load Balancers when reading since the zero time",
+			since:         time.Time{},
+			expectedLBIDs: []string{"test_lb_34987u329rfn23f", "test_lb_34gg4g43g34g5hh", "test_lb_3890ru23jfi32fj"},
+			err:           nil,
+		},
+		{
+			name:          "Should return no Load Balancers when reading since a future time",
+			since:         time.Now().Add(24 * time.Hour),
+			expectedLBIDs: nil,
+			err:           nil,
+		},
+	}
+
+	for _, test := range tests {
+		loadBalancers, err := ts.driver.ReadLoadBalancersUpdatedSince(testCtx, test.since)
+		ts.Equal(test.err, err)
+
+		var lbIDs []string
+		for _, loadBalancer := range loadBalancers {
+			lbIDs = append(lbIDs, loadBalancer.ID)
+		}
+		ts.Equal(test.expectedLBIDs, lbIDs)
+	}
+
+	// A change in a child table must bump the parent Load Balancer's updated_at
+	lbBefore, err := ts.driver.ReadLoadBalancer(testCtx, "test_lb_3890ru23jfi32fj")
+	ts.NoError(err)
+
+	err = ts.driver.UpsertStickinessOptions(testCtx, UpsertStickinessOptionsParams{LbID: "test_lb_3890ru23jfi32fj"})
+	ts.NoError(err)
+
+	loadBalancers, err := ts.driver.ReadLoadBalancersUpdatedSince(testCtx, lbBefore.UpdatedAt)
+	ts.NoError(err)
+	ts.Len(loadBalancers, 1)
+	ts.Equal("test_lb_3890ru23jfi32fj", loadBalancers[0].ID)
+
+	userRoles, err := ts.driver.ReadUserRolesUpdatedSince(testCtx, lbBefore.UpdatedAt)
+	ts.NoError(err)
+	ts.Equal(map[string]map[string][]types.PermissionsEnum{
+		"test_user_04228205bd261a": {"test_lb_3890ru23jfi32fj": {types.ReadEndpoint, types.WriteEndpoint}},
+		"test_user_admin5678":      {"test_lb_3890ru23jfi32fj": {types.ReadEndpoint, types.WriteEndpoint}},
+	}, userRoles)
+}
+
 func (ts *PGDriverTestSuite) Test_WriteLoadBalancer() {
 	tests := []struct {
 		name               string
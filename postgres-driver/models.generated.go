@@ -7,7 +7,9 @@ package postgresdriver
 import (
 	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/pokt-foundation/portal-db/types"
 )
@@ -97,6 +99,15 @@ type Blockchain struct {
 	UpdatedAt         sql.NullTime   `json:"updatedAt"`
 }
 
+type Event struct {
+	Sequence  int64           `json:"sequence"`
+	TableName string          `json:"tableName"`
+	Action    string          `json:"action"`
+	Data      json.RawMessage `json:"data"`
+	Previous  json.RawMessage `json:"previous"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
 type GatewayAat struct {
 	ID              int32          `json:"id"`
 	ApplicationID   string         `json:"applicationID"`
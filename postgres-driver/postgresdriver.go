@@ -1,14 +1,17 @@
 package postgresdriver
 
 import (
+	"context"
 	"crypto/rand"
 	"database/sql"
 	"encoding/hex"
-	"errors"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	// PQ import is required
 	_ "github.com/lib/pq"
+	"github.com/pokt-foundation/portal-db/driver"
 	"github.com/pokt-foundation/portal-db/types"
 )
 
@@ -18,19 +21,114 @@ const (
 )
 
 var (
-	ErrMissingID = errors.New("missing id")
+	ErrMissingID = driver.ErrMissingID
+
+	// Errors shared with every Driver, unique and foreign key violations are returned as ErrAlreadyExists and ErrInvalidReference
+	ErrNotFound         = driver.ErrNotFound
+	ErrAlreadyExists    = driver.ErrAlreadyExists
+	ErrInvalidReference = driver.ErrInvalidReference
+	ErrConflict         = driver.ErrConflict
 )
 
 // The PostgresDriver struct satisfies the Driver interface which defines all database driver methods
 type PostgresDriver struct {
 	*Queries
 	db           *sql.DB
+	tx           *sql.Tx // set on the driver RunInTx passes to its function, its writes join that transaction
+	txIsolation  sql.IsolationLevel
 	notification chan *types.Notification
 	listener     Listener
+	workers      int
+	errorHandler func(error)
+
+	// notificationRead is set once NotificationChannel is called, it is accessed atomically
+	notificationRead uint32
+	aggregator       *aggregator
+	coalescingWindow time.Duration
+	coalescedEvents  *uint64
+	eventRetention   time.Duration
+
+	replicaConnectionStrings []string
+	replicas                 replicas
+
+	stop      chan struct{}
+	abort     chan struct{}
+	listening chan struct{}
+	stopOnce  sync.Once
+	abortOnce sync.Once
+	closeOnce sync.Once
+	closeErr  error
+
+	subscriptions subscriptions
+	sequences     *sequenceTracker
+	// replayEvents reads the pages of events subscriptions replay, it is readEventsPage unless replaced by tests
+	replayEvents func(ctx context.Context, sequence int64) ([]*types.Notification, int64, error)
+}
+
+/* Option configures optional PostgresDriver behaviour */
+type Option func(*PostgresDriver)
+
+/* WithListenerWorkers parses notifications with the given number of workers, keeping only per-entity order */
+func WithListenerWorkers(workers int) Option {
+	return func(d *PostgresDriver) {
+		d.workers = workers
+	}
+}
+
+/* WithTxIsolation sets the isolation level of the transactions begun by RunInTx, they are serializable by default */
+func WithTxIsolation(level sql.IsolationLevel) Option {
+	return func(d *PostgresDriver) {
+		d.txIsolation = level
+	}
+}
+
+/* WithErrorHandler calls handler with every notification that could not be parsed, it may be called from several goroutines at once */
+func WithErrorHandler(handler func(error)) Option {
+	return func(d *PostgresDriver) {
+		d.errorHandler = handler
+	}
+}
+
+// WithAggregateNotifications merges the notifications of an Application or LoadBalancer and their child tables
+// into a single one on the applications or loadbalancers table holding the entity freshly read from the database
+func WithAggregateNotifications() Option {
+	return func(d *PostgresDriver) {
+		// The entities are read from the primary as replicas may not have the changes notified yet
+		d.aggregator = &aggregator{
+			readApplication: func(ctx context.Context, id string) (*types.Application, error) {
+				return d.ReadApplication(ReadFromPrimary(ctx), id)
+			},
+			readLoadBalancer: func(ctx context.Context, id string) (*types.LoadBalancer, error) {
+				return d.ReadLoadBalancer(ReadFromPrimary(ctx), id)
+			},
+		}
+	}
+}
+
+/* WithCoalescingWindow collapses the notifications of the same entity received within window into one, delaying them up to window */
+func WithCoalescingWindow(window time.Duration) Option {
+	return func(d *PostgresDriver) {
+		d.coalescingWindow = window
+		d.coalescedEvents = new(uint64)
+	}
+}
+
+// WithEventRetention deletes the outbox events older than retention every eventPruneInterval.
+// Subscriptions and caches resuming from a sequence older than that miss the events deleted and should reload instead
+func WithEventRetention(retention time.Duration) Option {
+	return func(d *PostgresDriver) {
+		d.eventRetention = retention
+	}
+}
+
+func (d *PostgresDriver) applyOptions(options []Option) {
+	for _, option := range options {
+		option(d)
+	}
 }
 
 /* NewPostgresDriver returns PostgresDriver instance from Postgres connection string */
-func NewPostgresDriver(connectionString string, listener Listener) (*PostgresDriver, error) {
+func NewPostgresDriver(connectionString string, listener Listener, options ...Option) (*PostgresDriver, error) {
 	db, err := sql.Open("postgres", connectionString)
 	if err != nil {
 		return nil, err
@@ -41,6 +139,15 @@ func NewPostgresDriver(connectionString string, listener Listener) (*PostgresDri
 		db:           db,
 		notification: make(chan *types.Notification, 32),
 		listener:     listener,
+		workers:      1,
+		txIsolation:  sql.LevelSerializable,
+	}
+	driver.replayEvents = driver.readEventsPage
+	driver.applyOptions(options)
+
+	err = driver.openReplicas()
+	if err != nil {
+		return nil, err
 	}
 
 	err = driver.listener.Listen("events")
@@ -48,32 +155,45 @@ func NewPostgresDriver(connectionString string, listener Listener) (*PostgresDri
 		return nil, err
 	}
 
-	go Listen(driver.listener.NotificationChannel(), driver.notification)
+	driver.startListening()
 
 	return driver, nil
 }
 
 /* NewPostgresDriverFromDBInstance returns PostgresDriver instance from sdl.DB instance */
 // mostly used for mocking tests
-func NewPostgresDriverFromDBInstance(db *sql.DB, listener Listener) *PostgresDriver {
+func NewPostgresDriverFromDBInstance(db *sql.DB, listener Listener, options ...Option) *PostgresDriver {
 	driver := &PostgresDriver{
 		Queries:      New(db),
+		db:           db,
 		notification: make(chan *types.Notification, 32),
 		listener:     listener,
+		workers:      1,
+		txIsolation:  sql.LevelSerializable,
+	}
+	driver.replayEvents = driver.readEventsPage
+	driver.applyOptions(options)
+
+	err := driver.openReplicas()
+	if err != nil {
+		panic(err)
 	}
 
-	err := driver.listener.Listen("events")
+	err = driver.listener.Listen("events")
 	if err != nil {
 		panic(err)
 	}
 
-	go Listen(driver.listener.NotificationChannel(), driver.notification)
+	driver.startListening()
 
 	return driver
 }
 
-/* NotificationChannel returns receiver Notification channel  */
+// NotificationChannel returns receiver Notification channel. Until it is first called only as many notifications as
+// the channel buffers are kept for it, so a driver used through Subscribe alone is never held back by it
 func (d *PostgresDriver) NotificationChannel() <-chan *types.Notification {
+	atomic.StoreUint32(&d.notificationRead, 1)
+
 	return d.notification
 }
 
@@ -130,9 +250,12 @@ func newSQLNullTime(value time.Time) sql.NullTime {
 	}
 }
 
-func psqlDateToTime(rawDate string) time.Time {
-	date, _ := time.Parse(psqlDateLayout, rawDate)
-	return date
+func psqlDateToTime(rawDate string) (time.Time, error) {
+	if rawDate == "" {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(psqlDateLayout, rawDate)
 }
 
 func boolPointer(value bool) *bool {
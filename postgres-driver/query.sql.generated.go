@@ -17,18 +17,82 @@ import (
 const activateBlockchain = `-- name: ActivateBlockchain :exec
 UPDATE blockchains
 SET active = $2,
-    updated_at = $3
+    updated_at = NOW()
 WHERE blockchain_id = $1
 `
 
 type ActivateBlockchainParams struct {
 	BlockchainID string       `json:"blockchainID"`
 	Active       sql.NullBool `json:"active"`
-	UpdatedAt    sql.NullTime `json:"updatedAt"`
 }
 
 func (q *Queries) ActivateBlockchain(ctx context.Context, arg ActivateBlockchainParams) error {
-	_, err := q.db.ExecContext(ctx, activateBlockchain, arg.BlockchainID, arg.Active, arg.UpdatedAt)
+	_, err := q.db.ExecContext(ctx, activateBlockchain, arg.BlockchainID, arg.Active)
+	return err
+}
+
+const countBlockchainRedirects = `-- name: CountBlockchainRedirects :one
+SELECT COUNT(*)
+FROM redirects
+WHERE blockchain_id = $1
+`
+
+func (q *Queries) CountBlockchainRedirects(ctx context.Context, blockchainID string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countBlockchainRedirects, blockchainID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const deleteBlockchain = `-- name: DeleteBlockchain :exec
+DELETE FROM blockchains
+WHERE blockchain_id = $1
+`
+
+func (q *Queries) DeleteBlockchain(ctx context.Context, blockchainID string) error {
+	_, err := q.db.ExecContext(ctx, deleteBlockchain, blockchainID)
+	return err
+}
+
+const deleteEventsBefore = `-- name: DeleteEventsBefore :execrows
+DELETE FROM events
+WHERE created_at < NOW() - make_interval(secs => $1)
+`
+
+func (q *Queries) DeleteEventsBefore(ctx context.Context, retentionSeconds float64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteEventsBefore, retentionSeconds)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteRedirect = `-- name: DeleteRedirect :execrows
+DELETE FROM redirects
+WHERE blockchain_id = $1
+    AND domain = $2
+`
+
+type DeleteRedirectParams struct {
+	BlockchainID string `json:"blockchainID"`
+	Domain       string `json:"domain"`
+}
+
+func (q *Queries) DeleteRedirect(ctx context.Context, arg DeleteRedirectParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteRedirect, arg.BlockchainID, arg.Domain)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteSyncCheckOptions = `-- name: DeleteSyncCheckOptions :exec
+DELETE FROM sync_check_options
+WHERE blockchain_id = $1
+`
+
+func (q *Queries) DeleteSyncCheckOptions(ctx context.Context, blockchainID string) error {
+	_, err := q.db.ExecContext(ctx, deleteSyncCheckOptions, blockchainID)
 	return err
 }
 
@@ -64,7 +128,7 @@ func (q *Queries) InsertAppLimit(ctx context.Context, arg InsertAppLimitParams)
 	return err
 }
 
-const insertApplication = `-- name: InsertApplication :exec
+const insertApplication = `-- name: InsertApplication :one
 INSERT into applications (
         application_id,
         user_id,
@@ -89,8 +153,9 @@ VALUES (
         $8,
         $9,
         $10,
-        $11
+        NOW()
     )
+RETURNING updated_at
 `
 
 type InsertApplicationParams struct {
@@ -104,11 +169,10 @@ type InsertApplicationParams struct {
 	Status        sql.NullString `json:"status"`
 	Dummy         sql.NullBool   `json:"dummy"`
 	CreatedAt     sql.NullTime   `json:"createdAt"`
-	UpdatedAt     sql.NullTime   `json:"updatedAt"`
 }
 
-func (q *Queries) InsertApplication(ctx context.Context, arg InsertApplicationParams) error {
-	_, err := q.db.ExecContext(ctx, insertApplication,
+func (q *Queries) InsertApplication(ctx context.Context, arg InsertApplicationParams) (sql.NullTime, error) {
+	row := q.db.QueryRowContext(ctx, insertApplication,
 		arg.ApplicationID,
 		arg.UserID,
 		arg.Name,
@@ -119,12 +183,13 @@ func (q *Queries) InsertApplication(ctx context.Context, arg InsertApplicationPa
 		arg.Status,
 		arg.Dummy,
 		arg.CreatedAt,
-		arg.UpdatedAt,
 	)
-	return err
+	var updated_at sql.NullTime
+	err := row.Scan(&updated_at)
+	return updated_at, err
 }
 
-const insertBlockchain = `-- name: InsertBlockchain :exec
+const insertBlockchain = `-- name: InsertBlockchain :one
 INSERT into blockchains (
         blockchain_id,
         active,
@@ -159,8 +224,9 @@ VALUES (
         $13,
         $14,
         $15,
-        $16
+        NOW()
     )
+RETURNING updated_at
 `
 
 type InsertBlockchainParams struct {
@@ -179,11 +245,10 @@ type InsertBlockchainParams struct {
 	RequestTimeout    sql.NullInt32  `json:"requestTimeout"`
 	Ticker            sql.NullString `json:"ticker"`
 	CreatedAt         sql.NullTime   `json:"createdAt"`
-	UpdatedAt         sql.NullTime   `json:"updatedAt"`
 }
 
-func (q *Queries) InsertBlockchain(ctx context.Context, arg InsertBlockchainParams) error {
-	_, err := q.db.ExecContext(ctx, insertBlockchain,
+func (q *Queries) InsertBlockchain(ctx context.Context, arg InsertBlockchainParams) (sql.NullTime, error) {
+	row := q.db.QueryRowContext(ctx, insertBlockchain,
 		arg.BlockchainID,
 		arg.Active,
 		arg.Altruist,
@@ -199,9 +264,10 @@ func (q *Queries) InsertBlockchain(ctx context.Context, arg InsertBlockchainPara
 		arg.RequestTimeout,
 		arg.Ticker,
 		arg.CreatedAt,
-		arg.UpdatedAt,
 	)
-	return err
+	var updated_at sql.NullTime
+	err := row.Scan(&updated_at)
+	return updated_at, err
 }
 
 const insertGatewayAAT = `-- name: InsertGatewayAAT :exec
@@ -312,7 +378,7 @@ func (q *Queries) InsertLbApps(ctx context.Context, arg InsertLbAppsParams) erro
 	return err
 }
 
-const insertLoadBalancer = `-- name: InsertLoadBalancer :exec
+const insertLoadBalancer = `-- name: InsertLoadBalancer :one
 INSERT into loadbalancers (
         lb_id,
         name,
@@ -331,8 +397,9 @@ VALUES (
         $5,
         $6,
         $7,
-        $8
+        NOW()
     )
+RETURNING updated_at
 `
 
 type InsertLoadBalancerParams struct {
@@ -343,11 +410,10 @@ type InsertLoadBalancerParams struct {
 	Gigastake         sql.NullBool   `json:"gigastake"`
 	GigastakeRedirect sql.NullBool   `json:"gigastakeRedirect"`
 	CreatedAt         sql.NullTime   `json:"createdAt"`
-	UpdatedAt         sql.NullTime   `json:"updatedAt"`
 }
 
-func (q *Queries) InsertLoadBalancer(ctx context.Context, arg InsertLoadBalancerParams) error {
-	_, err := q.db.ExecContext(ctx, insertLoadBalancer,
+func (q *Queries) InsertLoadBalancer(ctx context.Context, arg InsertLoadBalancerParams) (sql.NullTime, error) {
+	row := q.db.QueryRowContext(ctx, insertLoadBalancer,
 		arg.LbID,
 		arg.Name,
 		arg.UserID,
@@ -355,9 +421,10 @@ func (q *Queries) InsertLoadBalancer(ctx context.Context, arg InsertLoadBalancer
 		arg.Gigastake,
 		arg.GigastakeRedirect,
 		arg.CreatedAt,
-		arg.UpdatedAt,
 	)
-	return err
+	var updated_at sql.NullTime
+	err := row.Scan(&updated_at)
+	return updated_at, err
 }
 
 const insertNotificationSettings = `-- name: InsertNotificationSettings :exec
@@ -400,7 +467,7 @@ func (q *Queries) InsertNotificationSettings(ctx context.Context, arg InsertNoti
 	return err
 }
 
-const insertRedirect = `-- name: InsertRedirect :exec
+const insertRedirect = `-- name: InsertRedirect :one
 INSERT into redirects (
         blockchain_id,
         alias,
@@ -415,8 +482,9 @@ VALUES (
         $3,
         $4,
         $5,
-        $6
+        NOW()
     )
+RETURNING updated_at
 `
 
 type InsertRedirectParams struct {
@@ -425,19 +493,19 @@ type InsertRedirectParams struct {
 	Loadbalancer string       `json:"loadbalancer"`
 	Domain       string       `json:"domain"`
 	CreatedAt    sql.NullTime `json:"createdAt"`
-	UpdatedAt    sql.NullTime `json:"updatedAt"`
 }
 
-func (q *Queries) InsertRedirect(ctx context.Context, arg InsertRedirectParams) error {
-	_, err := q.db.ExecContext(ctx, insertRedirect,
+func (q *Queries) InsertRedirect(ctx context.Context, arg InsertRedirectParams) (sql.NullTime, error) {
+	row := q.db.QueryRowContext(ctx, insertRedirect,
 		arg.BlockchainID,
 		arg.Alias,
 		arg.Loadbalancer,
 		arg.Domain,
 		arg.CreatedAt,
-		arg.UpdatedAt,
 	)
-	return err
+	var updated_at sql.NullTime
+	err := row.Scan(&updated_at)
+	return updated_at, err
 }
 
 const insertStickinessOptions = `-- name: InsertStickinessOptions :exec
@@ -520,7 +588,7 @@ INSERT INTO user_access (
         created_at,
         updated_at
     )
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+VALUES ($1, $2, $3, $4, $5, $6, NOW())
 `
 
 type InsertUserAccessParams struct {
@@ -530,7 +598,6 @@ type InsertUserAccessParams struct {
 	Email     sql.NullString `json:"email"`
 	Accepted  sql.NullBool   `json:"accepted"`
 	CreatedAt sql.NullTime   `json:"createdAt"`
-	UpdatedAt sql.NullTime   `json:"updatedAt"`
 }
 
 func (q *Queries) InsertUserAccess(ctx context.Context, arg InsertUserAccessParams) error {
@@ -541,14 +608,56 @@ func (q *Queries) InsertUserAccess(ctx context.Context, arg InsertUserAccessPara
 		arg.Email,
 		arg.Accepted,
 		arg.CreatedAt,
-		arg.UpdatedAt,
 	)
 	return err
 }
 
+const lockApplication = `-- name: LockApplication :one
+SELECT updated_at
+FROM applications
+WHERE application_id = $1 FOR
+UPDATE
+`
+
+func (q *Queries) LockApplication(ctx context.Context, applicationID string) (sql.NullTime, error) {
+	row := q.db.QueryRowContext(ctx, lockApplication, applicationID)
+	var updated_at sql.NullTime
+	err := row.Scan(&updated_at)
+	return updated_at, err
+}
+
+const lockBlockchain = `-- name: LockBlockchain :one
+SELECT updated_at
+FROM blockchains
+WHERE blockchain_id = $1 FOR
+UPDATE
+`
+
+func (q *Queries) LockBlockchain(ctx context.Context, blockchainID string) (sql.NullTime, error) {
+	row := q.db.QueryRowContext(ctx, lockBlockchain, blockchainID)
+	var updated_at sql.NullTime
+	err := row.Scan(&updated_at)
+	return updated_at, err
+}
+
+const lockLoadBalancer = `-- name: LockLoadBalancer :one
+SELECT updated_at
+FROM loadbalancers
+WHERE lb_id = $1 FOR
+UPDATE
+`
+
+func (q *Queries) LockLoadBalancer(ctx context.Context, lbID string) (sql.NullTime, error) {
+	row := q.db.QueryRowContext(ctx, lockLoadBalancer, lbID)
+	var updated_at sql.NullTime
+	err := row.Scan(&updated_at)
+	return updated_at, err
+}
+
 const removeApp = `-- name: RemoveApp :exec
 UPDATE applications
-SET status = COALESCE($2, status)
+SET status = COALESCE($2, status),
+    updated_at = NOW()
 WHERE application_id = $1
 `
 
@@ -565,17 +674,12 @@ func (q *Queries) RemoveApp(ctx context.Context, arg RemoveAppParams) error {
 const removeLB = `-- name: RemoveLB :exec
 UPDATE loadbalancers
 SET user_id = '',
-    updated_at = $2
+    updated_at = NOW()
 WHERE lb_id = $1
 `
 
-type RemoveLBParams struct {
-	LbID      string       `json:"lbID"`
-	UpdatedAt sql.NullTime `json:"updatedAt"`
-}
-
-func (q *Queries) RemoveLB(ctx context.Context, arg RemoveLBParams) error {
-	_, err := q.db.ExecContext(ctx, removeLB, arg.LbID, arg.UpdatedAt)
+func (q *Queries) RemoveLB(ctx context.Context, lbID string) error {
+	_, err := q.db.ExecContext(ctx, removeLB, lbID)
 	return err
 }
 
@@ -736,134 +840,98 @@ func (q *Queries) SelectApplications(ctx context.Context) ([]SelectApplicationsR
 	return items, nil
 }
 
-const selectBlockchains = `-- name: SelectBlockchains :many
-SELECT b.blockchain_id,
-    b.altruist,
-    b.blockchain,
-    b.blockchain_aliases,
-    b.chain_id,
-    b.chain_id_check,
-    b.description,
-    b.enforce_result,
-    b.log_limit_blocks,
-    b.network,
-    b.path,
-    b.request_timeout,
-    b.ticker,
-    b.active,
-    s.synccheck AS s_sync_check,
-    s.allowance AS s_allowance,
-    s.body AS s_body,
-    s.path AS s_path,
-    s.result_key AS s_result_key,
-    COALESCE(redirects.r, '[]') AS redirects,
-    b.created_at,
-    b.updated_at
-FROM blockchains AS b
-    LEFT JOIN sync_check_options AS s ON b.blockchain_id = s.blockchain_id
-    LEFT JOIN LATERAL (
-        SELECT json_agg(
-                json_build_object(
-                    'alias',
-                    r.alias,
-                    'loadBalancerID',
-                    r.loadbalancer,
-                    'domain',
-                    r.domain
-                )
-            ) AS r
-        FROM redirects AS r
-        WHERE b.blockchain_id = r.blockchain_id
-    ) redirects ON true
-ORDER BY b.blockchain_id ASC
+const selectApplicationsPage = `-- name: SelectApplicationsPage :many
+SELECT a.application_id,
+    a.contact_email,
+    a.description,
+    a.dummy,
+    a.name,
+    a.owner,
+    a.status,
+    a.url,
+    a.user_id,
+    a.first_date_surpassed,
+    ga.address AS ga_address,
+    ga.client_public_key AS ga_client_public_key,
+    ga.private_key AS ga_private_key,
+    ga.public_key AS ga_public_key,
+    ga.signature AS ga_signature,
+    ga.version AS ga_version,
+    gs.secret_key,
+    gs.secret_key_required,
+    gs.whitelist_blockchains,
+    gs.whitelist_contracts,
+    gs.whitelist_methods,
+    gs.whitelist_origins,
+    gs.whitelist_user_agents,
+    ns.signed_up,
+    ns.on_quarter,
+    ns.on_half,
+    ns.on_three_quarters,
+    ns.on_full,
+    al.custom_limit,
+    al.pay_plan,
+    pp.daily_limit AS plan_limit,
+    a.created_at,
+    a.updated_at
+FROM applications AS a
+    LEFT JOIN gateway_aat AS ga ON a.application_id = ga.application_id
+    LEFT JOIN gateway_settings AS gs ON a.application_id = gs.application_id
+    LEFT JOIN notification_settings AS ns ON a.application_id = ns.application_id
+    LEFT JOIN app_limits AS al ON a.application_id = al.application_id
+    LEFT JOIN pay_plans AS pp ON al.pay_plan = pp.plan_type
+WHERE a.application_id > $1
+    AND (
+        $2::VARCHAR IS NULL
+        OR a.user_id = $2
+    )
+    AND (
+        $3::VARCHAR IS NULL
+        OR a.status = $3
+    )
+    AND (
+        $4::VARCHAR IS NULL
+        OR al.pay_plan = $4
+    )
+    AND (
+        $5::BOOLEAN IS NULL
+        OR a.dummy = $5
+    )
+    AND (
+        $6::TIMESTAMP IS NULL
+        OR a.created_at > $6
+    )
+ORDER BY a.application_id ASC
+LIMIT $7
 `
 
-type SelectBlockchainsRow struct {
-	BlockchainID      string          `json:"blockchainID"`
-	Altruist          sql.NullString  `json:"altruist"`
-	Blockchain        sql.NullString  `json:"blockchain"`
-	BlockchainAliases []string        `json:"blockchainAliases"`
-	ChainID           sql.NullString  `json:"chainID"`
-	ChainIDCheck      sql.NullString  `json:"chainIDCheck"`
-	Description       sql.NullString  `json:"description"`
-	EnforceResult     sql.NullString  `json:"enforceResult"`
-	LogLimitBlocks    sql.NullInt32   `json:"logLimitBlocks"`
-	Network           sql.NullString  `json:"network"`
-	Path              sql.NullString  `json:"path"`
-	RequestTimeout    sql.NullInt32   `json:"requestTimeout"`
-	Ticker            sql.NullString  `json:"ticker"`
-	Active            sql.NullBool    `json:"active"`
-	SSyncCheck        sql.NullString  `json:"sSyncCheck"`
-	SAllowance        sql.NullInt32   `json:"sAllowance"`
-	SBody             sql.NullString  `json:"sBody"`
-	SPath             sql.NullString  `json:"sPath"`
-	SResultKey        sql.NullString  `json:"sResultKey"`
-	Redirects         json.RawMessage `json:"redirects"`
-	CreatedAt         sql.NullTime    `json:"createdAt"`
-	UpdatedAt         sql.NullTime    `json:"updatedAt"`
-}
-
-func (q *Queries) SelectBlockchains(ctx context.Context) ([]SelectBlockchainsRow, error) {
-	rows, err := q.db.QueryContext(ctx, selectBlockchains)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []SelectBlockchainsRow
-	for rows.Next() {
-		var i SelectBlockchainsRow
-		if err := rows.Scan(
-			&i.BlockchainID,
-			&i.Altruist,
-			&i.Blockchain,
-			pq.Array(&i.BlockchainAliases),
-			&i.ChainID,
-			&i.ChainIDCheck,
-			&i.Description,
-			&i.EnforceResult,
-			&i.LogLimitBlocks,
-			&i.Network,
-			&i.Path,
-			&i.RequestTimeout,
-			&i.Ticker,
-			&i.Active,
-			&i.SSyncCheck,
-			&i.SAllowance,
-			&i.SBody,
-			&i.SPath,
-			&i.SResultKey,
-			&i.Redirects,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Close(); err != nil {
-		return nil, err
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+type SelectApplicationsPageParams struct {
+	Cursor       string         `json:"cursor"`
+	UserID       sql.NullString `json:"userID"`
+	Status       sql.NullString `json:"status"`
+	PayPlan      sql.NullString `json:"payPlan"`
+	Dummy        sql.NullBool   `json:"dummy"`
+	CreatedAfter sql.NullTime   `json:"createdAfter"`
+	MaxResults   int32          `json:"maxResults"`
 }
 
-const selectGatewaySettings = `-- name: SelectGatewaySettings :one
-SELECT application_id,
-    secret_key,
-    secret_key_required,
-    whitelist_blockchains,
-    whitelist_contracts,
-    whitelist_methods,
-    whitelist_origins,
-    whitelist_user_agents
-FROM gateway_settings
-WHERE application_id = $1
-`
-
-type SelectGatewaySettingsRow struct {
+type SelectApplicationsPageRow struct {
 	ApplicationID        string         `json:"applicationID"`
+	ContactEmail         sql.NullString `json:"contactEmail"`
+	Description          sql.NullString `json:"description"`
+	Dummy                sql.NullBool   `json:"dummy"`
+	Name                 sql.NullString `json:"name"`
+	Owner                sql.NullString `json:"owner"`
+	Status               sql.NullString `json:"status"`
+	Url                  sql.NullString `json:"url"`
+	UserID               sql.NullString `json:"userID"`
+	FirstDateSurpassed   sql.NullTime   `json:"firstDateSurpassed"`
+	GaAddress            sql.NullString `json:"gaAddress"`
+	GaClientPublicKey    sql.NullString `json:"gaClientPublicKey"`
+	GaPrivateKey         sql.NullString `json:"gaPrivateKey"`
+	GaPublicKey          sql.NullString `json:"gaPublicKey"`
+	GaSignature          sql.NullString `json:"gaSignature"`
+	GaVersion            sql.NullString `json:"gaVersion"`
 	SecretKey            sql.NullString `json:"secretKey"`
 	SecretKeyRequired    sql.NullBool   `json:"secretKeyRequired"`
 	WhitelistBlockchains []string       `json:"whitelistBlockchains"`
@@ -871,14 +939,586 @@ type SelectGatewaySettingsRow struct {
 	WhitelistMethods     sql.NullString `json:"whitelistMethods"`
 	WhitelistOrigins     []string       `json:"whitelistOrigins"`
 	WhitelistUserAgents  []string       `json:"whitelistUserAgents"`
+	SignedUp             sql.NullBool   `json:"signedUp"`
+	OnQuarter            sql.NullBool   `json:"onQuarter"`
+	OnHalf               sql.NullBool   `json:"onHalf"`
+	OnThreeQuarters      sql.NullBool   `json:"onThreeQuarters"`
+	OnFull               sql.NullBool   `json:"onFull"`
+	CustomLimit          sql.NullInt32  `json:"customLimit"`
+	PayPlan              sql.NullString `json:"payPlan"`
+	PlanLimit            sql.NullInt32  `json:"planLimit"`
+	CreatedAt            sql.NullTime   `json:"createdAt"`
+	UpdatedAt            sql.NullTime   `json:"updatedAt"`
 }
 
-func (q *Queries) SelectGatewaySettings(ctx context.Context, applicationID string) (SelectGatewaySettingsRow, error) {
-	row := q.db.QueryRowContext(ctx, selectGatewaySettings, applicationID)
-	var i SelectGatewaySettingsRow
-	err := row.Scan(
-		&i.ApplicationID,
-		&i.SecretKey,
+func (q *Queries) SelectApplicationsPage(ctx context.Context, arg SelectApplicationsPageParams) ([]SelectApplicationsPageRow, error) {
+	rows, err := q.db.QueryContext(ctx, selectApplicationsPage,
+		arg.Cursor,
+		arg.UserID,
+		arg.Status,
+		arg.PayPlan,
+		arg.Dummy,
+		arg.CreatedAfter,
+		arg.MaxResults,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SelectApplicationsPageRow
+	for rows.Next() {
+		var i SelectApplicationsPageRow
+		if err := rows.Scan(
+			&i.ApplicationID,
+			&i.ContactEmail,
+			&i.Description,
+			&i.Dummy,
+			&i.Name,
+			&i.Owner,
+			&i.Status,
+			&i.Url,
+			&i.UserID,
+			&i.FirstDateSurpassed,
+			&i.GaAddress,
+			&i.GaClientPublicKey,
+			&i.GaPrivateKey,
+			&i.GaPublicKey,
+			&i.GaSignature,
+			&i.GaVersion,
+			&i.SecretKey,
+			&i.SecretKeyRequired,
+			pq.Array(&i.WhitelistBlockchains),
+			&i.WhitelistContracts,
+			&i.WhitelistMethods,
+			pq.Array(&i.WhitelistOrigins),
+			pq.Array(&i.WhitelistUserAgents),
+			&i.SignedUp,
+			&i.OnQuarter,
+			&i.OnHalf,
+			&i.OnThreeQuarters,
+			&i.OnFull,
+			&i.CustomLimit,
+			&i.PayPlan,
+			&i.PlanLimit,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectApplicationsUpdatedSince = `-- name: SelectApplicationsUpdatedSince :many
+SELECT a.application_id,
+    a.contact_email,
+    a.description,
+    a.dummy,
+    a.name,
+    a.owner,
+    a.status,
+    a.url,
+    a.user_id,
+    a.first_date_surpassed,
+    ga.address AS ga_address,
+    ga.client_public_key AS ga_client_public_key,
+    ga.private_key AS ga_private_key,
+    ga.public_key AS ga_public_key,
+    ga.signature AS ga_signature,
+    ga.version AS ga_version,
+    gs.secret_key,
+    gs.secret_key_required,
+    gs.whitelist_blockchains,
+    gs.whitelist_contracts,
+    gs.whitelist_methods,
+    gs.whitelist_origins,
+    gs.whitelist_user_agents,
+    ns.signed_up,
+    ns.on_quarter,
+    ns.on_half,
+    ns.on_three_quarters,
+    ns.on_full,
+    al.custom_limit,
+    al.pay_plan,
+    pp.daily_limit AS plan_limit,
+    a.created_at,
+    a.updated_at
+FROM applications AS a
+    LEFT JOIN gateway_aat AS ga ON a.application_id = ga.application_id
+    LEFT JOIN gateway_settings AS gs ON a.application_id = gs.application_id
+    LEFT JOIN notification_settings AS ns ON a.application_id = ns.application_id
+    LEFT JOIN app_limits AS al ON a.application_id = al.application_id
+    LEFT JOIN pay_plans AS pp ON al.pay_plan = pp.plan_type
+WHERE a.updated_at > $1
+ORDER BY a.application_id ASC
+`
+
+type SelectApplicationsUpdatedSinceRow struct {
+	ApplicationID        string         `json:"applicationID"`
+	ContactEmail         sql.NullString `json:"contactEmail"`
+	Description          sql.NullString `json:"description"`
+	Dummy                sql.NullBool   `json:"dummy"`
+	Name                 sql.NullString `json:"name"`
+	Owner                sql.NullString `json:"owner"`
+	Status               sql.NullString `json:"status"`
+	Url                  sql.NullString `json:"url"`
+	UserID               sql.NullString `json:"userID"`
+	FirstDateSurpassed   sql.NullTime   `json:"firstDateSurpassed"`
+	GaAddress            sql.NullString `json:"gaAddress"`
+	GaClientPublicKey    sql.NullString `json:"gaClientPublicKey"`
+	GaPrivateKey         sql.NullString `json:"gaPrivateKey"`
+	GaPublicKey          sql.NullString `json:"gaPublicKey"`
+	GaSignature          sql.NullString `json:"gaSignature"`
+	GaVersion            sql.NullString `json:"gaVersion"`
+	SecretKey            sql.NullString `json:"secretKey"`
+	SecretKeyRequired    sql.NullBool   `json:"secretKeyRequired"`
+	WhitelistBlockchains []string       `json:"whitelistBlockchains"`
+	WhitelistContracts   sql.NullString `json:"whitelistContracts"`
+	WhitelistMethods     sql.NullString `json:"whitelistMethods"`
+	WhitelistOrigins     []string       `json:"whitelistOrigins"`
+	WhitelistUserAgents  []string       `json:"whitelistUserAgents"`
+	SignedUp             sql.NullBool   `json:"signedUp"`
+	OnQuarter            sql.NullBool   `json:"onQuarter"`
+	OnHalf               sql.NullBool   `json:"onHalf"`
+	OnThreeQuarters      sql.NullBool   `json:"onThreeQuarters"`
+	OnFull               sql.NullBool   `json:"onFull"`
+	CustomLimit          sql.NullInt32  `json:"customLimit"`
+	PayPlan              sql.NullString `json:"payPlan"`
+	PlanLimit            sql.NullInt32  `json:"planLimit"`
+	CreatedAt            sql.NullTime   `json:"createdAt"`
+	UpdatedAt            sql.NullTime   `json:"updatedAt"`
+}
+
+func (q *Queries) SelectApplicationsUpdatedSince(ctx context.Context, updatedSince sql.NullTime) ([]SelectApplicationsUpdatedSinceRow, error) {
+	rows, err := q.db.QueryContext(ctx, selectApplicationsUpdatedSince, updatedSince)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SelectApplicationsUpdatedSinceRow
+	for rows.Next() {
+		var i SelectApplicationsUpdatedSinceRow
+		if err := rows.Scan(
+			&i.ApplicationID,
+			&i.ContactEmail,
+			&i.Description,
+			&i.Dummy,
+			&i.Name,
+			&i.Owner,
+			&i.Status,
+			&i.Url,
+			&i.UserID,
+			&i.FirstDateSurpassed,
+			&i.GaAddress,
+			&i.GaClientPublicKey,
+			&i.GaPrivateKey,
+			&i.GaPublicKey,
+			&i.GaSignature,
+			&i.GaVersion,
+			&i.SecretKey,
+			&i.SecretKeyRequired,
+			pq.Array(&i.WhitelistBlockchains),
+			&i.WhitelistContracts,
+			&i.WhitelistMethods,
+			pq.Array(&i.WhitelistOrigins),
+			pq.Array(&i.WhitelistUserAgents),
+			&i.SignedUp,
+			&i.OnQuarter,
+			&i.OnHalf,
+			&i.OnThreeQuarters,
+			&i.OnFull,
+			&i.CustomLimit,
+			&i.PayPlan,
+			&i.PlanLimit,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectBlockchainWhitelists = `-- name: SelectBlockchainWhitelists :many
+SELECT application_id,
+    whitelist_blockchains,
+    whitelist_contracts,
+    whitelist_methods
+FROM gateway_settings
+WHERE $1::VARCHAR = ANY(whitelist_blockchains)
+    OR whitelist_contracts LIKE '%' || $1 || '%'
+    OR whitelist_methods LIKE '%' || $1 || '%'
+`
+
+type SelectBlockchainWhitelistsRow struct {
+	ApplicationID        string         `json:"applicationID"`
+	WhitelistBlockchains []string       `json:"whitelistBlockchains"`
+	WhitelistContracts   sql.NullString `json:"whitelistContracts"`
+	WhitelistMethods     sql.NullString `json:"whitelistMethods"`
+}
+
+func (q *Queries) SelectBlockchainWhitelists(ctx context.Context, blockchainID string) ([]SelectBlockchainWhitelistsRow, error) {
+	rows, err := q.db.QueryContext(ctx, selectBlockchainWhitelists, blockchainID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SelectBlockchainWhitelistsRow
+	for rows.Next() {
+		var i SelectBlockchainWhitelistsRow
+		if err := rows.Scan(
+			&i.ApplicationID,
+			pq.Array(&i.WhitelistBlockchains),
+			&i.WhitelistContracts,
+			&i.WhitelistMethods,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectBlockchains = `-- name: SelectBlockchains :many
+SELECT b.blockchain_id,
+    b.altruist,
+    b.blockchain,
+    b.blockchain_aliases,
+    b.chain_id,
+    b.chain_id_check,
+    b.description,
+    b.enforce_result,
+    b.log_limit_blocks,
+    b.network,
+    b.path,
+    b.request_timeout,
+    b.ticker,
+    b.active,
+    s.synccheck AS s_sync_check,
+    s.allowance AS s_allowance,
+    s.body AS s_body,
+    s.path AS s_path,
+    s.result_key AS s_result_key,
+    COALESCE(redirects.r, '[]') AS redirects,
+    b.created_at,
+    b.updated_at
+FROM blockchains AS b
+    LEFT JOIN sync_check_options AS s ON b.blockchain_id = s.blockchain_id
+    LEFT JOIN LATERAL (
+        SELECT json_agg(
+                json_build_object(
+                    'alias',
+                    r.alias,
+                    'loadBalancerID',
+                    r.loadbalancer,
+                    'domain',
+                    r.domain
+                )
+            ) AS r
+        FROM redirects AS r
+        WHERE b.blockchain_id = r.blockchain_id
+    ) redirects ON true
+ORDER BY b.blockchain_id ASC
+`
+
+type SelectBlockchainsRow struct {
+	BlockchainID      string          `json:"blockchainID"`
+	Altruist          sql.NullString  `json:"altruist"`
+	Blockchain        sql.NullString  `json:"blockchain"`
+	BlockchainAliases []string        `json:"blockchainAliases"`
+	ChainID           sql.NullString  `json:"chainID"`
+	ChainIDCheck      sql.NullString  `json:"chainIDCheck"`
+	Description       sql.NullString  `json:"description"`
+	EnforceResult     sql.NullString  `json:"enforceResult"`
+	LogLimitBlocks    sql.NullInt32   `json:"logLimitBlocks"`
+	Network           sql.NullString  `json:"network"`
+	Path              sql.NullString  `json:"path"`
+	RequestTimeout    sql.NullInt32   `json:"requestTimeout"`
+	Ticker            sql.NullString  `json:"ticker"`
+	Active            sql.NullBool    `json:"active"`
+	SSyncCheck        sql.NullString  `json:"sSyncCheck"`
+	SAllowance        sql.NullInt32   `json:"sAllowance"`
+	SBody             sql.NullString  `json:"sBody"`
+	SPath             sql.NullString  `json:"sPath"`
+	SResultKey        sql.NullString  `json:"sResultKey"`
+	Redirects         json.RawMessage `json:"redirects"`
+	CreatedAt         sql.NullTime    `json:"createdAt"`
+	UpdatedAt         sql.NullTime    `json:"updatedAt"`
+}
+
+func (q *Queries) SelectBlockchains(ctx context.Context) ([]SelectBlockchainsRow, error) {
+	rows, err := q.db.QueryContext(ctx, selectBlockchains)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SelectBlockchainsRow
+	for rows.Next() {
+		var i SelectBlockchainsRow
+		if err := rows.Scan(
+			&i.BlockchainID,
+			&i.Altruist,
+			&i.Blockchain,
+			pq.Array(&i.BlockchainAliases),
+			&i.ChainID,
+			&i.ChainIDCheck,
+			&i.Description,
+			&i.EnforceResult,
+			&i.LogLimitBlocks,
+			&i.Network,
+			&i.Path,
+			&i.RequestTimeout,
+			&i.Ticker,
+			&i.Active,
+			&i.SSyncCheck,
+			&i.SAllowance,
+			&i.SBody,
+			&i.SPath,
+			&i.SResultKey,
+			&i.Redirects,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectBlockchainsUpdatedSince = `-- name: SelectBlockchainsUpdatedSince :many
+SELECT b.blockchain_id,
+    b.altruist,
+    b.blockchain,
+    b.blockchain_aliases,
+    b.chain_id,
+    b.chain_id_check,
+    b.description,
+    b.enforce_result,
+    b.log_limit_blocks,
+    b.network,
+    b.path,
+    b.request_timeout,
+    b.ticker,
+    b.active,
+    s.synccheck AS s_sync_check,
+    s.allowance AS s_allowance,
+    s.body AS s_body,
+    s.path AS s_path,
+    s.result_key AS s_result_key,
+    COALESCE(redirects.r, '[]') AS redirects,
+    b.created_at,
+    b.updated_at
+FROM blockchains AS b
+    LEFT JOIN sync_check_options AS s ON b.blockchain_id = s.blockchain_id
+    LEFT JOIN LATERAL (
+        SELECT json_agg(
+                json_build_object(
+                    'alias',
+                    r.alias,
+                    'loadBalancerID',
+                    r.loadbalancer,
+                    'domain',
+                    r.domain
+                )
+            ) AS r
+        FROM redirects AS r
+        WHERE b.blockchain_id = r.blockchain_id
+    ) redirects ON true
+WHERE b.updated_at > $1
+ORDER BY b.blockchain_id ASC
+`
+
+type SelectBlockchainsUpdatedSinceRow struct {
+	BlockchainID      string          `json:"blockchainID"`
+	Altruist          sql.NullString  `json:"altruist"`
+	Blockchain        sql.NullString  `json:"blockchain"`
+	BlockchainAliases []string        `json:"blockchainAliases"`
+	ChainID           sql.NullString  `json:"chainID"`
+	ChainIDCheck      sql.NullString  `json:"chainIDCheck"`
+	Description       sql.NullString  `json:"description"`
+	EnforceResult     sql.NullString  `json:"enforceResult"`
+	LogLimitBlocks    sql.NullInt32   `json:"logLimitBlocks"`
+	Network           sql.NullString  `json:"network"`
+	Path              sql.NullString  `json:"path"`
+	RequestTimeout    sql.NullInt32   `json:"requestTimeout"`
+	Ticker            sql.NullString  `json:"ticker"`
+	Active            sql.NullBool    `json:"active"`
+	SSyncCheck        sql.NullString  `json:"sSyncCheck"`
+	SAllowance        sql.NullInt32   `json:"sAllowance"`
+	SBody             sql.NullString  `json:"sBody"`
+	SPath             sql.NullString  `json:"sPath"`
+	SResultKey        sql.NullString  `json:"sResultKey"`
+	Redirects         json.RawMessage `json:"redirects"`
+	CreatedAt         sql.NullTime    `json:"createdAt"`
+	UpdatedAt         sql.NullTime    `json:"updatedAt"`
+}
+
+func (q *Queries) SelectBlockchainsUpdatedSince(ctx context.Context, updatedSince sql.NullTime) ([]SelectBlockchainsUpdatedSinceRow, error) {
+	rows, err := q.db.QueryContext(ctx, selectBlockchainsUpdatedSince, updatedSince)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SelectBlockchainsUpdatedSinceRow
+	for rows.Next() {
+		var i SelectBlockchainsUpdatedSinceRow
+		if err := rows.Scan(
+			&i.BlockchainID,
+			&i.Altruist,
+			&i.Blockchain,
+			pq.Array(&i.BlockchainAliases),
+			&i.ChainID,
+			&i.ChainIDCheck,
+			&i.Description,
+			&i.EnforceResult,
+			&i.LogLimitBlocks,
+			&i.Network,
+			&i.Path,
+			&i.RequestTimeout,
+			&i.Ticker,
+			&i.Active,
+			&i.SSyncCheck,
+			&i.SAllowance,
+			&i.SBody,
+			&i.SPath,
+			&i.SResultKey,
+			&i.Redirects,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectEvent = `-- name: SelectEvent :one
+SELECT sequence, table_name, action, data, previous, created_at
+FROM events
+WHERE sequence = $1
+`
+
+func (q *Queries) SelectEvent(ctx context.Context, sequence int64) (Event, error) {
+	row := q.db.QueryRowContext(ctx, selectEvent, sequence)
+	var i Event
+	err := row.Scan(
+		&i.Sequence,
+		&i.TableName,
+		&i.Action,
+		&i.Data,
+		&i.Previous,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const selectEventsSince = `-- name: SelectEventsSince :many
+SELECT sequence, table_name, action, data, previous, created_at
+FROM events
+WHERE sequence > $1
+ORDER BY sequence ASC
+LIMIT $2
+`
+
+type SelectEventsSinceParams struct {
+	Sequence   int64 `json:"sequence"`
+	MaxResults int32 `json:"maxResults"`
+}
+
+func (q *Queries) SelectEventsSince(ctx context.Context, arg SelectEventsSinceParams) ([]Event, error) {
+	rows, err := q.db.QueryContext(ctx, selectEventsSince, arg.Sequence, arg.MaxResults)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.Sequence,
+			&i.TableName,
+			&i.Action,
+			&i.Data,
+			&i.Previous,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectGatewaySettings = `-- name: SelectGatewaySettings :one
+SELECT application_id,
+    secret_key,
+    secret_key_required,
+    whitelist_blockchains,
+    whitelist_contracts,
+    whitelist_methods,
+    whitelist_origins,
+    whitelist_user_agents
+FROM gateway_settings
+WHERE application_id = $1
+`
+
+type SelectGatewaySettingsRow struct {
+	ApplicationID        string         `json:"applicationID"`
+	SecretKey            sql.NullString `json:"secretKey"`
+	SecretKeyRequired    sql.NullBool   `json:"secretKeyRequired"`
+	WhitelistBlockchains []string       `json:"whitelistBlockchains"`
+	WhitelistContracts   sql.NullString `json:"whitelistContracts"`
+	WhitelistMethods     sql.NullString `json:"whitelistMethods"`
+	WhitelistOrigins     []string       `json:"whitelistOrigins"`
+	WhitelistUserAgents  []string       `json:"whitelistUserAgents"`
+}
+
+func (q *Queries) SelectGatewaySettings(ctx context.Context, applicationID string) (SelectGatewaySettingsRow, error) {
+	row := q.db.QueryRowContext(ctx, selectGatewaySettings, applicationID)
+	var i SelectGatewaySettingsRow
+	err := row.Scan(
+		&i.ApplicationID,
+		&i.SecretKey,
 		&i.SecretKeyRequired,
 		pq.Array(&i.WhitelistBlockchains),
 		&i.WhitelistContracts,
@@ -889,7 +1529,256 @@ func (q *Queries) SelectGatewaySettings(ctx context.Context, applicationID strin
 	return i, err
 }
 
-const selectLoadBalancers = `-- name: SelectLoadBalancers :many
+const selectLoadBalancerExists = `-- name: SelectLoadBalancerExists :one
+SELECT EXISTS(
+        SELECT 1
+        FROM loadbalancers
+        WHERE lb_id = $1 FOR SHARE
+    )
+`
+
+func (q *Queries) SelectLoadBalancerExists(ctx context.Context, lbID string) (bool, error) {
+	row := q.db.QueryRowContext(ctx, selectLoadBalancerExists, lbID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const selectLoadBalancers = `-- name: SelectLoadBalancers :many
+SELECT lb.lb_id,
+    lb.name,
+    lb.request_timeout,
+    lb.gigastake,
+    lb.gigastake_redirect,
+    lb.user_id,
+    so.duration AS s_duration,
+    so.sticky_max AS s_sticky_max,
+    so.stickiness AS s_stickiness,
+    so.origins AS s_origins,
+    STRING_AGG(la.app_id, ',') AS app_ids,
+    COALESCE(user_access.ua, '[]') AS users,
+    lb.created_at,
+    lb.updated_at
+FROM loadbalancers AS lb
+    LEFT JOIN stickiness_options AS so ON lb.lb_id = so.lb_id
+    LEFT JOIN lb_apps AS la ON lb.lb_id = la.lb_id
+    LEFT JOIN LATERAL (
+        SELECT jsonb_agg(
+                json_build_object(
+                    'userID',
+                    ua.user_id,
+                    'roleName',
+                    ua.role_name,
+                    'email',
+                    ua.email,
+                    'accepted',
+                    ua.accepted
+                )
+            ) AS ua
+        FROM user_access AS ua
+        WHERE lb.lb_id = ua.lb_id
+    ) user_access ON true
+GROUP BY lb.lb_id,
+    lb.lb_id,
+    lb.name,
+    lb.created_at,
+    lb.updated_at,
+    lb.request_timeout,
+    lb.gigastake,
+    lb.gigastake_redirect,
+    lb.user_id,
+    so.duration,
+    so.sticky_max,
+    so.stickiness,
+    so.origins,
+    user_access.ua
+ORDER BY lb.lb_id ASC
+`
+
+type SelectLoadBalancersRow struct {
+	LbID              string          `json:"lbID"`
+	Name              sql.NullString  `json:"name"`
+	RequestTimeout    sql.NullInt32   `json:"requestTimeout"`
+	Gigastake         sql.NullBool    `json:"gigastake"`
+	GigastakeRedirect sql.NullBool    `json:"gigastakeRedirect"`
+	UserID            sql.NullString  `json:"userID"`
+	SDuration         sql.NullString  `json:"sDuration"`
+	SStickyMax        sql.NullInt32   `json:"sStickyMax"`
+	SStickiness       sql.NullBool    `json:"sStickiness"`
+	SOrigins          []string        `json:"sOrigins"`
+	AppIds            []byte          `json:"appIds"`
+	Users             json.RawMessage `json:"users"`
+	CreatedAt         sql.NullTime    `json:"createdAt"`
+	UpdatedAt         sql.NullTime    `json:"updatedAt"`
+}
+
+func (q *Queries) SelectLoadBalancers(ctx context.Context) ([]SelectLoadBalancersRow, error) {
+	rows, err := q.db.QueryContext(ctx, selectLoadBalancers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SelectLoadBalancersRow
+	for rows.Next() {
+		var i SelectLoadBalancersRow
+		if err := rows.Scan(
+			&i.LbID,
+			&i.Name,
+			&i.RequestTimeout,
+			&i.Gigastake,
+			&i.GigastakeRedirect,
+			&i.UserID,
+			&i.SDuration,
+			&i.SStickyMax,
+			&i.SStickiness,
+			pq.Array(&i.SOrigins),
+			&i.AppIds,
+			&i.Users,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectLoadBalancersPage = `-- name: SelectLoadBalancersPage :many
+SELECT lb.lb_id,
+    lb.name,
+    lb.request_timeout,
+    lb.gigastake,
+    lb.gigastake_redirect,
+    lb.user_id,
+    so.duration AS s_duration,
+    so.sticky_max AS s_sticky_max,
+    so.stickiness AS s_stickiness,
+    so.origins AS s_origins,
+    STRING_AGG(la.app_id, ',') AS app_ids,
+    COALESCE(user_access.ua, '[]') AS users,
+    lb.created_at,
+    lb.updated_at
+FROM loadbalancers AS lb
+    LEFT JOIN stickiness_options AS so ON lb.lb_id = so.lb_id
+    LEFT JOIN lb_apps AS la ON lb.lb_id = la.lb_id
+    LEFT JOIN LATERAL (
+        SELECT jsonb_agg(
+                json_build_object(
+                    'userID',
+                    ua.user_id,
+                    'roleName',
+                    ua.role_name,
+                    'email',
+                    ua.email,
+                    'accepted',
+                    ua.accepted
+                )
+            ) AS ua
+        FROM user_access AS ua
+        WHERE lb.lb_id = ua.lb_id
+    ) user_access ON true
+WHERE lb.lb_id > $1
+    AND (
+        $2::VARCHAR IS NULL
+        OR lb.user_id = $2
+    )
+    AND (
+        $3::TIMESTAMP IS NULL
+        OR lb.created_at > $3
+    )
+GROUP BY lb.lb_id,
+    lb.lb_id,
+    lb.name,
+    lb.created_at,
+    lb.updated_at,
+    lb.request_timeout,
+    lb.gigastake,
+    lb.gigastake_redirect,
+    lb.user_id,
+    so.duration,
+    so.sticky_max,
+    so.stickiness,
+    so.origins,
+    user_access.ua
+ORDER BY lb.lb_id ASC
+LIMIT $4
+`
+
+type SelectLoadBalancersPageParams struct {
+	Cursor       string         `json:"cursor"`
+	UserID       sql.NullString `json:"userID"`
+	CreatedAfter sql.NullTime   `json:"createdAfter"`
+	MaxResults   int32          `json:"maxResults"`
+}
+
+type SelectLoadBalancersPageRow struct {
+	LbID              string          `json:"lbID"`
+	Name              sql.NullString  `json:"name"`
+	RequestTimeout    sql.NullInt32   `json:"requestTimeout"`
+	Gigastake         sql.NullBool    `json:"gigastake"`
+	GigastakeRedirect sql.NullBool    `json:"gigastakeRedirect"`
+	UserID            sql.NullString  `json:"userID"`
+	SDuration         sql.NullString  `json:"sDuration"`
+	SStickyMax        sql.NullInt32   `json:"sStickyMax"`
+	SStickiness       sql.NullBool    `json:"sStickiness"`
+	SOrigins          []string        `json:"sOrigins"`
+	AppIds            []byte          `json:"appIds"`
+	Users             json.RawMessage `json:"users"`
+	CreatedAt         sql.NullTime    `json:"createdAt"`
+	UpdatedAt         sql.NullTime    `json:"updatedAt"`
+}
+
+func (q *Queries) SelectLoadBalancersPage(ctx context.Context, arg SelectLoadBalancersPageParams) ([]SelectLoadBalancersPageRow, error) {
+	rows, err := q.db.QueryContext(ctx, selectLoadBalancersPage,
+		arg.Cursor,
+		arg.UserID,
+		arg.CreatedAfter,
+		arg.MaxResults,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SelectLoadBalancersPageRow
+	for rows.Next() {
+		var i SelectLoadBalancersPageRow
+		if err := rows.Scan(
+			&i.LbID,
+			&i.Name,
+			&i.RequestTimeout,
+			&i.Gigastake,
+			&i.GigastakeRedirect,
+			&i.UserID,
+			&i.SDuration,
+			&i.SStickyMax,
+			&i.SStickiness,
+			pq.Array(&i.SOrigins),
+			&i.AppIds,
+			&i.Users,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectLoadBalancersUpdatedSince = `-- name: SelectLoadBalancersUpdatedSince :many
 SELECT lb.lb_id,
     lb.name,
     lb.request_timeout,
@@ -923,6 +1812,7 @@ FROM loadbalancers AS lb
         FROM user_access AS ua
         WHERE lb.lb_id = ua.lb_id
     ) user_access ON true
+WHERE lb.updated_at > $1
 GROUP BY lb.lb_id,
     lb.lb_id,
     lb.name,
@@ -940,7 +1830,7 @@ GROUP BY lb.lb_id,
 ORDER BY lb.lb_id ASC
 `
 
-type SelectLoadBalancersRow struct {
+type SelectLoadBalancersUpdatedSinceRow struct {
 	LbID              string          `json:"lbID"`
 	Name              sql.NullString  `json:"name"`
 	RequestTimeout    sql.NullInt32   `json:"requestTimeout"`
@@ -957,15 +1847,15 @@ type SelectLoadBalancersRow struct {
 	UpdatedAt         sql.NullTime    `json:"updatedAt"`
 }
 
-func (q *Queries) SelectLoadBalancers(ctx context.Context) ([]SelectLoadBalancersRow, error) {
-	rows, err := q.db.QueryContext(ctx, selectLoadBalancers)
+func (q *Queries) SelectLoadBalancersUpdatedSince(ctx context.Context, updatedSince sql.NullTime) ([]SelectLoadBalancersUpdatedSinceRow, error) {
+	rows, err := q.db.QueryContext(ctx, selectLoadBalancersUpdatedSince, updatedSince)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []SelectLoadBalancersRow
+	var items []SelectLoadBalancersUpdatedSinceRow
 	for rows.Next() {
-		var i SelectLoadBalancersRow
+		var i SelectLoadBalancersUpdatedSinceRow
 		if err := rows.Scan(
 			&i.LbID,
 			&i.Name,
@@ -1150,6 +2040,103 @@ func (q *Queries) SelectOneApplication(ctx context.Context, applicationID string
 	return i, err
 }
 
+const selectOneBlockchain = `-- name: SelectOneBlockchain :one
+SELECT b.blockchain_id,
+    b.altruist,
+    b.blockchain,
+    b.blockchain_aliases,
+    b.chain_id,
+    b.chain_id_check,
+    b.description,
+    b.enforce_result,
+    b.log_limit_blocks,
+    b.network,
+    b.path,
+    b.request_timeout,
+    b.ticker,
+    b.active,
+    s.synccheck AS s_sync_check,
+    s.allowance AS s_allowance,
+    s.body AS s_body,
+    s.path AS s_path,
+    s.result_key AS s_result_key,
+    COALESCE(redirects.r, '[]') AS redirects,
+    b.created_at,
+    b.updated_at
+FROM blockchains AS b
+    LEFT JOIN sync_check_options AS s ON b.blockchain_id = s.blockchain_id
+    LEFT JOIN LATERAL (
+        SELECT json_agg(
+                json_build_object(
+                    'alias',
+                    r.alias,
+                    'loadBalancerID',
+                    r.loadbalancer,
+                    'domain',
+                    r.domain
+                )
+            ) AS r
+        FROM redirects AS r
+        WHERE b.blockchain_id = r.blockchain_id
+    ) redirects ON true
+WHERE b.blockchain_id = $1
+`
+
+type SelectOneBlockchainRow struct {
+	BlockchainID      string          `json:"blockchainID"`
+	Altruist          sql.NullString  `json:"altruist"`
+	Blockchain        sql.NullString  `json:"blockchain"`
+	BlockchainAliases []string        `json:"blockchainAliases"`
+	ChainID           sql.NullString  `json:"chainID"`
+	ChainIDCheck      sql.NullString  `json:"chainIDCheck"`
+	Description       sql.NullString  `json:"description"`
+	EnforceResult     sql.NullString  `json:"enforceResult"`
+	LogLimitBlocks    sql.NullInt32   `json:"logLimitBlocks"`
+	Network           sql.NullString  `json:"network"`
+	Path              sql.NullString  `json:"path"`
+	RequestTimeout    sql.NullInt32   `json:"requestTimeout"`
+	Ticker            sql.NullString  `json:"ticker"`
+	Active            sql.NullBool    `json:"active"`
+	SSyncCheck        sql.NullString  `json:"sSyncCheck"`
+	SAllowance        sql.NullInt32   `json:"sAllowance"`
+	SBody             sql.NullString  `json:"sBody"`
+	SPath             sql.NullString  `json:"sPath"`
+	SResultKey        sql.NullString  `json:"sResultKey"`
+	Redirects         json.RawMessage `json:"redirects"`
+	CreatedAt         sql.NullTime    `json:"createdAt"`
+	UpdatedAt         sql.NullTime    `json:"updatedAt"`
+}
+
+func (q *Queries) SelectOneBlockchain(ctx context.Context, blockchainID string) (SelectOneBlockchainRow, error) {
+	row := q.db.QueryRowContext(ctx, selectOneBlockchain, blockchainID)
+	var i SelectOneBlockchainRow
+	err := row.Scan(
+		&i.BlockchainID,
+		&i.Altruist,
+		&i.Blockchain,
+		pq.Array(&i.BlockchainAliases),
+		&i.ChainID,
+		&i.ChainIDCheck,
+		&i.Description,
+		&i.EnforceResult,
+		&i.LogLimitBlocks,
+		&i.Network,
+		&i.Path,
+		&i.RequestTimeout,
+		&i.Ticker,
+		&i.Active,
+		&i.SSyncCheck,
+		&i.SAllowance,
+		&i.SBody,
+		&i.SPath,
+		&i.SResultKey,
+		&i.Redirects,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const selectOneLoadBalancer = `-- name: SelectOneLoadBalancer :one
 SELECT lb.lb_id,
     lb.name,
@@ -1275,6 +2262,70 @@ func (q *Queries) SelectPayPlans(ctx context.Context) ([]SelectPayPlansRow, erro
 	return items, nil
 }
 
+const selectRedirects = `-- name: SelectRedirects :many
+SELECT blockchain_id,
+    alias,
+    loadbalancer,
+    domain,
+    created_at,
+    updated_at
+FROM redirects
+WHERE (
+        $1::VARCHAR IS NULL
+        OR blockchain_id = $1
+    )
+    AND (
+        $2::VARCHAR IS NULL
+        OR loadbalancer = $2
+    )
+ORDER BY blockchain_id ASC,
+    domain ASC
+`
+
+type SelectRedirectsParams struct {
+	BlockchainID sql.NullString `json:"blockchainID"`
+	Loadbalancer sql.NullString `json:"loadbalancer"`
+}
+
+type SelectRedirectsRow struct {
+	BlockchainID string       `json:"blockchainID"`
+	Alias        string       `json:"alias"`
+	Loadbalancer string       `json:"loadbalancer"`
+	Domain       string       `json:"domain"`
+	CreatedAt    sql.NullTime `json:"createdAt"`
+	UpdatedAt    sql.NullTime `json:"updatedAt"`
+}
+
+func (q *Queries) SelectRedirects(ctx context.Context, arg SelectRedirectsParams) ([]SelectRedirectsRow, error) {
+	rows, err := q.db.QueryContext(ctx, selectRedirects, arg.BlockchainID, arg.Loadbalancer)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SelectRedirectsRow
+	for rows.Next() {
+		var i SelectRedirectsRow
+		if err := rows.Scan(
+			&i.BlockchainID,
+			&i.Alias,
+			&i.Loadbalancer,
+			&i.Domain,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const selectUserRoles = `-- name: SelectUserRoles :many
 SELECT ua.lb_id,
     ua.user_id,
@@ -1312,9 +2363,102 @@ func (q *Queries) SelectUserRoles(ctx context.Context) ([]SelectUserRolesRow, er
 	return items, nil
 }
 
+const selectUserRolesUpdatedSince = `-- name: SelectUserRolesUpdatedSince :many
+SELECT lb.lb_id,
+    ua.user_id,
+    ur.permissions as permissions
+FROM loadbalancers as lb
+    LEFT JOIN user_access AS ua ON ua.lb_id = lb.lb_id
+    LEFT JOIN user_roles AS ur ON ua.role_name = ur.name
+WHERE lb.updated_at > $1
+`
+
+type SelectUserRolesUpdatedSinceRow struct {
+	LbID        string                  `json:"lbID"`
+	UserID      sql.NullString          `json:"userID"`
+	Permissions []types.PermissionsEnum `json:"permissions"`
+}
+
+func (q *Queries) SelectUserRolesUpdatedSince(ctx context.Context, updatedSince sql.NullTime) ([]SelectUserRolesUpdatedSinceRow, error) {
+	rows, err := q.db.QueryContext(ctx, selectUserRolesUpdatedSince, updatedSince)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SelectUserRolesUpdatedSinceRow
+	for rows.Next() {
+		var i SelectUserRolesUpdatedSinceRow
+		if err := rows.Scan(&i.LbID, &i.UserID, pq.Array(&i.Permissions)); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateBlockchain = `-- name: UpdateBlockchain :exec
+UPDATE blockchains AS b
+SET altruist = COALESCE($2, b.altruist),
+    blockchain = COALESCE($3, b.blockchain),
+    chain_id = COALESCE($4, b.chain_id),
+    chain_id_check = COALESCE($5, b.chain_id_check),
+    description = COALESCE($6, b.description),
+    enforce_result = COALESCE($7, b.enforce_result),
+    network = COALESCE($8, b.network),
+    path = COALESCE($9, b.path),
+    ticker = COALESCE($10, b.ticker),
+    blockchain_aliases = COALESCE($11, b.blockchain_aliases),
+    log_limit_blocks = COALESCE($12, b.log_limit_blocks),
+    request_timeout = COALESCE($13, b.request_timeout),
+    updated_at = NOW()
+WHERE b.blockchain_id = $1
+`
+
+type UpdateBlockchainParams struct {
+	BlockchainID      string         `json:"blockchainID"`
+	Altruist          sql.NullString `json:"altruist"`
+	Blockchain        sql.NullString `json:"blockchain"`
+	ChainID           sql.NullString `json:"chainID"`
+	ChainIDCheck      sql.NullString `json:"chainIDCheck"`
+	Description       sql.NullString `json:"description"`
+	EnforceResult     sql.NullString `json:"enforceResult"`
+	Network           sql.NullString `json:"network"`
+	Path              sql.NullString `json:"path"`
+	Ticker            sql.NullString `json:"ticker"`
+	BlockchainAliases []string       `json:"blockchainAliases"`
+	LogLimitBlocks    sql.NullInt32  `json:"logLimitBlocks"`
+	RequestTimeout    sql.NullInt32  `json:"requestTimeout"`
+}
+
+func (q *Queries) UpdateBlockchain(ctx context.Context, arg UpdateBlockchainParams) error {
+	_, err := q.db.ExecContext(ctx, updateBlockchain,
+		arg.BlockchainID,
+		arg.Altruist,
+		arg.Blockchain,
+		arg.ChainID,
+		arg.ChainIDCheck,
+		arg.Description,
+		arg.EnforceResult,
+		arg.Network,
+		arg.Path,
+		arg.Ticker,
+		pq.Array(arg.BlockchainAliases),
+		arg.LogLimitBlocks,
+		arg.RequestTimeout,
+	)
+	return err
+}
+
 const updateFirstDateSurpassed = `-- name: UpdateFirstDateSurpassed :exec
 UPDATE applications
-SET first_date_surpassed = $1
+SET first_date_surpassed = $1,
+    updated_at = NOW()
 WHERE application_id = ANY ($2::VARCHAR [])
 `
 
@@ -1331,43 +2475,68 @@ func (q *Queries) UpdateFirstDateSurpassed(ctx context.Context, arg UpdateFirstD
 const updateLB = `-- name: UpdateLB :exec
 UPDATE loadbalancers AS l
 SET name = COALESCE($2, l.name),
-    updated_at = $3
+    updated_at = NOW()
 WHERE l.lb_id = $1
 `
 
 type UpdateLBParams struct {
-	LbID      string         `json:"lbID"`
-	Name      sql.NullString `json:"name"`
-	UpdatedAt sql.NullTime   `json:"updatedAt"`
+	LbID string         `json:"lbID"`
+	Name sql.NullString `json:"name"`
 }
 
 func (q *Queries) UpdateLB(ctx context.Context, arg UpdateLBParams) error {
-	_, err := q.db.ExecContext(ctx, updateLB, arg.LbID, arg.Name, arg.UpdatedAt)
+	_, err := q.db.ExecContext(ctx, updateLB, arg.LbID, arg.Name)
 	return err
 }
 
+const updateRedirect = `-- name: UpdateRedirect :execrows
+UPDATE redirects AS r
+SET alias = COALESCE($1, r.alias),
+    domain = COALESCE($2, r.domain),
+    loadbalancer = COALESCE($3, r.loadbalancer),
+    updated_at = NOW()
+WHERE r.blockchain_id = $4
+    AND r.domain = $5
+`
+
+type UpdateRedirectParams struct {
+	Alias         sql.NullString `json:"alias"`
+	Domain        sql.NullString `json:"domain"`
+	Loadbalancer  sql.NullString `json:"loadbalancer"`
+	BlockchainID  string         `json:"blockchainID"`
+	CurrentDomain string         `json:"currentDomain"`
+}
+
+func (q *Queries) UpdateRedirect(ctx context.Context, arg UpdateRedirectParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateRedirect,
+		arg.Alias,
+		arg.Domain,
+		arg.Loadbalancer,
+		arg.BlockchainID,
+		arg.CurrentDomain,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateUserAccess = `-- name: UpdateUserAccess :exec
 UPDATE user_access as ua
 SET role_name = COALESCE($3, ua.role_name),
-    updated_at = $4
+    updated_at = NOW()
 WHERE ua.user_id = $1
     AND ua.lb_id = $2
 `
 
 type UpdateUserAccessParams struct {
-	UserID    sql.NullString `json:"userID"`
-	LbID      sql.NullString `json:"lbID"`
-	RoleName  sql.NullString `json:"roleName"`
-	UpdatedAt sql.NullTime   `json:"updatedAt"`
+	UserID   sql.NullString `json:"userID"`
+	LbID     sql.NullString `json:"lbID"`
+	RoleName sql.NullString `json:"roleName"`
 }
 
 func (q *Queries) UpdateUserAccess(ctx context.Context, arg UpdateUserAccessParams) error {
-	_, err := q.db.ExecContext(ctx, updateUserAccess,
-		arg.UserID,
-		arg.LbID,
-		arg.RoleName,
-		arg.UpdatedAt,
-	)
+	_, err := q.db.ExecContext(ctx, updateUserAccess, arg.UserID, arg.LbID, arg.RoleName)
 	return err
 }
 
@@ -1402,14 +2571,15 @@ INSERT INTO applications AS a (
         first_date_surpassed,
         updated_at
     )
-VALUES ($1, $2, $3, $4, $5) ON CONFLICT (application_id) DO
+VALUES ($1, $2, $3, $4, NOW()) ON CONFLICT (application_id) DO
 UPDATE
 SET name = COALESCE(EXCLUDED.name, a.name),
     status = COALESCE(EXCLUDED.status, a.status),
     first_date_surpassed = COALESCE(
         EXCLUDED.first_date_surpassed,
         a.first_date_surpassed
-    )
+    ),
+    updated_at = EXCLUDED.updated_at
 `
 
 type UpsertApplicationParams struct {
@@ -1417,7 +2587,6 @@ type UpsertApplicationParams struct {
 	Name               sql.NullString `json:"name"`
 	Status             sql.NullString `json:"status"`
 	FirstDateSurpassed sql.NullTime   `json:"firstDateSurpassed"`
-	UpdatedAt          sql.NullTime   `json:"updatedAt"`
 }
 
 func (q *Queries) UpsertApplication(ctx context.Context, arg UpsertApplicationParams) error {
@@ -1426,7 +2595,6 @@ func (q *Queries) UpsertApplication(ctx context.Context, arg UpsertApplicationPa
 		arg.Name,
 		arg.Status,
 		arg.FirstDateSurpassed,
-		arg.UpdatedAt,
 	)
 	return err
 }
@@ -1563,3 +2731,42 @@ func (q *Queries) UpsertStickinessOptions(ctx context.Context, arg UpsertStickin
 	)
 	return err
 }
+
+const upsertSyncCheckOptions = `-- name: UpsertSyncCheckOptions :exec
+INSERT INTO sync_check_options AS sco (
+        blockchain_id,
+        synccheck,
+        allowance,
+        body,
+        path,
+        result_key
+    )
+VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT (blockchain_id) DO
+UPDATE
+SET synccheck = COALESCE(EXCLUDED.synccheck, sco.synccheck),
+    allowance = COALESCE(EXCLUDED.allowance, sco.allowance),
+    body = COALESCE(EXCLUDED.body, sco.body),
+    path = COALESCE(EXCLUDED.path, sco.path),
+    result_key = COALESCE(EXCLUDED.result_key, sco.result_key)
+`
+
+type UpsertSyncCheckOptionsParams struct {
+	BlockchainID string         `json:"blockchainID"`
+	Synccheck    sql.NullString `json:"synccheck"`
+	Allowance    sql.NullInt32  `json:"allowance"`
+	Body         sql.NullString `json:"body"`
+	Path         sql.NullString `json:"path"`
+	ResultKey    sql.NullString `json:"resultKey"`
+}
+
+func (q *Queries) UpsertSyncCheckOptions(ctx context.Context, arg UpsertSyncCheckOptionsParams) error {
+	_, err := q.db.ExecContext(ctx, upsertSyncCheckOptions,
+		arg.BlockchainID,
+		arg.Synccheck,
+		arg.Allowance,
+		arg.Body,
+		arg.Path,
+		arg.ResultKey,
+	)
+	return err
+}
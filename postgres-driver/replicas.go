@@ -0,0 +1,158 @@
+package postgresdriver
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	defaultReplicaEjection = 30 * time.Second
+)
+
+type (
+	/* replica is a read replica, it gets no reads until ejectedUntil once it failed with a connection error */
+	replica struct {
+		db      *sql.DB
+		queries *Queries
+		// ejectedUntil holds Unix nanoseconds, it is accessed atomically
+		ejectedUntil int64
+	}
+
+	/* replicas routes reads to the healthy replicas in round robin */
+	replicas struct {
+		list     []*replica
+		next     uint64
+		ejection time.Duration
+	}
+
+	primaryKey struct{}
+)
+
+// WithReadReplicas sends the Reader methods to the replicas at the connection strings in round robin.
+// Writes, events and the entities of aggregated notifications stay on the primary so they are never stale
+func WithReadReplicas(connectionStrings ...string) Option {
+	return func(d *PostgresDriver) {
+		d.replicaConnectionStrings = append(d.replicaConnectionStrings, connectionStrings...)
+	}
+}
+
+/* WithReplicaEjection sets how long a replica failing with a connection error gets no reads, 30s by default */
+func WithReplicaEjection(period time.Duration) Option {
+	return func(d *PostgresDriver) {
+		d.replicas.ejection = period
+	}
+}
+
+// ReadFromPrimary returns a context sending the reads made with it to the primary,
+// for callers that must read their own writes while the replicas may lag behind
+func ReadFromPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+func readsFromPrimary(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryKey{}).(bool)
+	return primary
+}
+
+/* openReplicas opens the databases of the replicas' connection strings */
+func (d *PostgresDriver) openReplicas() error {
+	for _, connectionString := range d.replicaConnectionStrings {
+		db, err := sql.Open("postgres", connectionString)
+		if err != nil {
+			_ = d.closeReplicas()
+			return err
+		}
+
+		d.replicas.add(db)
+	}
+
+	return nil
+}
+
+func (d *PostgresDriver) closeReplicas() error {
+	var closeErr error
+
+	for _, r := range d.replicas.list {
+		if err := r.db.Close(); err != nil && closeErr == nil {
+			closeErr = err
+		}
+	}
+
+	return closeErr
+}
+
+func (r *replicas) add(db *sql.DB) {
+	r.list = append(r.list, &replica{db: db, queries: New(db)})
+}
+
+/* pick returns the next healthy replica, or nil when reads must go to the primary */
+func (r *replicas) pick(ctx context.Context, now time.Time) *replica {
+	if len(r.list) == 0 || readsFromPrimary(ctx) {
+		return nil
+	}
+
+	start := atomic.AddUint64(&r.next, 1) - 1
+	for i := range r.list {
+		candidate := r.list[(start+uint64(i))%uint64(len(r.list))]
+		if atomic.LoadInt64(&candidate.ejectedUntil) <= now.UnixNano() {
+			return candidate
+		}
+	}
+
+	return nil
+}
+
+func (r *replicas) eject(candidate *replica, now time.Time) {
+	ejection := r.ejection
+	if ejection == 0 {
+		ejection = defaultReplicaEjection
+	}
+
+	atomic.StoreInt64(&candidate.ejectedUntil, now.Add(ejection).UnixNano())
+}
+
+// read runs query against the next healthy replica, or against the primary when there is none or ctx requires it.
+// A replica failing with a connection error is ejected and the query is retried on the primary
+func read[T any](ctx context.Context, d *PostgresDriver, query func(q *Queries) (T, error)) (T, error) {
+	candidate := d.replicas.pick(ctx, time.Now())
+	if candidate == nil {
+		return query(d.Queries)
+	}
+
+	result, err := query(candidate.queries)
+	if err == nil || ctx.Err() != nil || !isConnectionError(err) {
+		return result, err
+	}
+
+	d.replicas.eject(candidate, time.Now())
+
+	return query(d.Queries)
+}
+
+/* isConnectionError returns true if err means the database could not be reached or is not serving queries */
+func isConnectionError(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		// Class 08 holds the connection exceptions and 57P the shutdowns and a database that is starting up
+		return pqErr.Code.Class() == "08" || strings.HasPrefix(string(pqErr.Code), "57P")
+	}
+
+	return false
+}
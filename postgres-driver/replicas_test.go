@@ -0,0 +1,136 @@
+package postgresdriver
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func newReplicatedDriver(replicas int) *PostgresDriver {
+	d := &PostgresDriver{Queries: New(nil)}
+	for i := 0; i < replicas; i++ {
+		d.replicas.list = append(d.replicas.list, &replica{queries: New(nil)})
+	}
+
+	return d
+}
+
+/* readFrom returns the index of the replica the read went to, -1 for the primary */
+func readFrom(ctx context.Context, d *PostgresDriver, fail func(q *Queries) error) (int, error) {
+	return read(ctx, d, func(q *Queries) (int, error) {
+		if fail != nil {
+			if err := fail(q); err != nil {
+				return 0, err
+			}
+		}
+
+		for i, r := range d.replicas.list {
+			if r.queries == q {
+				return i, nil
+			}
+		}
+
+		return -1, nil
+	})
+}
+
+func TestReadReplicas(t *testing.T) {
+	c := require.New(t)
+	ctx := context.Background()
+
+	d := newReplicatedDriver(2)
+
+	var reads []int
+	for i := 0; i < 4; i++ {
+		index, err := readFrom(ctx, d, nil)
+		c.NoError(err)
+		reads = append(reads, index)
+	}
+	c.Equal([]int{0, 1, 0, 1}, reads)
+
+	index, err := readFrom(ReadFromPrimary(ctx), d, nil)
+	c.NoError(err)
+	c.Equal(-1, index)
+
+	index, err = readFrom(ctx, newReplicatedDriver(0), nil)
+	c.NoError(err)
+	c.Equal(-1, index)
+}
+
+func TestReadReplicasEjection(t *testing.T) {
+	c := require.New(t)
+	ctx := context.Background()
+
+	d := newReplicatedDriver(2)
+	failing := d.replicas.list[0].queries
+
+	// the read failing on a replica is retried on the primary
+	index, err := readFrom(ctx, d, func(q *Queries) error {
+		if q == failing {
+			return fmt.Errorf("select: %w", driver.ErrBadConn)
+		}
+		return nil
+	})
+	c.NoError(err)
+	c.Equal(-1, index)
+
+	for i := 0; i < 3; i++ {
+		index, err = readFrom(ctx, d, nil)
+		c.NoError(err)
+		c.Equal(1, index)
+	}
+
+	// the ejected replica gets reads again once the ejection period is over
+	c.Equal(d.replicas.list[0], d.replicas.pick(ctx, time.Now().Add(defaultReplicaEjection)))
+
+	d.replicas.eject(d.replicas.list[1], time.Now())
+	index, err = readFrom(ctx, d, nil)
+	c.NoError(err)
+	c.Equal(-1, index)
+}
+
+func TestReadReplicasQueryError(t *testing.T) {
+	c := require.New(t)
+	ctx := context.Background()
+
+	d := newReplicatedDriver(1)
+
+	// errors of the query itself are returned without ejecting the replica
+	_, err := readFrom(ctx, d, func(*Queries) error { return sql.ErrNoRows })
+	c.ErrorIs(err, sql.ErrNoRows)
+
+	index, err := readFrom(ctx, d, nil)
+	c.NoError(err)
+	c.Equal(0, index)
+}
+
+func TestIsConnectionError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "bad connection", err: driver.ErrBadConn, expected: true},
+		{name: "closed connection", err: sql.ErrConnDone, expected: true},
+		{name: "network error", err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}, expected: true},
+		{name: "connection failure", err: &pq.Error{Code: "08006"}, expected: true},
+		{name: "database shutting down", err: &pq.Error{Code: "57P01"}, expected: true},
+		{name: "query canceled", err: &pq.Error{Code: "57014"}, expected: false},
+		{name: "undefined table", err: &pq.Error{Code: "42P01"}, expected: false},
+		{name: "no rows", err: sql.ErrNoRows, expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, isConnectionError(test.err))
+		})
+	}
+}
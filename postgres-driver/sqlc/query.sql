@@ -38,12 +38,93 @@ FROM blockchains AS b
         WHERE b.blockchain_id = r.blockchain_id
     ) redirects ON true
 ORDER BY b.blockchain_id ASC;
+-- name: SelectBlockchainsUpdatedSince :many
+SELECT b.blockchain_id,
+    b.altruist,
+    b.blockchain,
+    b.blockchain_aliases,
+    b.chain_id,
+    b.chain_id_check,
+    b.description,
+    b.enforce_result,
+    b.log_limit_blocks,
+    b.network,
+    b.path,
+    b.request_timeout,
+    b.ticker,
+    b.active,
+    s.synccheck AS s_sync_check,
+    s.allowance AS s_allowance,
+    s.body AS s_body,
+    s.path AS s_path,
+    s.result_key AS s_result_key,
+    COALESCE(redirects.r, '[]') AS redirects,
+    b.created_at,
+    b.updated_at
+FROM blockchains AS b
+    LEFT JOIN sync_check_options AS s ON b.blockchain_id = s.blockchain_id
+    LEFT JOIN LATERAL (
+        SELECT json_agg(
+                json_build_object(
+                    'alias',
+                    r.alias,
+                    'loadBalancerID',
+                    r.loadbalancer,
+                    'domain',
+                    r.domain
+                )
+            ) AS r
+        FROM redirects AS r
+        WHERE b.blockchain_id = r.blockchain_id
+    ) redirects ON true
+WHERE b.updated_at > @updated_since
+ORDER BY b.blockchain_id ASC;
+-- name: SelectOneBlockchain :one
+SELECT b.blockchain_id,
+    b.altruist,
+    b.blockchain,
+    b.blockchain_aliases,
+    b.chain_id,
+    b.chain_id_check,
+    b.description,
+    b.enforce_result,
+    b.log_limit_blocks,
+    b.network,
+    b.path,
+    b.request_timeout,
+    b.ticker,
+    b.active,
+    s.synccheck AS s_sync_check,
+    s.allowance AS s_allowance,
+    s.body AS s_body,
+    s.path AS s_path,
+    s.result_key AS s_result_key,
+    COALESCE(redirects.r, '[]') AS redirects,
+    b.created_at,
+    b.updated_at
+FROM blockchains AS b
+    LEFT JOIN sync_check_options AS s ON b.blockchain_id = s.blockchain_id
+    LEFT JOIN LATERAL (
+        SELECT json_agg(
+                json_build_object(
+                    'alias',
+                    r.alias,
+                    'loadBalancerID',
+                    r.loadbalancer,
+                    'domain',
+                    r.domain
+                )
+            ) AS r
+        FROM redirects AS r
+        WHERE b.blockchain_id = r.blockchain_id
+    ) redirects ON true
+WHERE b.blockchain_id = $1;
 -- name: SelectPayPlans :many
 SELECT plan_type,
     daily_limit
 FROM pay_plans
 ORDER BY plan_type ASC;
--- name: InsertBlockchain :exec
+-- name: InsertBlockchain :one
 INSERT into blockchains (
         blockchain_id,
         active,
@@ -78,9 +159,10 @@ VALUES (
         $13,
         $14,
         $15,
-        $16
-    );
--- name: InsertRedirect :exec
+        NOW()
+    )
+RETURNING updated_at;
+-- name: InsertRedirect :one
 INSERT into redirects (
         blockchain_id,
         alias,
@@ -95,8 +177,9 @@ VALUES (
         $3,
         $4,
         $5,
-        $6
-    );
+        NOW()
+    )
+RETURNING updated_at;
 -- name: InsertSyncCheckOptions :exec
 INSERT into sync_check_options (
         blockchain_id,
@@ -117,8 +200,94 @@ VALUES (
 -- name: ActivateBlockchain :exec
 UPDATE blockchains
 SET active = $2,
-    updated_at = $3
+    updated_at = NOW()
 WHERE blockchain_id = $1;
+-- name: LockBlockchain :one
+SELECT updated_at
+FROM blockchains
+WHERE blockchain_id = $1 FOR
+UPDATE;
+-- name: UpdateBlockchain :exec
+UPDATE blockchains AS b
+SET altruist = COALESCE($2, b.altruist),
+    blockchain = COALESCE($3, b.blockchain),
+    chain_id = COALESCE($4, b.chain_id),
+    chain_id_check = COALESCE($5, b.chain_id_check),
+    description = COALESCE($6, b.description),
+    enforce_result = COALESCE($7, b.enforce_result),
+    network = COALESCE($8, b.network),
+    path = COALESCE($9, b.path),
+    ticker = COALESCE($10, b.ticker),
+    blockchain_aliases = COALESCE($11, b.blockchain_aliases),
+    log_limit_blocks = COALESCE($12, b.log_limit_blocks),
+    request_timeout = COALESCE($13, b.request_timeout),
+    updated_at = NOW()
+WHERE b.blockchain_id = $1;
+-- name: UpsertSyncCheckOptions :exec
+INSERT INTO sync_check_options AS sco (
+        blockchain_id,
+        synccheck,
+        allowance,
+        body,
+        path,
+        result_key
+    )
+VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT (blockchain_id) DO
+UPDATE
+SET synccheck = COALESCE(EXCLUDED.synccheck, sco.synccheck),
+    allowance = COALESCE(EXCLUDED.allowance, sco.allowance),
+    body = COALESCE(EXCLUDED.body, sco.body),
+    path = COALESCE(EXCLUDED.path, sco.path),
+    result_key = COALESCE(EXCLUDED.result_key, sco.result_key);
+-- name: CountBlockchainRedirects :one
+SELECT COUNT(*)
+FROM redirects
+WHERE blockchain_id = $1;
+-- name: SelectBlockchainWhitelists :many
+SELECT application_id,
+    whitelist_blockchains,
+    whitelist_contracts,
+    whitelist_methods
+FROM gateway_settings
+WHERE @blockchain_id::VARCHAR = ANY(whitelist_blockchains)
+    OR whitelist_contracts LIKE '%' || @blockchain_id || '%'
+    OR whitelist_methods LIKE '%' || @blockchain_id || '%';
+-- name: DeleteSyncCheckOptions :exec
+DELETE FROM sync_check_options
+WHERE blockchain_id = $1;
+-- name: DeleteBlockchain :exec
+DELETE FROM blockchains
+WHERE blockchain_id = $1;
+-- name: SelectRedirects :many
+SELECT blockchain_id,
+    alias,
+    loadbalancer,
+    domain,
+    created_at,
+    updated_at
+FROM redirects
+WHERE (
+        sqlc.narg('blockchain_id')::VARCHAR IS NULL
+        OR blockchain_id = sqlc.narg('blockchain_id')
+    )
+    AND (
+        sqlc.narg('loadbalancer')::VARCHAR IS NULL
+        OR loadbalancer = sqlc.narg('loadbalancer')
+    )
+ORDER BY blockchain_id ASC,
+    domain ASC;
+-- name: UpdateRedirect :execrows
+UPDATE redirects AS r
+SET alias = COALESCE(sqlc.narg('alias'), r.alias),
+    domain = COALESCE(sqlc.narg('domain'), r.domain),
+    loadbalancer = COALESCE(sqlc.narg('loadbalancer'), r.loadbalancer),
+    updated_at = NOW()
+WHERE r.blockchain_id = @blockchain_id
+    AND r.domain = @current_domain;
+-- name: DeleteRedirect :execrows
+DELETE FROM redirects
+WHERE blockchain_id = $1
+    AND domain = $2;
 -- name: SelectApplications :many
 SELECT a.application_id,
     a.contact_email,
@@ -160,6 +329,111 @@ FROM applications AS a
     LEFT JOIN app_limits AS al ON a.application_id = al.application_id
     LEFT JOIN pay_plans AS pp ON al.pay_plan = pp.plan_type
 ORDER BY a.application_id ASC;
+-- name: SelectApplicationsUpdatedSince :many
+SELECT a.application_id,
+    a.contact_email,
+    a.description,
+    a.dummy,
+    a.name,
+    a.owner,
+    a.status,
+    a.url,
+    a.user_id,
+    a.first_date_surpassed,
+    ga.address AS ga_address,
+    ga.client_public_key AS ga_client_public_key,
+    ga.private_key AS ga_private_key,
+    ga.public_key AS ga_public_key,
+    ga.signature AS ga_signature,
+    ga.version AS ga_version,
+    gs.secret_key,
+    gs.secret_key_required,
+    gs.whitelist_blockchains,
+    gs.whitelist_contracts,
+    gs.whitelist_methods,
+    gs.whitelist_origins,
+    gs.whitelist_user_agents,
+    ns.signed_up,
+    ns.on_quarter,
+    ns.on_half,
+    ns.on_three_quarters,
+    ns.on_full,
+    al.custom_limit,
+    al.pay_plan,
+    pp.daily_limit AS plan_limit,
+    a.created_at,
+    a.updated_at
+FROM applications AS a
+    LEFT JOIN gateway_aat AS ga ON a.application_id = ga.application_id
+    LEFT JOIN gateway_settings AS gs ON a.application_id = gs.application_id
+    LEFT JOIN notification_settings AS ns ON a.application_id = ns.application_id
+    LEFT JOIN app_limits AS al ON a.application_id = al.application_id
+    LEFT JOIN pay_plans AS pp ON al.pay_plan = pp.plan_type
+WHERE a.updated_at > @updated_since
+ORDER BY a.application_id ASC;
+-- name: SelectApplicationsPage :many
+SELECT a.application_id,
+    a.contact_email,
+    a.description,
+    a.dummy,
+    a.name,
+    a.owner,
+    a.status,
+    a.url,
+    a.user_id,
+    a.first_date_surpassed,
+    ga.address AS ga_address,
+    ga.client_public_key AS ga_client_public_key,
+    ga.private_key AS ga_private_key,
+    ga.public_key AS ga_public_key,
+    ga.signature AS ga_signature,
+    ga.version AS ga_version,
+    gs.secret_key,
+    gs.secret_key_required,
+    gs.whitelist_blockchains,
+    gs.whitelist_contracts,
+    gs.whitelist_methods,
+    gs.whitelist_origins,
+    gs.whitelist_user_agents,
+    ns.signed_up,
+    ns.on_quarter,
+    ns.on_half,
+    ns.on_three_quarters,
+    ns.on_full,
+    al.custom_limit,
+    al.pay_plan,
+    pp.daily_limit AS plan_limit,
+    a.created_at,
+    a.updated_at
+FROM applications AS a
+    LEFT JOIN gateway_aat AS ga ON a.application_id = ga.application_id
+    LEFT JOIN gateway_settings AS gs ON a.application_id = gs.application_id
+    LEFT JOIN notification_settings AS ns ON a.application_id = ns.application_id
+    LEFT JOIN app_limits AS al ON a.application_id = al.application_id
+    LEFT JOIN pay_plans AS pp ON al.pay_plan = pp.plan_type
+WHERE a.application_id > @cursor
+    AND (
+        sqlc.narg('user_id')::VARCHAR IS NULL
+        OR a.user_id = sqlc.narg('user_id')
+    )
+    AND (
+        sqlc.narg('status')::VARCHAR IS NULL
+        OR a.status = sqlc.narg('status')
+    )
+    AND (
+        sqlc.narg('pay_plan')::VARCHAR IS NULL
+        OR al.pay_plan = sqlc.narg('pay_plan')
+    )
+    AND (
+        sqlc.narg('dummy')::BOOLEAN IS NULL
+        OR a.dummy = sqlc.narg('dummy')
+    )
+    AND (
+        sqlc.narg('created_after')::TIMESTAMP IS NULL
+        OR a.created_at > sqlc.narg('created_after')
+    )
+ORDER BY a.application_id ASC
+LIMIT @max_results;
 -- name: SelectOneApplication :one
 SELECT a.application_id,
     a.contact_email,
@@ -228,7 +502,7 @@ SELECT application_id,
     on_full
 FROM notification_settings
 WHERE application_id = $1;
--- name: InsertApplication :exec
+-- name: InsertApplication :one
 INSERT into applications (
         application_id,
         user_id,
@@ -253,8 +527,9 @@ VALUES (
         $8,
         $9,
         $10,
-        $11
-    );
+        NOW()
+    )
+RETURNING updated_at;
 -- name: InsertAppLimit :exec
 INSERT into app_limits (application_id, pay_plan, custom_limit)
 VALUES ($1, $2, $3);
@@ -315,6 +590,11 @@ VALUES (
         $5,
         $6
     );
+-- name: LockApplication :one
+SELECT updated_at
+FROM applications
+WHERE application_id = $1 FOR
+UPDATE;
 -- name: UpsertApplication :exec
 INSERT INTO applications AS a (
         application_id,
@@ -323,14 +603,15 @@ INSERT INTO applications AS a (
         first_date_surpassed,
         updated_at
     )
-VALUES ($1, $2, $3, $4, $5) ON CONFLICT (application_id) DO
+VALUES ($1, $2, $3, $4, NOW()) ON CONFLICT (application_id) DO
 UPDATE
 SET name = COALESCE(EXCLUDED.name, a.name),
     status = COALESCE(EXCLUDED.status, a.status),
     first_date_surpassed = COALESCE(
         EXCLUDED.first_date_surpassed,
         a.first_date_surpassed
-    );
+    ),
+    updated_at = EXCLUDED.updated_at;
 -- name: UpsertAppLimit :exec
 INSERT INTO app_limits AS al (
         application_id,
@@ -391,11 +672,13 @@ SET signed_up = COALESCE(EXCLUDED.signed_up, ns.signed_up),
     on_full = COALESCE(EXCLUDED.on_full, ns.on_full);
 -- name: UpdateFirstDateSurpassed :exec
 UPDATE applications
-SET first_date_surpassed = @first_date_surpassed
+SET first_date_surpassed = @first_date_surpassed,
+    updated_at = NOW()
 WHERE application_id = ANY (@application_ids::VARCHAR []);
 -- name: RemoveApp :exec
 UPDATE applications
-SET status = COALESCE($2, status)
+SET status = COALESCE($2, status),
+    updated_at = NOW()
 WHERE application_id = $1;
 -- name: SelectLoadBalancers :many
 SELECT lb.lb_id,
@@ -446,6 +729,115 @@ GROUP BY lb.lb_id,
     so.origins,
     user_access.ua
 ORDER BY lb.lb_id ASC;
+-- name: SelectLoadBalancersUpdatedSince :many
+SELECT lb.lb_id,
+    lb.name,
+    lb.request_timeout,
+    lb.gigastake,
+    lb.gigastake_redirect,
+    lb.user_id,
+    so.duration AS s_duration,
+    so.sticky_max AS s_sticky_max,
+    so.stickiness AS s_stickiness,
+    so.origins AS s_origins,
+    STRING_AGG(la.app_id, ',') AS app_ids,
+    COALESCE(user_access.ua, '[]') AS users,
+    lb.created_at,
+    lb.updated_at
+FROM loadbalancers AS lb
+    LEFT JOIN stickiness_options AS so ON lb.lb_id = so.lb_id
+    LEFT JOIN lb_apps AS la ON lb.lb_id = la.lb_id
+    LEFT JOIN LATERAL (
+        SELECT jsonb_agg(
+                json_build_object(
+                    'userID',
+                    ua.user_id,
+                    'roleName',
+                    ua.role_name,
+                    'email',
+                    ua.email,
+                    'accepted',
+                    ua.accepted
+                )
+            ) AS ua
+        FROM user_access AS ua
+        WHERE lb.lb_id = ua.lb_id
+    ) user_access ON true
+WHERE lb.updated_at > @updated_since
+GROUP BY lb.lb_id,
+    lb.lb_id,
+    lb.name,
+    lb.created_at,
+    lb.updated_at,
+    lb.request_timeout,
+    lb.gigastake,
+    lb.gigastake_redirect,
+    lb.user_id,
+    so.duration,
+    so.sticky_max,
+    so.stickiness,
+    so.origins,
+    user_access.ua
+ORDER BY lb.lb_id ASC;
+-- name: SelectLoadBalancersPage :many
+SELECT lb.lb_id,
+    lb.name,
+    lb.request_timeout,
+    lb.gigastake,
+    lb.gigastake_redirect,
+    lb.user_id,
+    so.duration AS s_duration,
+    so.sticky_max AS s_sticky_max,
+    so.stickiness AS s_stickiness,
+    so.origins AS s_origins,
+    STRING_AGG(la.app_id, ',') AS app_ids,
+    COALESCE(user_access.ua, '[]') AS users,
+    lb.created_at,
+    lb.updated_at
+FROM loadbalancers AS lb
+    LEFT JOIN stickiness_options AS so ON lb.lb_id = so.lb_id
+    LEFT JOIN lb_apps AS la ON lb.lb_id = la.lb_id
+    LEFT JOIN LATERAL (
+        SELECT jsonb_agg(
+                json_build_object(
+                    'userID',
+                    ua.user_id,
+                    'roleName',
+                    ua.role_name,
+                    'email',
+                    ua.email,
+                    'accepted',
+                    ua.accepted
+                )
+            ) AS ua
+        FROM user_access AS ua
+        WHERE lb.lb_id = ua.lb_id
+    ) user_access ON true
+WHERE lb.lb_id > @cursor
+    AND (
+        sqlc.narg('user_id')::VARCHAR IS NULL
+        OR lb.user_id = sqlc.narg('user_id')
+    )
+    AND (
+        sqlc.narg('created_after')::TIMESTAMP IS NULL
+        OR lb.created_at > sqlc.narg('created_after')
+    )
+GROUP BY lb.lb_id,
+    lb.lb_id,
+    lb.name,
+    lb.created_at,
+    lb.updated_at,
+    lb.request_timeout,
+    lb.gigastake,
+    lb.gigastake_redirect,
+    lb.user_id,
+    so.duration,
+    so.sticky_max,
+    so.stickiness,
+    so.origins,
+    user_access.ua
+ORDER BY lb.lb_id ASC
+LIMIT @max_results;
 -- name: SelectOneLoadBalancer :one
 SELECT lb.lb_id,
     lb.name,
@@ -501,7 +893,15 @@ SELECT ua.lb_id,
     ur.permissions as permissions
 FROM user_access as ua
     LEFT JOIN user_roles AS ur ON ua.role_name = ur.name;
--- name: InsertLoadBalancer :exec
+-- name: SelectUserRolesUpdatedSince :many
+SELECT lb.lb_id,
+    ua.user_id,
+    ur.permissions as permissions
+FROM loadbalancers as lb
+    LEFT JOIN user_access AS ua ON ua.lb_id = lb.lb_id
+    LEFT JOIN user_roles AS ur ON ua.role_name = ur.name
+WHERE lb.updated_at > @updated_since;
+-- name: InsertLoadBalancer :one
 INSERT into loadbalancers (
         lb_id,
         name,
@@ -520,8 +920,9 @@ VALUES (
         $5,
         $6,
         $7,
-        $8
-    );
+        NOW()
+    )
+RETURNING updated_at;
 -- name: InsertStickinessOptions :exec
 INSERT INTO stickiness_options (
         lb_id,
@@ -541,11 +942,11 @@ INSERT INTO user_access (
         created_at,
         updated_at
     )
-VALUES ($1, $2, $3, $4, $5, $6, $7);
+VALUES ($1, $2, $3, $4, $5, $6, NOW());
 -- name: UpdateUserAccess :exec
 UPDATE user_access as ua
 SET role_name = COALESCE($3, ua.role_name),
-    updated_at = $4
+    updated_at = NOW()
 WHERE ua.user_id = $1
     AND ua.lb_id = $2;
 -- name: DeleteUserAccess :exec
@@ -570,13 +971,37 @@ SET duration = COALESCE(EXCLUDED.duration, so.duration),
 INSERT into lb_apps (lb_id, app_id)
 SELECT @lb_id,
     unnest(@app_ids::VARCHAR []);
+-- name: SelectLoadBalancerExists :one
+SELECT EXISTS(
+        SELECT 1
+        FROM loadbalancers
+        WHERE lb_id = $1 FOR SHARE
+    );
+-- name: LockLoadBalancer :one
+SELECT updated_at
+FROM loadbalancers
+WHERE lb_id = $1 FOR
+UPDATE;
 -- name: UpdateLB :exec
 UPDATE loadbalancers AS l
 SET name = COALESCE($2, l.name),
-    updated_at = $3
+    updated_at = NOW()
 WHERE l.lb_id = $1;
 -- name: RemoveLB :exec
 UPDATE loadbalancers
 SET user_id = '',
-    updated_at = $2
+    updated_at = NOW()
 WHERE lb_id = $1;
+-- name: SelectEvent :one
+SELECT sequence, table_name, action, data, previous, created_at
+FROM events
+WHERE sequence = @sequence;
+-- name: SelectEventsSince :many
+SELECT sequence, table_name, action, data, previous, created_at
+FROM events
+WHERE sequence > @sequence
+ORDER BY sequence ASC
+LIMIT @max_results;
+-- name: DeleteEventsBefore :execrows
+DELETE FROM events
+WHERE created_at < NOW() - make_interval(secs => @retention_seconds);
@@ -170,24 +170,129 @@ CREATE TABLE IF NOT EXISTS lb_apps (
 	CONSTRAINT fk_lb FOREIGN KEY(lb_id) REFERENCES loadbalancers(lb_id),
 	CONSTRAINT fk_app FOREIGN KEY(app_id) REFERENCES applications(application_id)
 );
+-- Events Outbox Table
+CREATE TABLE IF NOT EXISTS events (
+	sequence BIGINT GENERATED ALWAYS AS IDENTITY,
+	table_name VARCHAR NOT NULL,
+	action VARCHAR NOT NULL,
+	data JSONB NOT NULL,
+	previous JSONB NOT NULL DEFAULT 'null',
+	created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+	PRIMARY KEY (sequence)
+);
+-- Updated At Indexes
+CREATE INDEX IF NOT EXISTS blockchains_updated_at_idx ON blockchains (updated_at);
+CREATE INDEX IF NOT EXISTS loadbalancers_updated_at_idx ON loadbalancers (updated_at);
+CREATE INDEX IF NOT EXISTS applications_updated_at_idx ON applications (updated_at);
+-- Events Created At Index, used to prune the events past their retention
+CREATE INDEX IF NOT EXISTS events_created_at_idx ON events (created_at);
+-- Parent Updated At Function
+-- Bumps the updated_at of the parent row whenever a child table row changes.
+-- TG_ARGV[0] = parent table, TG_ARGV[1] = parent key column, TG_ARGV[2] = child key column
+CREATE OR REPLACE FUNCTION touch_parent() RETURNS TRIGGER AS $$
+DECLARE parent_id VARCHAR;
+BEGIN IF (TG_OP = 'DELETE') THEN parent_id = row_to_json(OLD)->>TG_ARGV[2];
+ELSE parent_id = row_to_json(NEW)->>TG_ARGV[2];
+END IF;
+EXECUTE format(
+	'UPDATE %I SET updated_at = NOW() WHERE %I = $1',
+	TG_ARGV[0],
+	TG_ARGV[1]
+) USING parent_id;
+-- Result is ignored since this is an AFTER trigger
+RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+CREATE TRIGGER stickiness_options_touch_parent
+AFTER
+INSERT
+	OR
+UPDATE
+	OR DELETE ON stickiness_options FOR EACH ROW EXECUTE PROCEDURE touch_parent('loadbalancers', 'lb_id', 'lb_id');
+CREATE TRIGGER user_access_touch_parent
+AFTER
+INSERT
+	OR
+UPDATE
+	OR DELETE ON user_access FOR EACH ROW EXECUTE PROCEDURE touch_parent('loadbalancers', 'lb_id', 'lb_id');
+CREATE TRIGGER lb_apps_touch_parent
+AFTER
+INSERT
+	OR
+UPDATE
+	OR DELETE ON lb_apps FOR EACH ROW EXECUTE PROCEDURE touch_parent('loadbalancers', 'lb_id', 'lb_id');
+CREATE TRIGGER app_limits_touch_parent
+AFTER
+INSERT
+	OR
+UPDATE
+	OR DELETE ON app_limits FOR EACH ROW EXECUTE PROCEDURE touch_parent('applications', 'application_id', 'application_id');
+CREATE TRIGGER gateway_aat_touch_parent
+AFTER
+INSERT
+	OR
+UPDATE
+	OR DELETE ON gateway_aat FOR EACH ROW EXECUTE PROCEDURE touch_parent('applications', 'application_id', 'application_id');
+CREATE TRIGGER gateway_settings_touch_parent
+AFTER
+INSERT
+	OR
+UPDATE
+	OR DELETE ON gateway_settings FOR EACH ROW EXECUTE PROCEDURE touch_parent('applications', 'application_id', 'application_id');
+CREATE TRIGGER notification_settings_touch_parent
+AFTER
+INSERT
+	OR
+UPDATE
+	OR DELETE ON notification_settings FOR EACH ROW EXECUTE PROCEDURE touch_parent('applications', 'application_id', 'application_id');
+CREATE TRIGGER redirects_touch_parent
+AFTER
+INSERT
+	OR
+UPDATE
+	OR DELETE ON redirects FOR EACH ROW EXECUTE PROCEDURE touch_parent('blockchains', 'blockchain_id', 'blockchain_id');
+CREATE TRIGGER sync_check_options_touch_parent
+AFTER
+INSERT
+	OR
+UPDATE
+	OR DELETE ON sync_check_options FOR EACH ROW EXECUTE PROCEDURE touch_parent('blockchains', 'blockchain_id', 'blockchain_id');
 -- Listener Notification Function
 CREATE OR REPLACE FUNCTION notify_event() RETURNS TRIGGER AS $$
 DECLARE data json;
+previous json;
+event_sequence BIGINT;
 notification json;
-BEGIN -- Convert the old or new row to JSON, based on the kind of action.
+BEGIN -- Skip the parent updates of touch_parent that only bump updated_at, the child row change is notified already
+IF (
+	TG_OP = 'UPDATE'
+	AND pg_trigger_depth() > 1
+	AND to_jsonb(NEW) - 'updated_at' = to_jsonb(OLD) - 'updated_at'
+) THEN RETURN NULL;
+END IF;
+-- Convert the old or new row to JSON, based on the kind of action.
 -- Action = DELETE?             -> OLD row
 -- Action = INSERT or UPDATE?   -> NEW row
 IF (TG_OP = 'DELETE') THEN data = row_to_json(OLD);
 ELSE data = row_to_json(NEW);
 END IF;
--- Contruct the notification as a JSON string.
+-- Updates keep the OLD row too so consumers can tell what changed
+IF (TG_OP = 'UPDATE') THEN previous = row_to_json(OLD);
+ELSE previous = 'null';
+END IF;
+-- Serialize writers until commit so event sequences follow commit order.
+-- The lock is global on purpose: an identity value is taken when the row is inserted, not when it commits, so without it
+-- a transaction could commit an event below a sequence consumers already resumed from and they would never replay it.
+-- Every write to a notified table queues on it from its first change until commit, so those transactions must stay short.
+PERFORM pg_advisory_xact_lock(hashtext('events'));
+-- Save the event in the outbox, rows can be larger than the pg_notify payload limit
+INSERT INTO events (table_name, action, data, previous)
+VALUES (TG_TABLE_NAME, TG_OP, data, previous)
+RETURNING sequence INTO event_sequence;
+-- Contruct the notification as a JSON string, the listener reads the event from the outbox.
 notification = json_build_object(
-	'table',
-	TG_TABLE_NAME,
-	'action',
-	TG_OP,
-	'data',
-	data
+	'sequence',
+	event_sequence
 );
 -- Execute pg_notify(channel, notification)
 PERFORM pg_notify('events', notification::text);
@@ -204,51 +309,71 @@ CREATE TRIGGER loadbalancer_notify_event
 AFTER
 INSERT
 	OR
-UPDATE ON loadbalancers FOR EACH ROW EXECUTE PROCEDURE notify_event();
+UPDATE
+	OR DELETE ON loadbalancers FOR EACH ROW EXECUTE PROCEDURE notify_event();
 CREATE TRIGGER stickiness_options_notify_event
 AFTER
 INSERT
 	OR
-UPDATE ON stickiness_options FOR EACH ROW EXECUTE PROCEDURE notify_event();
+UPDATE
+	OR DELETE ON stickiness_options FOR EACH ROW EXECUTE PROCEDURE notify_event();
 CREATE TRIGGER user_access_notify_event
 AFTER
 INSERT
 	OR
-UPDATE ON user_access FOR EACH ROW EXECUTE PROCEDURE notify_event();
+UPDATE
+	OR DELETE ON user_access FOR EACH ROW EXECUTE PROCEDURE notify_event();
 CREATE TRIGGER lb_apps_notify_event
 AFTER
-INSERT ON lb_apps FOR EACH ROW EXECUTE PROCEDURE notify_event();
+INSERT
+	OR
+UPDATE
+	OR DELETE ON lb_apps FOR EACH ROW EXECUTE PROCEDURE notify_event();
 CREATE TRIGGER application_notify_event
 AFTER
 INSERT
 	OR
-UPDATE ON applications FOR EACH ROW EXECUTE PROCEDURE notify_event();
+UPDATE
+	OR DELETE ON applications FOR EACH ROW EXECUTE PROCEDURE notify_event();
 CREATE TRIGGER app_limits_notify_event
 AFTER
 INSERT
 	OR
-UPDATE ON app_limits FOR EACH ROW EXECUTE PROCEDURE notify_event();
+UPDATE
+	OR DELETE ON app_limits FOR EACH ROW EXECUTE PROCEDURE notify_event();
 CREATE TRIGGER gateway_aat_notify_event
 AFTER
-INSERT ON gateway_aat FOR EACH ROW EXECUTE PROCEDURE notify_event();
+INSERT
+	OR
+UPDATE
+	OR DELETE ON gateway_aat FOR EACH ROW EXECUTE PROCEDURE notify_event();
 CREATE TRIGGER gateway_settings_notify_event
 AFTER
 INSERT
 	OR
-UPDATE ON gateway_settings FOR EACH ROW EXECUTE PROCEDURE notify_event();
+UPDATE
+	OR DELETE ON gateway_settings FOR EACH ROW EXECUTE PROCEDURE notify_event();
 CREATE TRIGGER notification_settings_notify_event
 AFTER
 INSERT
 	OR
-UPDATE ON notification_settings FOR EACH ROW EXECUTE PROCEDURE notify_event();
+UPDATE
+	OR DELETE ON notification_settings FOR EACH ROW EXECUTE PROCEDURE notify_event();
 CREATE TRIGGER blockchain_notify_event
 AFTER
 INSERT
 	OR
-UPDATE ON blockchains FOR EACH ROW EXECUTE PROCEDURE notify_event();
+UPDATE
+	OR DELETE ON blockchains FOR EACH ROW EXECUTE PROCEDURE notify_event();
 CREATE TRIGGER redirect_notify_event
 AFTER
-INSERT ON redirects FOR EACH ROW EXECUTE PROCEDURE notify_event();
+INSERT
+	OR
+UPDATE
+	OR DELETE ON redirects FOR EACH ROW EXECUTE PROCEDURE notify_event();
 CREATE TRIGGER sync_check_options_notify_event
 AFTER
-INSERT ON sync_check_options FOR EACH ROW EXECUTE PROCEDURE notify_event();
+INSERT
+	OR
+UPDATE
+	OR DELETE ON sync_check_options FOR EACH ROW EXECUTE PROCEDURE notify_event();
@@ -0,0 +1,132 @@
+package postgresdriver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pokt-foundation/portal-db/driver"
+)
+
+const (
+	maxTxAttempts = 3
+	// txRetryBackoff is the base wait before retrying a transaction, it doubles with every attempt
+	txRetryBackoff = 10 * time.Millisecond
+)
+
+// txRetryJitter randomizes the retry waits, it is seeded on its own as the global source may not be
+var txRetryJitter = struct {
+	sync.Mutex
+	*rand.Rand
+}{Rand: rand.New(rand.NewSource(time.Now().UnixNano()))}
+
+/* writeTx is the transaction of a write, it only commits and rolls back the transactions it began */
+type writeTx struct {
+	*sql.Tx
+	joined bool
+}
+
+// RunInTx runs fn in a transaction committed once it returns nil, so the writes made through tx are applied together or not at all.
+// The transaction is serializable unless set otherwise with WithTxIsolation, and is retried from the start after a short
+// backoff on serialization failures and deadlocks, so fn may run several times and must not have effects outside of tx.
+// Calling RunInTx on tx joins its transaction
+func (p *PostgresDriver) RunInTx(ctx context.Context, fn func(tx driver.Writer) error) error {
+	if p.tx != nil {
+		return fn(p)
+	}
+
+	return retryTx(ctx, func() error {
+		return p.runInTx(ctx, fn)
+	})
+}
+
+func (p *PostgresDriver) runInTx(ctx context.Context, fn func(tx driver.Writer) error) error {
+	tx, err := p.db.BeginTx(ctx, &sql.TxOptions{Isolation: p.txIsolation})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	err = fn(&PostgresDriver{Queries: p.WithTx(tx), db: p.db, tx: tx})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+/* retryTx runs attempt until it doesn't fail with a serialization failure or deadlock, up to maxTxAttempts times */
+func retryTx(ctx context.Context, attempt func() error) error {
+	var err error
+
+	for i := 0; i < maxTxAttempts; i++ {
+		if i > 0 {
+			select {
+			case <-time.After(txRetryDelay(i)):
+			case <-ctx.Done():
+				return err
+			}
+		}
+
+		err = attempt()
+		if !isSerializationFailure(err) || ctx.Err() != nil {
+			return err
+		}
+	}
+
+	return err
+}
+
+/* txRetryDelay returns the wait before the given attempt, jittered so the transactions that conflicted don't retry in lockstep */
+func txRetryDelay(attempt int) time.Duration {
+	backoff := txRetryBackoff << (attempt - 1)
+
+	txRetryJitter.Lock()
+	defer txRetryJitter.Unlock()
+
+	return backoff/2 + time.Duration(txRetryJitter.Int63n(int64(backoff)))
+}
+
+/* beginTx begins the transaction of a write, joining the driver's transaction when it is bound to one */
+func (p *PostgresDriver) beginTx(ctx context.Context) (*writeTx, error) {
+	if p.tx != nil {
+		return &writeTx{Tx: p.tx, joined: true}, nil
+	}
+
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &writeTx{Tx: tx}, nil
+}
+
+func (t *writeTx) Commit() error {
+	if t.joined {
+		return nil
+	}
+
+	return t.Tx.Commit()
+}
+
+func (t *writeTx) Rollback() error {
+	if t.joined {
+		return nil
+	}
+
+	return t.Tx.Rollback()
+}
+
+/* isSerializationFailure returns true if err is a serialization failure or deadlock the transaction can be retried after */
+func isSerializationFailure(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == "40001" || pqErr.Code == "40P01"
+	}
+
+	return false
+}
@@ -0,0 +1,161 @@
+package postgresdriver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pokt-foundation/portal-db/driver"
+	"github.com/pokt-foundation/portal-db/types"
+	"github.com/stretchr/testify/require"
+)
+
+func (ts *PGDriverTestSuite) Test_RunInTxRetriesSerializationFailures() {
+	first, err := ts.driver.WriteApplication(testCtx, &types.Application{Name: "pokt_app_first", Limit: types.AppLimit{PayPlan: types.PayPlan{Type: types.FreetierV0}}})
+	ts.Require().NoError(err)
+	second, err := ts.driver.WriteApplication(testCtx, &types.Application{Name: "pokt_app_second", Limit: types.AppLimit{PayPlan: types.PayPlan{Type: types.FreetierV0}}})
+	ts.Require().NoError(err)
+
+	// each transaction reads the application the other one renames, so they can't both commit as serializable
+	firstRead, secondRead, firstErr := make(chan struct{}), make(chan struct{}), make(chan error, 1)
+
+	go func() {
+		firstErr <- ts.driver.RunInTx(testCtx, func(tx driver.Writer) error {
+			_, err := tx.(*PostgresDriver).SelectOneApplication(testCtx, first.ID)
+			if err != nil {
+				return err
+			}
+			close(firstRead)
+			<-secondRead
+
+			return tx.UpdateApplication(testCtx, second.ID, &types.UpdateApplication{Name: "pokt_app_renamed_by_first"})
+		})
+	}()
+
+	attempts := 0
+	err = ts.driver.RunInTx(testCtx, func(tx driver.Writer) error {
+		attempts++
+		if attempts == 1 {
+			<-firstRead
+		}
+
+		_, err := tx.(*PostgresDriver).SelectOneApplication(testCtx, second.ID)
+		if err != nil {
+			return err
+		}
+
+		// the first attempt writes once the first transaction committed and fails with 40001
+		if attempts == 1 {
+			close(secondRead)
+			ts.Require().NoError(<-firstErr)
+		}
+
+		return tx.UpdateApplication(testCtx, first.ID, &types.UpdateApplication{Name: "pokt_app_renamed_by_second"})
+	})
+	ts.Require().NoError(err)
+	ts.Equal(2, attempts)
+
+	app, err := ts.driver.SelectOneApplication(testCtx, first.ID)
+	ts.Require().NoError(err)
+	ts.Equal("pokt_app_renamed_by_second", app.Name.String)
+	app, err = ts.driver.SelectOneApplication(testCtx, second.ID)
+	ts.Require().NoError(err)
+	ts.Equal("pokt_app_renamed_by_first", app.Name.String)
+}
+
+func TestRetryTx(t *testing.T) {
+	tests := []struct {
+		name             string
+		errs             []error
+		expectedErr      error
+		expectedAttempts int
+	}{
+		{
+			name:             "Should not retry a successful transaction",
+			errs:             []error{nil},
+			expectedAttempts: 1,
+		},
+		{
+			name:             "Should retry serialization failures and deadlocks",
+			errs:             []error{&pq.Error{Code: "40001"}, &pq.Error{Code: "40P01"}, nil},
+			expectedAttempts: 3,
+		},
+		{
+			name:             "Should give up after the last attempt",
+			errs:             []error{&pq.Error{Code: "40001"}, &pq.Error{Code: "40001"}, &pq.Error{Code: "40001"}, nil},
+			expectedErr:      &pq.Error{Code: "40001"},
+			expectedAttempts: maxTxAttempts,
+		},
+		{
+			name:             "Should not retry other errors",
+			errs:             []error{ErrLBMustHaveUser, nil},
+			expectedErr:      ErrLBMustHaveUser,
+			expectedAttempts: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := require.New(t)
+
+			attempts := 0
+			err := retryTx(context.Background(), func() error {
+				attempts++
+				return test.errs[attempts-1]
+			})
+
+			c.Equal(test.expectedErr, err)
+			c.Equal(test.expectedAttempts, attempts)
+		})
+	}
+}
+
+func TestRetryTxCanceled(t *testing.T) {
+	c := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	attempts := 0
+	err := retryTx(ctx, func() error {
+		attempts++
+		return &pq.Error{Code: "40001"}
+	})
+
+	var pqErr *pq.Error
+	c.True(errors.As(err, &pqErr))
+	c.Equal(1, attempts)
+}
+
+func TestTxRetryDelay(t *testing.T) {
+	c := require.New(t)
+
+	for attempt := 1; attempt < maxTxAttempts; attempt++ {
+		backoff := txRetryBackoff << (attempt - 1)
+
+		for i := 0; i < 100; i++ {
+			delay := txRetryDelay(attempt)
+			c.GreaterOrEqual(delay, backoff/2)
+			c.Less(delay, backoff*3/2)
+		}
+	}
+}
+
+func TestRetryTxBacksOff(t *testing.T) {
+	c := require.New(t)
+
+	start := time.Now()
+	attempts := 0
+	err := retryTx(context.Background(), func() error {
+		attempts++
+		return &pq.Error{Code: "40001"}
+	})
+
+	var pqErr *pq.Error
+	c.True(errors.As(err, &pqErr))
+	c.Equal(maxTxAttempts, attempts)
+	// the waits before the second and third attempts are at least half of their backoff
+	c.GreaterOrEqual(time.Since(start), txRetryBackoff/2+txRetryBackoff)
+}
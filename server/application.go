@@ -0,0 +1,182 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pokt-foundation/portal-db/types"
+)
+
+func (s *Server) readPayPlans(w http.ResponseWriter, r *http.Request, _ []string) error {
+	payPlans, err := s.driver.ReadPayPlans(r.Context())
+	if err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusOK, payPlans)
+	return nil
+}
+
+func (s *Server) readApplications(w http.ResponseWriter, r *http.Request, _ []string) error {
+	applications, err := s.driver.ReadApplications(r.Context())
+	if err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusOK, applications)
+	return nil
+}
+
+func (s *Server) readApplication(w http.ResponseWriter, r *http.Request, params []string) error {
+	application, err := s.driver.ReadApplication(r.Context(), params[0])
+	if err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusOK, application)
+	return nil
+}
+
+func (s *Server) readApplicationsPage(w http.ResponseWriter, r *http.Request, _ []string) error {
+	options, err := queryOptions(r)
+	if err != nil {
+		return err
+	}
+
+	page, err := s.driver.ReadApplicationsPage(r.Context(), options)
+	if err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusOK, page)
+	return nil
+}
+
+func (s *Server) readApplicationsUpdatedSince(w http.ResponseWriter, r *http.Request, _ []string) error {
+	since, err := sinceTime(r)
+	if err != nil {
+		return err
+	}
+
+	applications, err := s.driver.ReadApplicationsUpdatedSince(r.Context(), since)
+	if err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusOK, applications)
+	return nil
+}
+
+func (s *Server) writeApplication(w http.ResponseWriter, r *http.Request, _ []string) error {
+	var app types.Application
+	if err := decodeBody(w, r, &app); err != nil {
+		return err
+	}
+
+	application, err := s.driver.WriteApplication(r.Context(), &app)
+	if err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusCreated, application)
+	return nil
+}
+
+/* updateApplication updates the app, or removes it when the update's Remove field is set */
+func (s *Server) updateApplication(w http.ResponseWriter, r *http.Request, params []string) error {
+	var update *types.UpdateApplication
+	if err := decodeBody(w, r, &update); err != nil {
+		return err
+	}
+
+	var err error
+	if update != nil && update.Remove {
+		err = s.driver.RemoveApplication(r.Context(), params[0])
+	} else {
+		err = s.driver.UpdateApplication(r.Context(), params[0], update)
+	}
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *Server) updateAppFirstDateSurpassed(w http.ResponseWriter, r *http.Request, _ []string) error {
+	var update types.UpdateFirstDateSurpassed
+	if err := decodeBody(w, r, &update); err != nil {
+		return err
+	}
+
+	err := s.driver.UpdateAppFirstDateSurpassed(r.Context(), &update)
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *Server) removeApplication(w http.ResponseWriter, r *http.Request, params []string) error {
+	err := s.driver.RemoveApplication(r.Context(), params[0])
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+/* queryOptions reads the QueryOptions of a page request from its query parameters, which are named after their JSON fields */
+func queryOptions(r *http.Request) (*types.QueryOptions, error) {
+	query := r.URL.Query()
+
+	options := &types.QueryOptions{
+		Cursor:  query.Get("cursor"),
+		UserID:  query.Get("userID"),
+		Status:  types.AppStatus(query.Get("status")),
+		PayPlan: types.PayPlanType(query.Get("payPlan")),
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil {
+			return nil, fmt.Errorf("%w: limit: %s", ErrInvalidQuery, err)
+		}
+		options.Limit = value
+	}
+	if dummy := query.Get("dummy"); dummy != "" {
+		value, err := strconv.ParseBool(dummy)
+		if err != nil {
+			return nil, fmt.Errorf("%w: dummy: %s", ErrInvalidQuery, err)
+		}
+		options.Dummy = &value
+	}
+	if createdAfter := query.Get("createdAfter"); createdAfter != "" {
+		value, err := time.Parse(time.RFC3339Nano, createdAfter)
+		if err != nil {
+			return nil, fmt.Errorf("%w: createdAfter: %s", ErrInvalidQuery, err)
+		}
+		options.CreatedAfter = value
+	}
+
+	return options, nil
+}
+
+/* sinceTime reads the RFC 3339 since query parameter of an updated since request, it is the zero time when missing */
+func sinceTime(r *http.Request) (time.Time, error) {
+	since := r.URL.Query().Get("since")
+	if since == "" {
+		return time.Time{}, nil
+	}
+
+	value, err := time.Parse(time.RFC3339Nano, since)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: since: %s", ErrInvalidQuery, err)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,156 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/pokt-foundation/portal-db/api"
+	"github.com/pokt-foundation/portal-db/types"
+)
+
+/* ActivateChainRequest is the body of a request to ActivateChain */
+type ActivateChainRequest = api.ActivateChainRequest
+
+func (s *Server) readBlockchains(w http.ResponseWriter, r *http.Request, _ []string) error {
+	blockchains, err := s.driver.ReadBlockchains(r.Context())
+	if err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusOK, blockchains)
+	return nil
+}
+
+func (s *Server) readBlockchain(w http.ResponseWriter, r *http.Request, params []string) error {
+	blockchain, err := s.driver.ReadBlockchain(r.Context(), params[0])
+	if err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusOK, blockchain)
+	return nil
+}
+
+func (s *Server) readBlockchainsUpdatedSince(w http.ResponseWriter, r *http.Request, _ []string) error {
+	since, err := sinceTime(r)
+	if err != nil {
+		return err
+	}
+
+	blockchains, err := s.driver.ReadBlockchainsUpdatedSince(r.Context(), since)
+	if err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusOK, blockchains)
+	return nil
+}
+
+func (s *Server) writeBlockchain(w http.ResponseWriter, r *http.Request, _ []string) error {
+	var chain types.Blockchain
+	if err := decodeBody(w, r, &chain); err != nil {
+		return err
+	}
+
+	blockchain, err := s.driver.WriteBlockchain(r.Context(), &chain)
+	if err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusCreated, blockchain)
+	return nil
+}
+
+func (s *Server) updateBlockchain(w http.ResponseWriter, r *http.Request, params []string) error {
+	var update *types.UpdateBlockchain
+	if err := decodeBody(w, r, &update); err != nil {
+		return err
+	}
+
+	err := s.driver.UpdateBlockchain(r.Context(), params[0], update)
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *Server) removeBlockchain(w http.ResponseWriter, r *http.Request, params []string) error {
+	err := s.driver.RemoveBlockchain(r.Context(), params[0])
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *Server) writeRedirect(w http.ResponseWriter, r *http.Request, _ []string) error {
+	var input types.Redirect
+	if err := decodeBody(w, r, &input); err != nil {
+		return err
+	}
+
+	redirect, err := s.driver.WriteRedirect(r.Context(), &input)
+	if err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusCreated, redirect)
+	return nil
+}
+
+func (s *Server) readRedirects(w http.ResponseWriter, r *http.Request, _ []string) error {
+	query := r.URL.Query()
+
+	redirects, err := s.driver.ReadRedirects(r.Context(), &types.RedirectFilter{
+		BlockchainID:   query.Get("blockchainID"),
+		LoadBalancerID: query.Get("loadBalancerID"),
+	})
+	if err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusOK, redirects)
+	return nil
+}
+
+func (s *Server) updateRedirect(w http.ResponseWriter, r *http.Request, params []string) error {
+	var update *types.UpdateRedirect
+	if err := decodeBody(w, r, &update); err != nil {
+		return err
+	}
+
+	err := s.driver.UpdateRedirect(r.Context(), params[0], params[1], update)
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *Server) removeRedirect(w http.ResponseWriter, r *http.Request, params []string) error {
+	err := s.driver.RemoveRedirect(r.Context(), params[0], params[1])
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *Server) activateChain(w http.ResponseWriter, r *http.Request, params []string) error {
+	var request ActivateChainRequest
+	if err := decodeBody(w, r, &request); err != nil {
+		return err
+	}
+
+	err := s.driver.ActivateChain(r.Context(), params[0], request.Active)
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
@@ -0,0 +1,136 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pokt-foundation/portal-db/types"
+)
+
+func (s *Server) readEventsSince(w http.ResponseWriter, r *http.Request, _ []string) error {
+	var sequence int64
+	if since := r.URL.Query().Get("since"); since != "" {
+		value, err := strconv.ParseInt(since, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%w: since: %s", ErrInvalidQuery, err)
+		}
+		sequence = value
+	}
+
+	events, err := s.driver.ReadEventsSince(r.Context(), sequence)
+	if err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusOK, events)
+	return nil
+}
+
+// streamNotifications streams the notifications of a subscription as Server-Sent Events whose data is the JSON Notification
+// and whose id is its sequence, so a reconnecting EventSource resumes after the last one it received through Last-Event-ID.
+// The stream ends when the subscription's channel is closed, after a RESYNC notification if the subscriber fell behind
+func (s *Server) streamNotifications(w http.ResponseWriter, r *http.Request, _ []string) error {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return ErrStreamingUnsupported
+	}
+
+	options, err := subscriptionOptions(r)
+	if err != nil {
+		return err
+	}
+
+	notifications, err := s.driver.Subscribe(r.Context(), options)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(s.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case n, ok := <-notifications:
+			if !ok {
+				return nil
+			}
+
+			data, err := json.Marshal(n)
+			if err != nil {
+				s.reportError(err)
+				return nil
+			}
+
+			if n.Sequence != 0 {
+				fmt.Fprintf(w, "id: %d\n", n.Sequence)
+			}
+			fmt.Fprintf(w, "data: %s\n\n", data)
+			flusher.Flush()
+
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+			flusher.Flush()
+
+		case <-r.Context().Done():
+			return nil
+		}
+	}
+}
+
+// subscriptionOptions reads the SubscriptionOptions of a notification stream from its query parameters, which are
+// named after their JSON fields, tables and actions being comma separated. Without fromSequence, the Last-Event-ID header is used
+func subscriptionOptions(r *http.Request) (*types.SubscriptionOptions, error) {
+	query := r.URL.Query()
+
+	options := &types.SubscriptionOptions{
+		SubscriptionFilter: types.SubscriptionFilter{EntityID: query.Get("entityID")},
+		Overflow:           types.OverflowPolicy(query.Get("overflow")),
+	}
+
+	for _, table := range splitList(query.Get("tables")) {
+		options.Tables = append(options.Tables, types.Table(table))
+	}
+	for _, action := range splitList(query.Get("actions")) {
+		options.Actions = append(options.Actions, types.Action(action))
+	}
+
+	if bufferSize := query.Get("bufferSize"); bufferSize != "" {
+		value, err := strconv.Atoi(bufferSize)
+		if err != nil {
+			return nil, fmt.Errorf("%w: bufferSize: %s", ErrInvalidQuery, err)
+		}
+		options.BufferSize = value
+	}
+
+	fromSequence := query.Get("fromSequence")
+	if fromSequence == "" {
+		fromSequence = r.Header.Get("Last-Event-ID")
+	}
+	if fromSequence != "" {
+		value, err := strconv.ParseInt(fromSequence, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: fromSequence: %s", ErrInvalidQuery, err)
+		}
+		options.FromSequence = &value
+	}
+
+	return options, nil
+}
+
+func splitList(list string) []string {
+	if list == "" {
+		return nil
+	}
+
+	return strings.Split(list, ",")
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pokt-foundation/portal-db/api"
+	"github.com/pokt-foundation/portal-db/memdriver"
+	"github.com/pokt-foundation/portal-db/types"
+	"github.com/stretchr/testify/require"
+)
+
+/* readEvent reads the next Server-Sent Event of the stream, skipping comments */
+func readEvent(t *testing.T, stream *bufio.Reader) (string, *types.Notification) {
+	t.Helper()
+
+	var id string
+	var n *types.Notification
+
+	for {
+		line, err := stream.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+
+		switch {
+		case line == "" && n != nil:
+			return id, n
+		case strings.HasPrefix(line, "id: "):
+			id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "data: "):
+			n = &types.Notification{}
+			require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), n))
+		}
+	}
+}
+
+func subscribe(t *testing.T, ts *httptest.Server, query string, header http.Header) *bufio.Reader {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/notification"+query, nil)
+	require.NoError(t, err)
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	resp, err := ts.Client().Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	t.Cleanup(func() { resp.Body.Close() })
+
+	return bufio.NewReader(resp.Body)
+}
+
+func TestServer_Notifications(t *testing.T) {
+	c := require.New(t)
+	d := memdriver.NewMemDriver()
+	ts := httptest.NewServer(NewServer(d, WithHeartbeat(10*time.Millisecond)))
+	defer ts.Close()
+	defer d.Close(context.Background())
+
+	stream := subscribe(t, ts, "?tables=applications,app_limits&actions=INSERT", nil)
+
+	var app types.Application
+	c.Equal(http.StatusCreated, do(t, ts, http.MethodPost, "/application", &types.Application{
+		Name:  "pokt_app_1",
+		Limit: types.AppLimit{PayPlan: types.PayPlan{Type: types.FreetierV0}},
+	}, &app))
+
+	id, n := readEvent(t, stream)
+	c.Equal("1", id)
+	c.Equal(types.ActionInsert, n.Action)
+	c.Equal(app.ID, n.Data.(*types.Application).ID)
+	c.Equal("pokt_app_1", n.Data.(*types.Application).Name)
+
+	id, n = readEvent(t, stream)
+	c.Equal("2", id)
+	c.Equal(types.TableAppLimits, n.Table)
+	c.Equal(types.FreetierV0, n.Data.(*types.AppLimit).PayPlan.Type)
+
+	c.Equal(http.StatusNoContent, do(t, ts, http.MethodPut, "/application/"+app.ID, &types.UpdateApplication{Name: "pokt_app_renamed"}, nil))
+
+	// a reconnecting client resumes after the last event it received
+	resumed := subscribe(t, ts, "", http.Header{"Last-Event-ID": {"3"}})
+	id, n = readEvent(t, resumed)
+	c.Equal("4", id)
+	c.Equal(types.ActionUpdate, n.Action)
+	c.Equal("pokt_app_1", n.Previous.(*types.Application).Name)
+	c.Equal([]string{"name", "updated_at"}, n.ChangedFields)
+
+	var events []*types.Notification
+	c.Equal(http.StatusOK, do(t, ts, http.MethodGet, "/event?since=2", nil, &events))
+	c.Len(events, 2)
+	c.Equal(int64(3), events[0].Sequence)
+	c.Equal("pokt_app_renamed", events[1].Data.(*types.Application).Name)
+
+	var resp api.ErrorResponse
+	c.Equal(http.StatusBadRequest, do(t, ts, http.MethodGet, "/notification?overflow=NOT_A_POLICY", nil, &resp))
+	c.Equal("INVALID_OVERFLOW_POLICY", resp.Code)
+}
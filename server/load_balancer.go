@@ -0,0 +1,168 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/pokt-foundation/portal-db/types"
+)
+
+func (s *Server) readLoadBalancers(w http.ResponseWriter, r *http.Request, _ []string) error {
+	loadBalancers, err := s.driver.ReadLoadBalancers(r.Context())
+	if err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusOK, loadBalancers)
+	return nil
+}
+
+func (s *Server) readLoadBalancer(w http.ResponseWriter, r *http.Request, params []string) error {
+	loadBalancer, err := s.driver.ReadLoadBalancer(r.Context(), params[0])
+	if err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusOK, loadBalancer)
+	return nil
+}
+
+func (s *Server) readLoadBalancersPage(w http.ResponseWriter, r *http.Request, _ []string) error {
+	options, err := queryOptions(r)
+	if err != nil {
+		return err
+	}
+
+	page, err := s.driver.ReadLoadBalancersPage(r.Context(), options)
+	if err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusOK, page)
+	return nil
+}
+
+func (s *Server) readLoadBalancersUpdatedSince(w http.ResponseWriter, r *http.Request, _ []string) error {
+	since, err := sinceTime(r)
+	if err != nil {
+		return err
+	}
+
+	loadBalancers, err := s.driver.ReadLoadBalancersUpdatedSince(r.Context(), since)
+	if err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusOK, loadBalancers)
+	return nil
+}
+
+func (s *Server) readUserRoles(w http.ResponseWriter, r *http.Request, _ []string) error {
+	userRoles, err := s.driver.ReadUserRoles(r.Context())
+	if err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusOK, userRoles)
+	return nil
+}
+
+func (s *Server) readUserRolesUpdatedSince(w http.ResponseWriter, r *http.Request, _ []string) error {
+	since, err := sinceTime(r)
+	if err != nil {
+		return err
+	}
+
+	userRoles, err := s.driver.ReadUserRolesUpdatedSince(r.Context(), since)
+	if err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusOK, userRoles)
+	return nil
+}
+
+func (s *Server) writeLoadBalancer(w http.ResponseWriter, r *http.Request, _ []string) error {
+	var lb types.LoadBalancer
+	if err := decodeBody(w, r, &lb); err != nil {
+		return err
+	}
+
+	loadBalancer, err := s.driver.WriteLoadBalancer(r.Context(), &lb)
+	if err != nil {
+		return err
+	}
+
+	writeJSON(w, http.StatusCreated, loadBalancer)
+	return nil
+}
+
+func (s *Server) writeLoadBalancerUser(w http.ResponseWriter, r *http.Request, params []string) error {
+	var userAccess types.UserAccess
+	if err := decodeBody(w, r, &userAccess); err != nil {
+		return err
+	}
+
+	err := s.driver.WriteLoadBalancerUser(r.Context(), params[0], userAccess)
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+/* updateLoadBalancer updates the load balancer, or removes it when the update's Remove field is set */
+func (s *Server) updateLoadBalancer(w http.ResponseWriter, r *http.Request, params []string) error {
+	var update types.UpdateLoadBalancer
+	if err := decodeBody(w, r, &update); err != nil {
+		return err
+	}
+
+	var err error
+	if update.Remove {
+		err = s.driver.RemoveLoadBalancer(r.Context(), params[0])
+	} else {
+		err = s.driver.UpdateLoadBalancer(r.Context(), params[0], &update)
+	}
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *Server) updateUserAccessRole(w http.ResponseWriter, r *http.Request, params []string) error {
+	var update types.UpdateUserAccess
+	if err := decodeBody(w, r, &update); err != nil {
+		return err
+	}
+
+	err := s.driver.UpdateUserAccessRole(r.Context(), params[1], params[0], update.RoleName)
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *Server) removeLoadBalancer(w http.ResponseWriter, r *http.Request, params []string) error {
+	err := s.driver.RemoveLoadBalancer(r.Context(), params[0])
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *Server) removeUserAccess(w http.ResponseWriter, r *http.Request, params []string) error {
+	err := s.driver.RemoveUserAccess(r.Context(), params[1], params[0])
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
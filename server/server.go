@@ -0,0 +1,216 @@
+// Package server exposes a driver.Driver as a JSON REST API, with the Notifications streamed as Server-Sent Events.
+// Request and response bodies are the types structs, errors are returned as an api.ErrorResponse whose Code identifies
+// the driver's sentinel error.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pokt-foundation/portal-db/api"
+	"github.com/pokt-foundation/portal-db/driver"
+)
+
+const (
+	defaultHeartbeat = 15 * time.Second
+	maxBodySize      = 1 << 20
+)
+
+var (
+	ErrInvalidBody          = api.ErrInvalidBody
+	ErrInvalidQuery         = api.ErrInvalidQuery
+	ErrUnknownRoute         = api.ErrUnknownRoute
+	ErrMethodNotAllowed     = api.ErrMethodNotAllowed
+	ErrStreamingUnsupported = errors.New("streaming unsupported")
+)
+
+type (
+	/* Server is an http.Handler serving the driver's Reader and Writer methods */
+	Server struct {
+		driver       driver.Driver
+		routes       []route
+		heartbeat    time.Duration
+		errorHandler func(error)
+	}
+
+	Option func(*Server)
+
+	/* handler serves a request, params holds the values of the route's path parameters in order */
+	handler func(w http.ResponseWriter, r *http.Request, params []string) error
+
+	route struct {
+		method  string
+		path    []string
+		handler handler
+	}
+)
+
+/* WithHeartbeat sets how often an idle notification stream sends a comment to keep the connection open, 15s by default */
+func WithHeartbeat(interval time.Duration) Option {
+	return func(s *Server) {
+		s.heartbeat = interval
+	}
+}
+
+/* WithErrorHandler sets a handler for internal errors, which are not returned to the caller */
+func WithErrorHandler(handler func(error)) Option {
+	return func(s *Server) {
+		s.errorHandler = handler
+	}
+}
+
+/* NewServer returns a Server for the driver */
+func NewServer(d driver.Driver, options ...Option) *Server {
+	s := &Server{
+		driver:    d,
+		heartbeat: defaultHeartbeat,
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	// Routes with a literal segment come before the ones with a parameter in its place
+	s.routes = []route{
+		newRoute(http.MethodGet, "/pay_plan", s.readPayPlans),
+
+		newRoute(http.MethodGet, "/application", s.readApplications),
+		newRoute(http.MethodPost, "/application", s.writeApplication),
+		newRoute(http.MethodGet, "/application/page", s.readApplicationsPage),
+		newRoute(http.MethodGet, "/application/updated", s.readApplicationsUpdatedSince),
+		newRoute(http.MethodPut, "/application/first_date_surpassed", s.updateAppFirstDateSurpassed),
+		newRoute(http.MethodGet, "/application/{id}", s.readApplication),
+		newRoute(http.MethodPut, "/application/{id}", s.updateApplication),
+		newRoute(http.MethodDelete, "/application/{id}", s.removeApplication),
+
+		newRoute(http.MethodGet, "/load_balancer", s.readLoadBalancers),
+		newRoute(http.MethodPost, "/load_balancer", s.writeLoadBalancer),
+		newRoute(http.MethodGet, "/load_balancer/page", s.readLoadBalancersPage),
+		newRoute(http.MethodGet, "/load_balancer/updated", s.readLoadBalancersUpdatedSince),
+		newRoute(http.MethodGet, "/load_balancer/{id}", s.readLoadBalancer),
+		newRoute(http.MethodPut, "/load_balancer/{id}", s.updateLoadBalancer),
+		newRoute(http.MethodDelete, "/load_balancer/{id}", s.removeLoadBalancer),
+		newRoute(http.MethodPost, "/load_balancer/{id}/user", s.writeLoadBalancerUser),
+		newRoute(http.MethodPut, "/load_balancer/{id}/user/{userID}", s.updateUserAccessRole),
+		newRoute(http.MethodDelete, "/load_balancer/{id}/user/{userID}", s.removeUserAccess),
+
+		newRoute(http.MethodGet, "/user_roles", s.readUserRoles),
+		newRoute(http.MethodGet, "/user_roles/updated", s.readUserRolesUpdatedSince),
+
+		newRoute(http.MethodGet, "/blockchain", s.readBlockchains),
+		newRoute(http.MethodPost, "/blockchain", s.writeBlockchain),
+		newRoute(http.MethodGet, "/blockchain/updated", s.readBlockchainsUpdatedSince),
+		newRoute(http.MethodGet, "/blockchain/{id}", s.readBlockchain),
+		newRoute(http.MethodPut, "/blockchain/{id}", s.updateBlockchain),
+		newRoute(http.MethodDelete, "/blockchain/{id}", s.removeBlockchain),
+		newRoute(http.MethodPut, "/blockchain/{id}/activate", s.activateChain),
+		newRoute(http.MethodGet, "/redirect", s.readRedirects),
+		newRoute(http.MethodPost, "/redirect", s.writeRedirect),
+		newRoute(http.MethodPut, "/redirect/{blockchainID}/{domain}", s.updateRedirect),
+		newRoute(http.MethodDelete, "/redirect/{blockchainID}/{domain}", s.removeRedirect),
+
+		newRoute(http.MethodGet, "/event", s.readEventsSince),
+		newRoute(http.MethodGet, "/notification", s.streamNotifications),
+	}
+
+	return s
+}
+
+func newRoute(method, path string, handler handler) route {
+	return route{method: method, path: splitPath(path), handler: handler}
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+/* match returns the values of the route's path parameters if the path matches it */
+func (rt route) match(path []string) ([]string, bool) {
+	if len(path) != len(rt.path) {
+		return nil, false
+	}
+
+	var params []string
+	for i, segment := range rt.path {
+		if strings.HasPrefix(segment, "{") {
+			if path[i] == "" {
+				return nil, false
+			}
+			params = append(params, path[i])
+			continue
+		}
+		if segment != path[i] {
+			return nil, false
+		}
+	}
+
+	return params, true
+}
+
+/* ServeHTTP routes the request to the handler of its method and path */
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := splitPath(r.URL.Path)
+	err := ErrUnknownRoute
+
+	// the first route matching the path shadows the routes with another pattern, like a parameter in place of its literal
+	var matched string
+	for _, rt := range s.routes {
+		params, ok := rt.match(path)
+		if !ok {
+			continue
+		}
+		pattern := strings.Join(rt.path, "/")
+		if matched != "" && pattern != matched {
+			continue
+		}
+		if rt.method != r.Method {
+			matched, err = pattern, ErrMethodNotAllowed
+			continue
+		}
+
+		if err := rt.handler(w, r, params); err != nil {
+			s.writeError(w, err)
+		}
+		return
+	}
+
+	s.writeError(w, err)
+}
+
+func (s *Server) writeError(w http.ResponseWriter, err error) {
+	for _, e := range api.ErrorCodes {
+		if errors.Is(err, e.Err) {
+			writeJSON(w, e.Status, api.ErrorResponse{Error: err.Error(), Code: e.Code})
+			return
+		}
+	}
+
+	s.reportError(err)
+	writeJSON(w, http.StatusInternalServerError, api.ErrorResponse{Error: http.StatusText(http.StatusInternalServerError)})
+}
+
+func (s *Server) reportError(err error) {
+	if s.errorHandler != nil {
+		s.errorHandler(err)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+/* decodeBody decodes the JSON request body into body */
+func decodeBody(w http.ResponseWriter, r *http.Request, body any) error {
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(body)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidBody, err)
+	}
+
+	return nil
+}
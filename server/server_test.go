@@ -0,0 +1,228 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pokt-foundation/portal-db/api"
+	"github.com/pokt-foundation/portal-db/driver"
+	"github.com/pokt-foundation/portal-db/memdriver"
+	"github.com/pokt-foundation/portal-db/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	d := memdriver.NewMemDriver()
+	ts := httptest.NewServer(NewServer(d))
+
+	t.Cleanup(func() {
+		_ = d.Close(context.Background())
+		ts.Close()
+	})
+
+	return ts
+}
+
+/* do sends a request with body encoded as JSON and decodes the response into out, returning the status code */
+func do(t *testing.T, ts *httptest.Server, method, path string, body, out any) int {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequest(method, ts.URL+path, reader)
+	require.NoError(t, err)
+
+	resp, err := ts.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	if out != nil {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+	}
+
+	return resp.StatusCode
+}
+
+func TestServer_Applications(t *testing.T) {
+	c := require.New(t)
+	ts := newTestServer(t)
+
+	var app types.Application
+	c.Equal(http.StatusCreated, do(t, ts, http.MethodPost, "/application", &types.Application{
+		UserID: "user_1",
+		Name:   "pokt_app_1",
+		Limit:  types.AppLimit{PayPlan: types.PayPlan{Type: types.FreetierV0}},
+	}, &app))
+	c.NotEmpty(app.ID)
+
+	var read types.Application
+	c.Equal(http.StatusOK, do(t, ts, http.MethodGet, "/application/"+app.ID, nil, &read))
+	c.Equal("pokt_app_1", read.Name)
+	c.Equal(250000, read.Limit.PayPlan.Limit)
+
+	c.Equal(http.StatusNoContent, do(t, ts, http.MethodPut, "/application/"+app.ID, &types.UpdateApplication{Name: "pokt_app_renamed"}, nil))
+
+	var page types.ApplicationsPage
+	c.Equal(http.StatusOK, do(t, ts, http.MethodGet, "/application/page?limit=10&userID=user_1", nil, &page))
+	c.Len(page.Applications, 1)
+	c.Equal("pokt_app_renamed", page.Applications[0].Name)
+
+	var updated []*types.Application
+	c.Equal(http.StatusOK, do(t, ts, http.MethodGet, "/application/updated?since=2022-11-11T11:11:11Z", nil, &updated))
+	c.Len(updated, 1)
+
+	c.Equal(http.StatusNoContent, do(t, ts, http.MethodPut, "/application/"+app.ID, &types.UpdateApplication{Remove: true}, nil))
+	c.Equal(http.StatusOK, do(t, ts, http.MethodGet, "/application/"+app.ID, nil, &read))
+	c.Equal(types.AwaitingGracePeriod, read.Status)
+
+	var payPlans []*types.PayPlan
+	c.Equal(http.StatusOK, do(t, ts, http.MethodGet, "/pay_plan", nil, &payPlans))
+	c.NotEmpty(payPlans)
+}
+
+func TestServer_LoadBalancersAndBlockchains(t *testing.T) {
+	c := require.New(t)
+	ts := newTestServer(t)
+
+	var lb types.LoadBalancer
+	c.Equal(http.StatusCreated, do(t, ts, http.MethodPost, "/load_balancer", &types.LoadBalancer{
+		Name:  "pokt_lb_1",
+		Users: []types.UserAccess{{UserID: "user_1", Email: "owner@test.com"}},
+	}, &lb))
+
+	c.Equal(http.StatusNoContent, do(t, ts, http.MethodPost, "/load_balancer/"+lb.ID+"/user",
+		&types.UserAccess{UserID: "user_2", Email: "member@test.com", RoleName: types.RoleMember}, nil))
+	c.Equal(http.StatusNoContent, do(t, ts, http.MethodPut, "/load_balancer/"+lb.ID+"/user/user_2",
+		&types.UpdateUserAccess{RoleName: types.RoleAdmin}, nil))
+
+	var userRoles map[string]map[string][]types.PermissionsEnum
+	c.Equal(http.StatusOK, do(t, ts, http.MethodGet, "/user_roles", nil, &userRoles))
+	c.Equal([]types.PermissionsEnum{types.ReadEndpoint, types.WriteEndpoint}, userRoles["user_2"][lb.ID])
+
+	c.Equal(http.StatusNoContent, do(t, ts, http.MethodDelete, "/load_balancer/"+lb.ID+"/user/user_2", nil, nil))
+	c.Equal(http.StatusNoContent, do(t, ts, http.MethodPut, "/load_balancer/"+lb.ID, &types.UpdateLoadBalancer{Name: "pokt_lb_renamed"}, nil))
+
+	var read types.LoadBalancer
+	c.Equal(http.StatusOK, do(t, ts, http.MethodGet, "/load_balancer/"+lb.ID, nil, &read))
+	c.Equal("pokt_lb_renamed", read.Name)
+	c.Len(read.Users, 1)
+
+	var blockchain types.Blockchain
+	c.Equal(http.StatusCreated, do(t, ts, http.MethodPost, "/blockchain", &types.Blockchain{ID: "0001", Blockchain: "pokt-mainnet"}, &blockchain))
+	c.Equal(http.StatusCreated, do(t, ts, http.MethodPost, "/redirect",
+		&types.Redirect{BlockchainID: "0001", Alias: "pokt-mainnet", Domain: "pokt.network", LoadBalancerID: lb.ID}, nil))
+	c.Equal(http.StatusNoContent, do(t, ts, http.MethodPut, "/blockchain/0001/activate", &ActivateChainRequest{Active: true}, nil))
+
+	c.Equal(http.StatusOK, do(t, ts, http.MethodGet, "/blockchain/0001", nil, &blockchain))
+	c.True(blockchain.Active)
+	c.Len(blockchain.Redirects, 1)
+}
+
+func TestServer_Errors(t *testing.T) {
+	c := require.New(t)
+	ts := newTestServer(t)
+
+	tests := []struct {
+		name           string
+		method, path   string
+		body           any
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:   "Should fail with an invalid app status",
+			method: http.MethodPost, path: "/application",
+			body:           &types.Application{Status: "NOT_A_STATUS"},
+			expectedStatus: http.StatusBadRequest, expectedCode: "INVALID_APP_STATUS",
+		},
+		{
+			name:   "Should fail without an update",
+			method: http.MethodPut, path: "/application/app_1",
+			expectedStatus: http.StatusBadRequest, expectedCode: "INVALID_BODY",
+		},
+		{
+			name:   "Should fail with a missing app",
+			method: http.MethodGet, path: "/application/app_1",
+			expectedStatus: http.StatusNotFound, expectedCode: "NOT_FOUND",
+		},
+		{
+			name:   "Should fail with a load balancer without users",
+			method: http.MethodPost, path: "/load_balancer",
+			body:           &types.LoadBalancer{Name: "pokt_lb_1"},
+			expectedStatus: http.StatusBadRequest, expectedCode: "LB_MUST_HAVE_USER",
+		},
+		{
+			name:   "Should fail setting a user to owner",
+			method: http.MethodPut, path: "/load_balancer/lb_1/user/user_1",
+			body:           &types.UpdateUserAccess{RoleName: types.RoleOwner},
+			expectedStatus: http.StatusBadRequest, expectedCode: "CANNOT_SET_TO_OWNER",
+		},
+		{
+			name:   "Should fail with an invalid page limit",
+			method: http.MethodGet, path: "/load_balancer/page?limit=-1",
+			expectedStatus: http.StatusBadRequest, expectedCode: "INVALID_PAGE_LIMIT",
+		},
+		{
+			name:   "Should fail with an invalid query parameter",
+			method: http.MethodGet, path: "/blockchain/updated?since=yesterday",
+			expectedStatus: http.StatusBadRequest, expectedCode: "INVALID_QUERY",
+		},
+		{
+			name:   "Should fail with an unknown route",
+			method: http.MethodGet, path: "/application/app_1/unknown",
+			expectedStatus: http.StatusNotFound, expectedCode: "UNKNOWN_ROUTE",
+		},
+		{
+			name:   "Should fail with a method the route does not have",
+			method: http.MethodPatch, path: "/application/app_1",
+			expectedStatus: http.StatusMethodNotAllowed, expectedCode: "METHOD_NOT_ALLOWED",
+		},
+		{
+			name:   "Should not remove an app named after a literal route",
+			method: http.MethodDelete, path: "/application/page",
+			expectedStatus: http.StatusMethodNotAllowed, expectedCode: "METHOD_NOT_ALLOWED",
+		},
+		{
+			name:   "Should not update a load balancer named after a literal route",
+			method: http.MethodPut, path: "/load_balancer/updated",
+			expectedStatus: http.StatusMethodNotAllowed, expectedCode: "METHOD_NOT_ALLOWED",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var resp api.ErrorResponse
+			c.Equal(test.expectedStatus, do(t, ts, test.method, test.path, test.body, &resp))
+			c.Equal(test.expectedCode, resp.Code)
+			c.NotEmpty(resp.Error)
+		})
+	}
+}
+
+func TestServer_InternalError(t *testing.T) {
+	c := require.New(t)
+
+	d := &driver.MockDriver{}
+	d.On("ReadApplications", mock.Anything).Return(nil, errors.New("connection refused"))
+
+	var reported error
+	ts := httptest.NewServer(NewServer(d, WithErrorHandler(func(err error) { reported = err })))
+	defer ts.Close()
+
+	var resp api.ErrorResponse
+	c.Equal(http.StatusInternalServerError, do(t, ts, http.MethodGet, "/application", nil, &resp))
+	c.Equal(api.ErrorResponse{Error: "Internal Server Error"}, resp)
+	c.EqualError(reported, "connection refused")
+}
@@ -85,6 +85,7 @@ type (
 		NotificationSettings *UpdateNotificationSettings `json:"notificationSettings,omitempty"`
 		Limit                *AppLimit                   `json:"appLimit,omitempty"`
 		Remove               bool                        `json:"remove,omitempty"`
+		ExpectedUpdatedAt    *time.Time                  `json:"expectedUpdatedAt,omitempty"`
 	}
 	UpdateGatewaySettings struct {
 		ID                   string              `json:"id,omitempty"`
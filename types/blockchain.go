@@ -1,9 +1,20 @@
 package types
 
 import (
+	"errors"
+	"strings"
 	"time"
 )
 
+const (
+	maxHostnameLength = 253
+	maxLabelLength    = 63
+)
+
+var (
+	ErrInvalidDomain = errors.New("invalid domain")
+)
+
 type (
 	Blockchain struct {
 		ID                string           `json:"id"`
@@ -42,4 +53,79 @@ type (
 		ResultKey    string `json:"resultKey"`
 		Allowance    int    `json:"allowance"`
 	}
+	/* Update structs */
+	UpdateBlockchain struct {
+		Altruist          string            `json:"altruist,omitempty"`
+		Blockchain        string            `json:"blockchain,omitempty"`
+		ChainID           string            `json:"chainID,omitempty"`
+		ChainIDCheck      string            `json:"chainIDCheck,omitempty"`
+		Description       string            `json:"description,omitempty"`
+		EnforceResult     string            `json:"enforceResult,omitempty"`
+		Network           string            `json:"network,omitempty"`
+		Path              string            `json:"path,omitempty"`
+		SyncCheck         string            `json:"syncCheck,omitempty"`
+		Ticker            string            `json:"ticker,omitempty"`
+		BlockchainAliases []string          `json:"blockchainAliases,omitempty"`
+		LogLimitBlocks    int               `json:"logLimitBlocks,omitempty"`
+		RequestTimeout    int               `json:"requestTimeout,omitempty"`
+		SyncCheckOptions  *SyncCheckOptions `json:"syncCheckOptions,omitempty"`
+	}
+	UpdateRedirect struct {
+		Alias          string `json:"alias,omitempty"`
+		Domain         string `json:"domain,omitempty"`
+		LoadBalancerID string `json:"loadBalancerID,omitempty"`
+	}
+	/* RedirectFilter selects the redirects of a blockchain or load balancer, empty fields match every redirect */
+	RedirectFilter struct {
+		BlockchainID   string `json:"blockchainID,omitempty"`
+		LoadBalancerID string `json:"loadBalancerID,omitempty"`
+	}
 )
+
+func (u *UpdateBlockchain) Validate() error {
+	if u == nil {
+		return ErrNoFieldsToUpdate
+	}
+
+	return nil
+}
+
+func (r *Redirect) Validate() error {
+	if !validHostname(r.Domain) {
+		return ErrInvalidDomain
+	}
+
+	return nil
+}
+
+func (u *UpdateRedirect) Validate() error {
+	if u == nil {
+		return ErrNoFieldsToUpdate
+	}
+	if u.Domain != "" && !validHostname(u.Domain) {
+		return ErrInvalidDomain
+	}
+
+	return nil
+}
+
+/* validHostname returns true if the domain is a hostname as defined by RFC 1123 */
+func validHostname(domain string) bool {
+	if domain == "" || len(domain) > maxHostnameLength {
+		return false
+	}
+
+	for _, label := range strings.Split(domain, ".") {
+		if label == "" || len(label) > maxLabelLength || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+
+		for _, char := range label {
+			if !(char >= 'a' && char <= 'z' || char >= 'A' && char <= 'Z' || char >= '0' && char <= '9' || char == '-') {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -1,13 +1,27 @@
 package types
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrUnknownTable = errors.New("unknown table")
+)
+
 type (
 	Table  string
 	Action string
 
 	Notification struct {
-		Table  Table
-		Action Action
-		Data   SavedOnDB
+		Sequence int64     `json:"sequence,omitempty"`
+		Table    Table     `json:"table,omitempty"`
+		Action   Action    `json:"action"`
+		Data     SavedOnDB `json:"data,omitempty"`
+		// Previous and ChangedFields are only set on UPDATE, ChangedFields holds the names of the changed columns
+		Previous      SavedOnDB `json:"previous,omitempty"`
+		ChangedFields []string  `json:"changedFields,omitempty"`
 	}
 )
 
@@ -31,6 +45,8 @@ const (
 	ActionInsert Action = "INSERT"
 	ActionUpdate Action = "UPDATE"
 	ActionDelete Action = "DELETE"
+	// ActionResync is sent when the connection to the database was reestablished and notifications may have been lost
+	ActionResync Action = "RESYNC"
 )
 
 type SavedOnDB interface {
@@ -76,3 +92,72 @@ func (r *Redirect) Table() Table {
 func (o *SyncCheckOptions) Table() Table {
 	return TableSyncCheckOptions
 }
+
+/* UnmarshalJSON decodes Data and Previous into the struct of the notification's table */
+func (n *Notification) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Sequence      int64           `json:"sequence"`
+		Table         Table           `json:"table"`
+		Action        Action          `json:"action"`
+		Data          json.RawMessage `json:"data"`
+		Previous      json.RawMessage `json:"previous"`
+		ChangedFields []string        `json:"changedFields"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*n = Notification{Sequence: raw.Sequence, Table: raw.Table, Action: raw.Action, ChangedFields: raw.ChangedFields}
+
+	var err error
+	if n.Data, err = decodeSavedOnDB(raw.Table, raw.Data); err != nil {
+		return err
+	}
+	if n.Previous, err = decodeSavedOnDB(raw.Table, raw.Previous); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func decodeSavedOnDB(table Table, data json.RawMessage) (SavedOnDB, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+
+	var row SavedOnDB
+	switch table {
+	case TableLoadBalancers:
+		row = &LoadBalancer{}
+	case TableStickinessOptions:
+		row = &StickyOptions{}
+	case TableUserAccess:
+		row = &UserAccess{}
+	case TableLbApps:
+		row = &LbApp{}
+	case TableApplications:
+		row = &Application{}
+	case TableAppLimits:
+		row = &AppLimit{}
+	case TableGatewayAAT:
+		row = &GatewayAAT{}
+	case TableGatewaySettings:
+		row = &GatewaySettings{}
+	case TableNotificationSettings:
+		row = &NotificationSettings{}
+	case TableBlockchains:
+		row = &Blockchain{}
+	case TableRedirects:
+		row = &Redirect{}
+	case TableSyncCheckOptions:
+		row = &SyncCheckOptions{}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTable, table)
+	}
+
+	if err := json.Unmarshal(data, row); err != nil {
+		return nil, err
+	}
+
+	return row, nil
+}
@@ -44,9 +44,10 @@ type (
 	}
 	/* Update structs */
 	UpdateLoadBalancer struct {
-		Name          string               `json:"name,omitempty"`
-		StickyOptions *UpdateStickyOptions `json:"stickinessOptions,omitempty"`
-		Remove        bool                 `json:"remove,omitempty"`
+		Name              string               `json:"name,omitempty"`
+		StickyOptions     *UpdateStickyOptions `json:"stickinessOptions,omitempty"`
+		Remove            bool                 `json:"remove,omitempty"`
+		ExpectedUpdatedAt *time.Time           `json:"expectedUpdatedAt,omitempty"`
 	}
 	UpdateStickyOptions struct {
 		ID            string   `json:"id,omitempty"`
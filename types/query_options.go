@@ -0,0 +1,65 @@
+package types
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	DefaultPageLimit = 100
+	MaxPageLimit     = 1000
+)
+
+var (
+	ErrInvalidPageLimit = errors.New("invalid page limit")
+)
+
+type (
+	/* QueryOptions pages through and filters Applications and LoadBalancers.
+	Status, PayPlan and Dummy only apply to Applications. */
+	QueryOptions struct {
+		Limit        int         `json:"limit,omitempty"`
+		Cursor       string      `json:"cursor,omitempty"`
+		UserID       string      `json:"userID,omitempty"`
+		Status       AppStatus   `json:"status,omitempty"`
+		PayPlan      PayPlanType `json:"payPlan,omitempty"`
+		Dummy        *bool       `json:"dummy,omitempty"`
+		CreatedAfter time.Time   `json:"createdAfter,omitempty"`
+	}
+
+	/* Page structs */
+	ApplicationsPage struct {
+		Applications []*Application `json:"applications"`
+		NextCursor   string         `json:"nextCursor,omitempty"`
+	}
+	LoadBalancersPage struct {
+		LoadBalancers []*LoadBalancer `json:"loadBalancers"`
+		NextCursor    string          `json:"nextCursor,omitempty"`
+	}
+)
+
+func (o *QueryOptions) Validate() error {
+	if o == nil {
+		return nil
+	}
+	if o.Limit < 0 || o.Limit > MaxPageLimit {
+		return ErrInvalidPageLimit
+	}
+	if !ValidAppStatuses[o.Status] {
+		return ErrInvalidAppStatus
+	}
+	if !ValidPayPlanTypes[o.PayPlan] {
+		return ErrInvalidPayPlanType
+	}
+
+	return nil
+}
+
+/* PageLimit returns the number of items a page should hold, falling back to DefaultPageLimit */
+func (o *QueryOptions) PageLimit() int {
+	if o == nil || o.Limit == 0 {
+		return DefaultPageLimit
+	}
+
+	return o.Limit
+}
@@ -0,0 +1,163 @@
+package types
+
+import (
+	"errors"
+)
+
+const (
+	DefaultSubscriptionBufferSize = 32
+
+	OverflowBlock      OverflowPolicy = "BLOCK"
+	OverflowDropOldest OverflowPolicy = "DROP_OLDEST"
+	OverflowDisconnect OverflowPolicy = "DISCONNECT"
+)
+
+var (
+	ErrInvalidBufferSize     = errors.New("invalid buffer size")
+	ErrInvalidOverflowPolicy = errors.New("invalid overflow policy")
+
+	ValidOverflowPolicies = map[OverflowPolicy]bool{
+		"":                 true, // defaults to OverflowBlock
+		OverflowBlock:      true,
+		OverflowDropOldest: true,
+		OverflowDisconnect: true,
+	}
+)
+
+type (
+	/* OverflowPolicy decides what happens when a subscriber's buffer is full.
+	OverflowBlock waits for the subscriber, OverflowDropOldest discards its oldest buffered notification
+	and OverflowDisconnect sends it an ActionResync notification and closes its channel */
+	OverflowPolicy string
+
+	/* SubscriptionFilter selects the notifications a subscriber receives, empty fields match everything.
+	EntityID matches the ID of the Application, LoadBalancer or Blockchain the notification belongs to */
+	SubscriptionFilter struct {
+		Tables   []Table  `json:"tables,omitempty"`
+		Actions  []Action `json:"actions,omitempty"`
+		EntityID string   `json:"entityID,omitempty"`
+	}
+
+	/* SubscriptionOptions configures a subscription, when FromSequence is set stored events after it are replayed first */
+	SubscriptionOptions struct {
+		SubscriptionFilter
+		FromSequence *int64         `json:"fromSequence,omitempty"`
+		BufferSize   int            `json:"bufferSize,omitempty"`
+		Overflow     OverflowPolicy `json:"overflow,omitempty"`
+	}
+)
+
+func (o *SubscriptionOptions) Validate() error {
+	if o == nil {
+		return nil
+	}
+	if o.BufferSize < 0 {
+		return ErrInvalidBufferSize
+	}
+	if !ValidOverflowPolicies[o.Overflow] {
+		return ErrInvalidOverflowPolicy
+	}
+
+	return nil
+}
+
+/* Buffer returns the number of notifications the subscriber can hold, falling back to DefaultSubscriptionBufferSize */
+func (o *SubscriptionOptions) Buffer() int {
+	if o == nil || o.BufferSize == 0 {
+		return DefaultSubscriptionBufferSize
+	}
+
+	return o.BufferSize
+}
+
+/* Policy returns the subscription's overflow policy, falling back to OverflowBlock */
+func (o *SubscriptionOptions) Policy() OverflowPolicy {
+	if o == nil || o.Overflow == "" {
+		return OverflowBlock
+	}
+
+	return o.Overflow
+}
+
+/* Matches returns true if the notification passes the filter, ActionResync notifications always pass */
+func (f *SubscriptionFilter) Matches(n *Notification) bool {
+	if f == nil || n.Action == ActionResync {
+		return true
+	}
+
+	if len(f.Tables) != 0 && !containsTable(f.Tables, n.Table) {
+		return false
+	}
+	if len(f.Actions) != 0 && !containsAction(f.Actions, n.Action) {
+		return false
+	}
+	if f.EntityID != "" && !containsString(n.EntityIDs(), f.EntityID) {
+		return false
+	}
+
+	return true
+}
+
+/* EntityIDs returns the IDs of the entities the notification's data belongs to */
+func (n *Notification) EntityIDs() []string {
+	switch data := n.Data.(type) {
+	case *Application:
+		return []string{data.ID}
+	case *AppLimit:
+		return []string{data.ID}
+	case *GatewayAAT:
+		return []string{data.ID}
+	case *GatewaySettings:
+		return []string{data.ID}
+	case *NotificationSettings:
+		return []string{data.ID}
+
+	case *LoadBalancer:
+		return []string{data.ID}
+	case *StickyOptions:
+		return []string{data.ID}
+	case *UserAccess:
+		return []string{data.ID}
+	case *LbApp:
+		return []string{data.LbID, data.AppID}
+
+	case *Blockchain:
+		return []string{data.ID}
+	case *SyncCheckOptions:
+		return []string{data.BlockchainID}
+	case *Redirect:
+		return []string{data.BlockchainID, data.LoadBalancerID}
+	}
+
+	return nil
+}
+
+func containsTable(tables []Table, table Table) bool {
+	for _, t := range tables {
+		if t == table {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsAction(actions []Action, action Action) bool {
+	for _, a := range actions {
+		if a == action {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}